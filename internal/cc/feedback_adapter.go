@@ -99,7 +99,7 @@ func (f *FeedbackAdapter) unpackRunLengthChunk(
 			ssrc:           0,
 			sequenceNumber: i,
 		}
-		if ack, ok := f.history.get(key); ok {
+		if ack, ok := f.history.acknowledge(key); ok {
 			if chunk.PacketStatusSymbol != rtcp.TypeTCCPacketNotReceived {
 				if len(deltas)-1 < deltaIndex {
 					return deltaIndex, refTime, result, errInvalidFeedback
@@ -127,7 +127,7 @@ func (f *FeedbackAdapter) unpackStatusVectorChunk(
 			ssrc:           0,
 			sequenceNumber: start + uint16(i), //nolint:gosec // G115
 		}
-		if ack, ok := f.history.get(key); ok {
+		if ack, ok := f.history.acknowledge(key); ok {
 			if symbol != rtcp.TypeTCCPacketNotReceived {
 				if len(deltas)-1 < deltaIndex {
 					return deltaIndex, refTime, result, errInvalidFeedback
@@ -144,6 +144,16 @@ func (f *FeedbackAdapter) unpackStatusVectorChunk(
 	return deltaIndex, refTime, result, nil
 }
 
+// OutstandingBytes returns the size of the packets that were sent after since, and not
+// yet reported as received or lost by feedback. Packets sent before since timed out, and
+// do not count as outstanding in later calls with an earlier since either.
+func (f *FeedbackAdapter) OutstandingBytes(since time.Time) int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.history.outstandingBytes(since)
+}
+
 // OnTransportCCFeedback converts incoming TWCC RTCP packet feedback to
 // Acknowledgments.
 func (f *FeedbackAdapter) OnTransportCCFeedback(
@@ -200,7 +210,7 @@ func (f *FeedbackAdapter) OnRFC8888Feedback(_ time.Time, feedback *rtcp.CCFeedba
 				ssrc:           rb.MediaSSRC,
 				sequenceNumber: sequenceNumber,
 			}
-			if ack, ok := f.history.get(key); ok {
+			if ack, ok := f.history.acknowledge(key); ok {
 				if mb.Received {
 					delta := time.Duration((float64(mb.ArrivalTimeOffset) / 1024.0) * float64(time.Second))
 					ack.Arrival = referenceTime.Add(-delta)
@@ -219,10 +229,23 @@ type feedbackHistoryKey struct {
 	sequenceNumber uint16
 }
 
+// feedbackHistoryEntry is a sent packet, and whether it counts as outstanding. A packet is
+// outstanding until feedback reports it, it times out or it is evicted.
+type feedbackHistoryEntry struct {
+	ack         Acknowledgment
+	outstanding bool
+}
+
 type feedbackHistory struct {
 	size      int
 	evictList *list.List
 	items     map[feedbackHistoryKey]*list.Element
+
+	// outstanding is the size of the outstanding packets.
+	outstanding int
+	// timedOut is the most recent packet checked for the timeout, the packets after it
+	// toward the back of evictList timed out, too.
+	timedOut *list.Element
 }
 
 func newFeedbackHistory(size int) *feedbackHistory {
@@ -233,32 +256,78 @@ func newFeedbackHistory(size int) *feedbackHistory {
 	}
 }
 
-func (f *feedbackHistory) get(key feedbackHistoryKey) (Acknowledgment, bool) {
+// acknowledge returns a sent packet, and marks it as reported by feedback.
+func (f *feedbackHistory) acknowledge(key feedbackHistoryKey) (Acknowledgment, bool) {
 	ent, ok := f.items[key]
 	if ok {
-		if ack, ok := ent.Value.(Acknowledgment); ok {
-			return ack, true
+		if entry, ok := ent.Value.(*feedbackHistoryEntry); ok {
+			f.settle(entry)
+
+			return entry.ack, true
 		}
 	}
 
 	return Acknowledgment{}, false
 }
 
+// outstandingBytes returns the size of the packets sent after since that were not acknowledged.
+// Only the packets that were not checked for the timeout yet are visited.
+func (f *feedbackHistory) outstandingBytes(since time.Time) int {
+	next := f.evictList.Back()
+	if f.timedOut != nil {
+		next = f.timedOut.Prev()
+	}
+	for ; next != nil; next = next.Prev() {
+		entry, ok := next.Value.(*feedbackHistoryEntry)
+		if ok && !entry.ack.Departure.Before(since) {
+			break
+		}
+		if ok {
+			f.settle(entry)
+		}
+		f.timedOut = next
+	}
+
+	return f.outstanding
+}
+
+// settle removes a packet from the outstanding packets.
+func (f *feedbackHistory) settle(entry *feedbackHistoryEntry) {
+	if entry.outstanding {
+		entry.outstanding = false
+		f.outstanding -= entry.ack.Size
+	}
+}
+
+// remove removes a packet from the history.
+func (f *feedbackHistory) remove(ent *list.Element) {
+	if ent == f.timedOut {
+		f.timedOut = ent.Next()
+	}
+	f.evictList.Remove(ent)
+	if entry, ok := ent.Value.(*feedbackHistoryEntry); ok {
+		f.settle(entry)
+		key := feedbackHistoryKey{
+			ssrc:           entry.ack.SSRC,
+			sequenceNumber: entry.ack.SequenceNumber,
+		}
+		delete(f.items, key)
+	}
+}
+
 func (f *feedbackHistory) add(ack Acknowledgment) {
 	key := feedbackHistoryKey{
 		ssrc:           ack.SSRC,
 		sequenceNumber: ack.SequenceNumber,
 	}
-	// Check for existing
+	// Replace existing
 	if ent, ok := f.items[key]; ok {
-		f.evictList.MoveToFront(ent)
-		ent.Value = ack
-
-		return
+		f.remove(ent)
 	}
 	// Add new
-	ent := f.evictList.PushFront(ack)
+	ent := f.evictList.PushFront(&feedbackHistoryEntry{ack: ack, outstanding: true})
 	f.items[key] = ent
+	f.outstanding += ack.Size
 	// Evict if necessary
 	if f.evictList.Len() > f.size {
 		f.removeOldest()
@@ -267,13 +336,6 @@ func (f *feedbackHistory) add(ack Acknowledgment) {
 
 func (f *feedbackHistory) removeOldest() {
 	if ent := f.evictList.Back(); ent != nil {
-		f.evictList.Remove(ent)
-		if ack, ok := ent.Value.(Acknowledgment); ok {
-			key := feedbackHistoryKey{
-				ssrc:           ack.SSRC,
-				sequenceNumber: ack.SequenceNumber,
-			}
-			delete(f.items, key)
-		}
+		f.remove(ent)
 	}
 }
@@ -1007,3 +1007,54 @@ func TestFeedbackAdapterTWCC(t *testing.T) {
 		})
 	})
 }
+
+func TestFeedbackAdapterOutstandingBytes(t *testing.T) {
+	t0 := time.Time{}.Add(time.Hour)
+	adapter := NewFeedbackAdapter()
+	size := 0
+	for i := uint16(0); i < 5; i++ {
+		pkt := getPacketWithTransportCCExt(t, i)
+		size = pkt.Header.MarshalSize() + 1200
+		assert.NoError(t, adapter.OnSent(
+			t0.Add(time.Duration(i)*time.Millisecond), &pkt.Header, 1200,
+			interceptor.Attributes{TwccExtensionAttributesKey: hdrExtID},
+		))
+	}
+	assert.Equal(t, 5*size, adapter.OutstandingBytes(t0))
+	assert.Equal(t, 2*size, adapter.OutstandingBytes(t0.Add(3*time.Millisecond)))
+
+	// Packets reported as lost are no longer outstanding either
+	feedback := &rtcp.TransportLayerCC{
+		BaseSequenceNumber: 0,
+		PacketStatusCount:  3,
+		PacketChunks: []rtcp.PacketStatusChunk{
+			&rtcp.RunLengthChunk{
+				Type:               rtcp.TypeTCCRunLengthChunk,
+				PacketStatusSymbol: rtcp.TypeTCCPacketNotReceived,
+				RunLength:          3,
+			},
+		},
+	}
+	_, err := adapter.OnTransportCCFeedback(t0, feedback)
+	assert.NoError(t, err)
+	assert.Equal(t, 2*size, adapter.OutstandingBytes(t0))
+
+	// Repeated feedback does not change the outstanding bytes
+	_, err = adapter.OnTransportCCFeedback(t0, feedback)
+	assert.NoError(t, err)
+	assert.Equal(t, 2*size, adapter.OutstandingBytes(t0))
+
+	// Timed out packets stay timed out
+	assert.Equal(t, size, adapter.OutstandingBytes(t0.Add(4*time.Millisecond)))
+	assert.Equal(t, size, adapter.OutstandingBytes(t0))
+
+	// Evicted packets are no longer outstanding
+	for i := uint16(5); i < 5+250; i++ {
+		pkt := getPacketWithTransportCCExt(t, i)
+		assert.NoError(t, adapter.OnSent(
+			t0.Add(time.Second), &pkt.Header, 1200,
+			interceptor.Attributes{TwccExtensionAttributesKey: hdrExtID},
+		))
+	}
+	assert.Equal(t, 250*size, adapter.OutstandingBytes(t0))
+}
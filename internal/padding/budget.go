@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package padding
+
+import (
+	"sync"
+	"time"
+)
+
+// maxDebt is the duration of media at the padding rate that is remembered, so that
+// padding resumes soon after the media rate drops.
+const maxDebt = 500 * time.Millisecond
+
+// Budget is the number of bytes a pacer may send as padding at a padding rate. Media
+// packets consume the budget as well, so that padding only fills the rate left by media.
+// A budget that is not used within a pacing interval does not build up.
+type Budget struct {
+	lock  sync.Mutex
+	rate  int
+	bytes float64
+	last  time.Time
+}
+
+// SetRate sets the padding rate in bits per second. A zero rate disables padding.
+func (b *Budget) SetRate(rate int) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.rate = rate
+}
+
+// Update adds the budget of the time since the last update.
+func (b *Budget) Update(now time.Time) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if !b.last.IsZero() && now.After(b.last) {
+		b.bytes = min(b.bytes, 0) + float64(b.rate)*now.Sub(b.last).Seconds()/8
+		b.bytes = max(b.bytes, -float64(b.rate)*maxDebt.Seconds()/8)
+	}
+	b.last = now
+}
+
+// Consume subtracts the size of a sent packet from the budget.
+func (b *Budget) Consume(size int) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.bytes -= float64(size)
+}
+
+// Available returns true if padding may be sent.
+func (b *Budget) Available() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.rate > 0 && b.bytes > 0
+}
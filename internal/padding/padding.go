@@ -0,0 +1,214 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+// Package padding generates the padding that pacers send to sustain a padding rate.
+package padding
+
+import (
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/rtp"
+)
+
+const (
+	// historySize is the number of media packets per stream kept for payload padding.
+	historySize = 32
+	// maxPaddingAge is the age after which a media packet is not retransmitted as padding
+	// anymore.
+	maxPaddingAge = time.Second
+	// paddingSize is the padding of a padding-only packet, the padding length is a single byte.
+	paddingSize = 255
+	// rtxHeaderLength is the length of the original sequence number in an RTX payload.
+	rtxHeaderLength = 2
+)
+
+// sentPacket is a media packet in the history of a stream.
+type sentPacket struct {
+	header  rtp.Header
+	payload []byte
+	sent    time.Time
+	order   uint64
+	used    int
+}
+
+// stream is a local stream with an RTX SSRC that can carry padding.
+type stream struct {
+	ssrc           uint32
+	rtxSSRC        uint32
+	rtxPayloadType uint8
+
+	// rtxSequenceNumber is the next sequence number on the RTX SSRC, valid if numbered is set.
+	rtxSequenceNumber uint16
+	numbered          bool
+
+	// lastMedia orders the streams by their last media packet, zero if none was sent.
+	lastMedia uint64
+	timestamp uint32
+	history   []*sentPacket
+	next      int
+}
+
+// Generator generates padding packets on the RTX SSRC of local streams. It prefers
+// payload padding, which retransmits recently sent media packets with RTX, and falls back
+// to padding-only packets if no recent media packet is available. Streams without an RTX
+// SSRC cannot carry padding.
+//
+// Padding packets share the RTX SSRC with the retransmissions of the application, so all
+// packets sent on an RTX SSRC must be passed to OnSent, which numbers them consecutively.
+type Generator struct {
+	lock    sync.Mutex
+	streams []*stream
+	bySSRC  map[uint32]*stream
+	order   uint64
+}
+
+// NewGenerator returns a new Generator.
+func NewGenerator() *Generator {
+	return &Generator{
+		bySSRC: map[uint32]*stream{},
+	}
+}
+
+// AddStream registers a local stream. Streams without an RTX SSRC and payload type are
+// ignored.
+func (g *Generator) AddStream(info *interceptor.StreamInfo) {
+	if info.SSRCRetransmission == 0 || info.PayloadTypeRetransmission == 0 {
+		return
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if _, ok := g.bySSRC[info.SSRC]; ok {
+		return
+	}
+	s := &stream{
+		ssrc:           info.SSRC,
+		rtxSSRC:        info.SSRCRetransmission,
+		rtxPayloadType: info.PayloadTypeRetransmission,
+		history:        make([]*sentPacket, historySize),
+	}
+	g.streams = append(g.streams, s)
+	g.bySSRC[s.ssrc] = s
+	g.bySSRC[s.rtxSSRC] = s
+}
+
+// RemoveStream removes the local stream with the given media SSRC.
+func (g *Generator) RemoveStream(ssrc uint32) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	s, ok := g.bySSRC[ssrc]
+	if !ok || s.ssrc != ssrc {
+		return
+	}
+	delete(g.bySSRC, s.ssrc)
+	delete(g.bySSRC, s.rtxSSRC)
+	for i, other := range g.streams {
+		if other == s {
+			g.streams = append(g.streams[:i], g.streams[i+1:]...)
+
+			break
+		}
+	}
+}
+
+// OnSent must be called for every packet the pacer sends, right before it is written.
+// Media packets are kept for payload padding, and packets on an RTX SSRC get their
+// sequence number rewritten, so that retransmissions and padding are numbered
+// consecutively.
+func (g *Generator) OnSent(header *rtp.Header, payload []byte, now time.Time) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	s, ok := g.bySSRC[header.SSRC]
+	if !ok {
+		return
+	}
+
+	if header.SSRC == s.rtxSSRC {
+		if !s.numbered {
+			s.rtxSequenceNumber = header.SequenceNumber
+			s.numbered = true
+		}
+		header.SequenceNumber = s.rtxSequenceNumber
+		s.rtxSequenceNumber++
+
+		return
+	}
+
+	g.order++
+	s.lastMedia = g.order
+	s.timestamp = header.Timestamp
+	if len(payload) == 0 {
+		return
+	}
+	hdr := header.Clone()
+	s.history[s.next] = &sentPacket{
+		header:  hdr,
+		payload: append([]byte(nil), payload...),
+		sent:    now,
+		order:   g.order,
+	}
+	s.next = (s.next + 1) % len(s.history)
+}
+
+// Next returns the next padding packet, or false if no stream can carry padding yet. The
+// sequence number of the packet is assigned by OnSent.
+func (g *Generator) Next(now time.Time) (*rtp.Header, []byte, bool) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	// Payload padding with the recent media packet that was retransmitted least often,
+	// and the most recent one of those.
+	var (
+		best       *sentPacket
+		bestStream *stream
+	)
+	for _, s := range g.streams {
+		for _, p := range s.history {
+			if p == nil || now.Sub(p.sent) > maxPaddingAge {
+				continue
+			}
+			if best == nil || p.used < best.used || (p.used == best.used && p.order > best.order) {
+				best, bestStream = p, s
+			}
+		}
+	}
+	if best != nil {
+		best.used++
+		header := best.header.Clone()
+		header.SSRC = bestStream.rtxSSRC
+		header.PayloadType = bestStream.rtxPayloadType
+		header.Padding = false
+		header.PaddingSize = 0
+		payload := make([]byte, rtxHeaderLength+len(best.payload))
+		binary.BigEndian.PutUint16(payload, best.header.SequenceNumber)
+		copy(payload[rtxHeaderLength:], best.payload)
+
+		return &header, payload, true
+	}
+
+	// Padding-only packets on the stream that sent media most recently.
+	var last *stream
+	for _, s := range g.streams {
+		if s.lastMedia > 0 && (last == nil || s.lastMedia > last.lastMedia) {
+			last = s
+		}
+	}
+	if last == nil {
+		return nil, nil, false
+	}
+
+	return &rtp.Header{
+		Version:     2,
+		Padding:     true,
+		PaddingSize: paddingSize,
+		PayloadType: last.rtxPayloadType,
+		Timestamp:   last.timestamp,
+		SSRC:        last.rtxSSRC,
+	}, nil, true
+}
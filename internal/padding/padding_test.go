@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package padding
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator(t *testing.T) {
+	info := &interceptor.StreamInfo{
+		SSRC:                      1,
+		SSRCRetransmission:        2,
+		PayloadType:               96,
+		PayloadTypeRetransmission: 97,
+	}
+	now := time.Now()
+
+	t.Run("ignores streams without RTX", func(t *testing.T) {
+		g := NewGenerator()
+		g.AddStream(&interceptor.StreamInfo{SSRC: 3, PayloadType: 111})
+		g.OnSent(&rtp.Header{SSRC: 3, SequenceNumber: 5}, []byte{1, 2}, now)
+		_, _, ok := g.Next(now)
+		assert.False(t, ok)
+	})
+
+	t.Run("no padding before media is sent", func(t *testing.T) {
+		g := NewGenerator()
+		g.AddStream(info)
+		_, _, ok := g.Next(now)
+		assert.False(t, ok)
+	})
+
+	t.Run("retransmits recent packets as payload padding", func(t *testing.T) {
+		g := NewGenerator()
+		g.AddStream(info)
+		g.OnSent(&rtp.Header{SSRC: 1, PayloadType: 96, SequenceNumber: 10, Timestamp: 1000}, []byte{1, 2}, now)
+		g.OnSent(&rtp.Header{SSRC: 1, PayloadType: 96, SequenceNumber: 11, Timestamp: 1000, Marker: true}, []byte{3}, now)
+
+		// The most recent packet first, then the one retransmitted least often
+		for _, expected := range []struct {
+			seq     uint16
+			payload []byte
+			marker  bool
+		}{
+			{11, []byte{0, 11, 3}, true},
+			{10, []byte{0, 10, 1, 2}, false},
+			{11, []byte{0, 11, 3}, true},
+		} {
+			header, payload, ok := g.Next(now)
+			require.True(t, ok)
+			assert.Equal(t, uint32(2), header.SSRC)
+			assert.Equal(t, uint8(97), header.PayloadType)
+			assert.Equal(t, uint32(1000), header.Timestamp)
+			assert.Equal(t, expected.marker, header.Marker)
+			assert.False(t, header.Padding)
+			assert.Equal(t, expected.payload, payload, "seq %v", expected.seq)
+		}
+	})
+
+	t.Run("falls back to padding-only packets", func(t *testing.T) {
+		g := NewGenerator()
+		g.AddStream(info)
+		g.OnSent(&rtp.Header{SSRC: 1, PayloadType: 96, SequenceNumber: 10, Timestamp: 1000}, []byte{1, 2}, now)
+
+		header, payload, ok := g.Next(now.Add(2 * time.Second))
+		require.True(t, ok)
+		assert.Empty(t, payload)
+		assert.Equal(t, &rtp.Header{
+			Version:     2,
+			Padding:     true,
+			PaddingSize: 255,
+			PayloadType: 97,
+			Timestamp:   1000,
+			SSRC:        2,
+		}, header)
+	})
+
+	t.Run("numbers RTX packets consecutively", func(t *testing.T) {
+		g := NewGenerator()
+		g.AddStream(info)
+		g.OnSent(&rtp.Header{SSRC: 1, SequenceNumber: 10}, []byte{1}, now)
+
+		// The first retransmission of the application sets the sequence numbers
+		retransmission := &rtp.Header{SSRC: 2, SequenceNumber: 500}
+		g.OnSent(retransmission, []byte{0, 10, 1}, now)
+		assert.Equal(t, uint16(500), retransmission.SequenceNumber)
+
+		header, payload, ok := g.Next(now)
+		require.True(t, ok)
+		g.OnSent(header, payload, now)
+		assert.Equal(t, uint16(501), header.SequenceNumber)
+
+		// Later retransmissions follow the padding
+		retransmission = &rtp.Header{SSRC: 2, SequenceNumber: 501}
+		g.OnSent(retransmission, []byte{0, 10, 1}, now)
+		assert.Equal(t, uint16(502), retransmission.SequenceNumber)
+	})
+
+	t.Run("removes streams", func(t *testing.T) {
+		g := NewGenerator()
+		g.AddStream(info)
+		g.OnSent(&rtp.Header{SSRC: 1, SequenceNumber: 10}, []byte{1}, now)
+		g.RemoveStream(2)
+		_, _, ok := g.Next(now)
+		assert.True(t, ok)
+		g.RemoveStream(1)
+		_, _, ok = g.Next(now)
+		assert.False(t, ok)
+	})
+}
+
+func TestBudget(t *testing.T) {
+	now := time.Now()
+	budget := &Budget{}
+	budget.Update(now)
+	budget.Update(now.Add(5 * time.Millisecond))
+	assert.False(t, budget.Available())
+
+	// 8 kbit/s are 5 bytes in 5ms
+	budget.SetRate(8000)
+	budget.Update(now.Add(10 * time.Millisecond))
+	assert.True(t, budget.Available())
+	budget.Consume(5)
+	assert.False(t, budget.Available())
+
+	// Unused budget does not build up
+	budget.Update(now.Add(15 * time.Millisecond))
+	budget.Update(now.Add(20 * time.Millisecond))
+	budget.Consume(6)
+	assert.False(t, budget.Available())
+
+	// The debt of media above the padding rate is limited to maxDebt
+	budget.Consume(10_000)
+	budget.Update(now.Add(20*time.Millisecond + maxDebt))
+	budget.Update(now.Add(20*time.Millisecond + 2*maxDebt))
+	assert.False(t, budget.Available())
+	budget.Update(now.Add(25*time.Millisecond + 2*maxDebt))
+	assert.True(t, budget.Available())
+}
@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+// Package tmmbr implements the Temporary Maximum Media Stream Bit Rate Request (TMMBR)
+// and Notification (TMMBN) RTCP feedback messages defined in RFC 5104.
+package tmmbr
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+
+	"github.com/pion/rtcp"
+)
+
+const (
+	// FormatTMMBR is the feedback message type of TMMBR.
+	FormatTMMBR uint8 = 3
+	// FormatTMMBN is the feedback message type of TMMBN.
+	FormatTMMBN uint8 = 4
+
+	headerLength = 4
+	ssrcLength   = 4
+	entryLength  = 8
+
+	mantissaBits = 17
+	maxExponent  = 63
+	maxOverhead  = 1<<9 - 1
+)
+
+var (
+	errPacketTooShort = errors.New("packet too short")
+	errWrongType      = errors.New("wrong packet type")
+)
+
+// Entry is a FCI entry of a TMMBR or TMMBN. In a TMMBR, SSRC is the media sender the
+// request applies to. In a TMMBN, it is the SSRC of the receiver that owns the entry.
+// See https://datatracker.ietf.org/doc/html/rfc5104#section-4.2.1.1
+type Entry struct {
+	SSRC uint32
+	// Bitrate is the maximum total media bitrate in bits per second.
+	Bitrate uint64
+	// Overhead is the measured per packet overhead in bytes.
+	Overhead uint16
+}
+
+// Request is a Temporary Maximum Media Stream Bit Rate Request.
+type Request struct {
+	SenderSSRC uint32
+	Entries    []Entry
+}
+
+// Notification is a Temporary Maximum Media Stream Bit Rate Notification.
+type Notification struct {
+	SenderSSRC uint32
+	Entries    []Entry
+}
+
+// FromRaw returns the Request or Notification of a raw RTCP packet, or nil if the packet
+// is neither.
+func FromRaw(raw rtcp.RawPacket) rtcp.Packet {
+	header := raw.Header()
+	if header.Type != rtcp.TypeTransportSpecificFeedback {
+		return nil
+	}
+
+	var pkt rtcp.Packet
+	switch header.Count {
+	case FormatTMMBR:
+		pkt = &Request{}
+	case FormatTMMBN:
+		pkt = &Notification{}
+	default:
+		return nil
+	}
+	if err := pkt.Unmarshal(raw); err != nil {
+		return nil
+	}
+
+	return pkt
+}
+
+// Marshal encodes the Request in binary.
+func (r Request) Marshal() ([]byte, error) {
+	return marshal(FormatTMMBR, r.SenderSSRC, r.Entries)
+}
+
+// Unmarshal decodes the Request from binary.
+func (r *Request) Unmarshal(rawPacket []byte) error {
+	var err error
+	r.SenderSSRC, r.Entries, err = unmarshal(FormatTMMBR, rawPacket)
+
+	return err
+}
+
+// MarshalSize returns the size of the Request once marshaled.
+func (r Request) MarshalSize() int {
+	return marshalSize(r.Entries)
+}
+
+// DestinationSSRC returns the media senders the Request applies to.
+func (r *Request) DestinationSSRC() []uint32 {
+	ssrcs := make([]uint32, 0, len(r.Entries))
+	for _, entry := range r.Entries {
+		ssrcs = append(ssrcs, entry.SSRC)
+	}
+
+	return ssrcs
+}
+
+// Marshal encodes the Notification in binary.
+func (n Notification) Marshal() ([]byte, error) {
+	return marshal(FormatTMMBN, n.SenderSSRC, n.Entries)
+}
+
+// Unmarshal decodes the Notification from binary.
+func (n *Notification) Unmarshal(rawPacket []byte) error {
+	var err error
+	n.SenderSSRC, n.Entries, err = unmarshal(FormatTMMBN, rawPacket)
+
+	return err
+}
+
+// MarshalSize returns the size of the Notification once marshaled.
+func (n Notification) MarshalSize() int {
+	return marshalSize(n.Entries)
+}
+
+// DestinationSSRC returns the SSRC of the media sender the Notification is sent by.
+func (n *Notification) DestinationSSRC() []uint32 {
+	return []uint32{n.SenderSSRC}
+}
+
+func marshalSize(entries []Entry) int {
+	return headerLength + 2*ssrcLength + entryLength*len(entries)
+}
+
+func marshal(format uint8, senderSSRC uint32, entries []Entry) ([]byte, error) {
+	size := marshalSize(entries)
+	header, err := rtcp.Header{
+		Count:  format,
+		Type:   rtcp.TypeTransportSpecificFeedback,
+		Length: uint16(size/4 - 1), //nolint:gosec // G115
+	}.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, size)
+	copy(buf, header)
+	binary.BigEndian.PutUint32(buf[headerLength:], senderSSRC)
+	// The SSRC of media source is not used and set to 0.
+	offset := headerLength + 2*ssrcLength
+	for _, entry := range entries {
+		exponent := 0
+		if n := bits.Len64(entry.Bitrate); n > mantissaBits {
+			exponent = min(n-mantissaBits, maxExponent)
+		}
+		mantissa := entry.Bitrate >> exponent
+		overhead := min(entry.Overhead, maxOverhead)
+
+		binary.BigEndian.PutUint32(buf[offset:], entry.SSRC)
+		//nolint:gosec // G115
+		binary.BigEndian.PutUint32(buf[offset+4:], uint32(exponent)<<26|uint32(mantissa)<<9|uint32(overhead))
+		offset += entryLength
+	}
+
+	return buf, nil
+}
+
+func unmarshal(format uint8, rawPacket []byte) (uint32, []Entry, error) {
+	if len(rawPacket) < headerLength+2*ssrcLength {
+		return 0, nil, errPacketTooShort
+	}
+	var header rtcp.Header
+	if err := header.Unmarshal(rawPacket); err != nil {
+		return 0, nil, err
+	}
+	if header.Type != rtcp.TypeTransportSpecificFeedback || header.Count != format {
+		return 0, nil, errWrongType
+	}
+	size := 4 * (int(header.Length) + 1)
+	if len(rawPacket) < size {
+		return 0, nil, errPacketTooShort
+	}
+
+	senderSSRC := binary.BigEndian.Uint32(rawPacket[headerLength:])
+	entries := []Entry{}
+	for offset := headerLength + 2*ssrcLength; offset+entryLength <= size; offset += entryLength {
+		word := binary.BigEndian.Uint32(rawPacket[offset+4:])
+		entries = append(entries, Entry{
+			SSRC:     binary.BigEndian.Uint32(rawPacket[offset:]),
+			Bitrate:  uint64(word>>9&(1<<mantissaBits-1)) << (word >> 26),
+			Overhead: uint16(word & maxOverhead),
+		})
+	}
+
+	return senderSSRC, entries, nil
+}
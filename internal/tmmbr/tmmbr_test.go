@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package tmmbr
+
+import (
+	"testing"
+
+	"github.com/pion/rtcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTrip(t *testing.T) {
+	request := &Request{
+		SenderSSRC: 0x902f9e2e,
+		Entries: []Entry{
+			{SSRC: 1, Bitrate: 100_000, Overhead: 40},
+			// 5 Mbit/s needs an exponent, and loses the low bits of the mantissa
+			{SSRC: 2, Bitrate: 5_000_000, Overhead: 600},
+		},
+	}
+	raw, err := request.Marshal()
+	require.NoError(t, err)
+	assert.Len(t, raw, request.MarshalSize())
+
+	// TMMBR is not known to the rtcp package, so it is parsed as raw packet
+	pkts, err := rtcp.Unmarshal(raw)
+	require.NoError(t, err)
+	require.Len(t, pkts, 1)
+	rawPacket, ok := pkts[0].(*rtcp.RawPacket)
+	require.True(t, ok)
+
+	parsed, ok := FromRaw(*rawPacket).(*Request)
+	require.True(t, ok)
+	assert.Equal(t, request.SenderSSRC, parsed.SenderSSRC)
+	assert.Equal(t, []Entry{
+		{SSRC: 1, Bitrate: 100_000, Overhead: 40},
+		{SSRC: 2, Bitrate: 5_000_000 >> 6 << 6, Overhead: maxOverhead},
+	}, parsed.Entries)
+	assert.Equal(t, []uint32{1, 2}, parsed.DestinationSSRC())
+
+	notification := &Notification{SenderSSRC: 1, Entries: []Entry{{SSRC: 0x902f9e2e, Bitrate: 100_000}}}
+	raw, err = notification.Marshal()
+	require.NoError(t, err)
+	parsedNotification, ok := FromRaw(raw).(*Notification)
+	require.True(t, ok)
+	assert.Equal(t, notification, parsedNotification)
+
+	// Empty notifications are valid
+	raw, err = Notification{SenderSSRC: 1}.Marshal()
+	require.NoError(t, err)
+	parsedNotification, ok = FromRaw(raw).(*Notification)
+	require.True(t, ok)
+	assert.Empty(t, parsedNotification.Entries)
+}
+
+func TestFromRawIgnoresOtherPackets(t *testing.T) {
+	raw, err := (&rtcp.RapidResynchronizationRequest{SenderSSRC: 1, MediaSSRC: 2}).Marshal()
+	require.NoError(t, err)
+	assert.Nil(t, FromRaw(raw))
+
+	assert.Nil(t, FromRaw(rtcp.RawPacket{0x83, 205, 0, 1, 0, 0, 0, 1}))
+}
@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+// Package audioframe implements a NetEq style audio jitter buffer that turns
+// audio RTP packets into a steady 10 ms cadence of encoded audio frames.
+package audioframe
+
+import "time"
+
+// EncodedAudioFrame is an encoded audio frame parsed from an RTP payload.
+// This is similar to libwebrtc's EncodedAudioFrame (api/audio_codecs/audio_decoder.h)
+// together with the Packet it is carried in (modules/audio_coding/neteq/packet.h).
+type EncodedAudioFrame struct {
+	// Timestamp is the RTP timestamp of the first sample of the frame.
+	Timestamp uint32
+
+	// SequenceNumber is the RTP sequence number of the packet carrying the frame.
+	SequenceNumber uint16
+
+	// PayloadType is the RTP payload type of the packet carrying the frame.
+	PayloadType uint8
+
+	// Data is the encoded frame. For redundant frames this is the payload carrying
+	// the redundant data, to be decoded with the codec's FEC decoding.
+	Data []byte
+
+	// Samples is the duration of the frame in samples per channel at the RTP clock rate.
+	Samples int
+
+	// Redundant indicates the frame was recovered from in-band FEC of a later packet.
+	Redundant bool
+
+	// ArrivalTime is the time the packet carrying the frame was received.
+	ArrivalTime time.Time
+}
+
+// AudioDecoder parses RTP payloads of a codec into encoded audio frames.
+// This corresponds to the payload parsing part of libwebrtc's AudioDecoder
+// (api/audio_codecs/audio_decoder.h ParsePayload). Decoding to PCM, including
+// packet loss concealment for Expand and Merge, is left to the application.
+type AudioDecoder interface {
+	// ParsePayload splits an RTP payload with the given timestamp into encoded frames.
+	// Redundant frames must be returned with Redundant set.
+	ParsePayload(payload []byte, timestamp uint32) ([]*EncodedAudioFrame, error)
+
+	// SampleRate returns the RTP clock rate of the codec.
+	SampleRate() int
+}
+
+// Operation is the playout operation decided for a 10 ms output.
+// This is a subset of libwebrtc's NetEq::Operation (api/neteq/neteq.h).
+type Operation int
+
+const (
+	// OperationNormal indicates the frames should be decoded and played out as is.
+	// A Normal output without frames continues the playout of previously decoded audio.
+	OperationNormal Operation = iota
+	// OperationExpand indicates no audio is available and concealment audio
+	// (codec PLC or signal extrapolation) must be generated for the output.
+	OperationExpand
+	// OperationMerge indicates the frames follow concealment audio and must be
+	// decoded and cross-faded with the concealment audio.
+	OperationMerge
+	// OperationAccelerate indicates the frames should be decoded and shortened by
+	// TimeStretchSamples to reduce the buffer level.
+	OperationAccelerate
+	// OperationPreemptiveExpand indicates the frames should be decoded and lengthened
+	// by TimeStretchSamples to increase the buffer level.
+	OperationPreemptiveExpand
+)
+
+// String returns the name of the operation.
+func (o Operation) String() string {
+	switch o {
+	case OperationNormal:
+		return "normal"
+	case OperationExpand:
+		return "expand"
+	case OperationMerge:
+		return "merge"
+	case OperationAccelerate:
+		return "accelerate"
+	case OperationPreemptiveExpand:
+		return "preemptive expand"
+	default:
+		return "unknown"
+	}
+}
+
+// AudioOutput is the result of a 10 ms pull from an AudioJitterBuffer.
+// This is similar to the AudioFrame produced by libwebrtc's NetEq::GetAudio,
+// before the decoding and signal processing steps.
+type AudioOutput struct {
+	// Operation is the playout operation for the output.
+	Operation Operation
+
+	// Frames are the encoded frames to decode for the output, in timestamp order.
+	// Empty when previously decoded audio covers the output or for Expand.
+	// If the frames are shorter than the output, the remainder must be concealed.
+	Frames []*EncodedAudioFrame
+
+	// Timestamp is the RTP timestamp of the first sample played out by the output.
+	Timestamp uint32
+
+	// Samples is the output size in samples per channel (10 ms at the RTP clock rate).
+	Samples int
+
+	// TimeStretchSamples is the number of samples per channel to remove for
+	// Accelerate, or to add for PreemptiveExpand.
+	TimeStretchSamples int
+}
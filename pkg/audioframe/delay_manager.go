@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package audioframe
+
+import (
+	"time"
+)
+
+// Delay manager constants.
+// Reference: libwebrtc modules/audio_coding/neteq/delay_manager.cc and underrun_optimizer.cc
+const (
+	// delayBucketSize is the width of a relative delay histogram bucket.
+	delayBucketSize = 20 * time.Millisecond
+	// delayBucketCount is the number of relative delay histogram buckets.
+	delayBucketCount = 100
+	// delayQuantile is the fraction of packets that should arrive in time.
+	delayQuantile = 0.95
+	// delayForgetFactor is the weight of the histogram for each new packet.
+	delayForgetFactor = 0.983
+	// arrivalHistoryWindow is the window of the minimum arrival delay.
+	// Reference: libwebrtc packet_arrival_history.cc kWindowSizeMs
+	arrivalHistoryWindow = 2 * time.Second
+	// defaultMinDelay is the default minimum target delay.
+	defaultMinDelay = 0
+	// defaultMaxDelay is the default maximum target delay.
+	defaultMaxDelay = 2 * time.Second
+	// initialTargetDelay is the target delay before any packet arrived.
+	// Reference: libwebrtc delay_manager.cc kStartDelayMs
+	initialTargetDelay = 80 * time.Millisecond
+)
+
+// arrival is a packet arrival time relative to its RTP timestamp.
+type arrival struct {
+	time time.Time
+	// delay is the arrival time minus the RTP timestamp in wall clock time.
+	delay time.Duration
+}
+
+// delayManager estimates the target playout delay from packet arrival jitter.
+// The relative delay of a packet is its arrival delay compared to the fastest packet
+// of the last two seconds. The target delay is the 95th percentile of a forgetting
+// histogram of relative delays.
+// Reference: libwebrtc modules/audio_coding/neteq/delay_manager.cc
+type delayManager struct {
+	minDelay time.Duration
+	maxDelay time.Duration
+
+	histogram []float64
+	arrivals  []arrival
+	target    time.Duration
+
+	// Arrival delays are relative to the first packet.
+	baseTime      time.Time
+	baseTimestamp time.Duration
+	started       bool
+}
+
+func newDelayManager() *delayManager {
+	// Start with all probability mass at the initial delay
+	histogram := make([]float64, delayBucketCount)
+	histogram[initialTargetDelay/delayBucketSize-1] = 1
+
+	return &delayManager{
+		minDelay:  defaultMinDelay,
+		maxDelay:  defaultMaxDelay,
+		histogram: histogram,
+		target:    initialTargetDelay,
+	}
+}
+
+// update adds a packet arriving at now. timestamp is the unwrapped RTP timestamp of
+// the packet converted to wall clock time, packetDuration the duration of its audio.
+// Reference: libwebrtc delay_manager.cc Update() and packet_arrival_history.cc
+func (m *delayManager) update(timestamp, packetDuration time.Duration, now time.Time) {
+	if !m.started {
+		m.baseTime = now
+		m.baseTimestamp = timestamp
+		m.started = true
+	}
+	delay := now.Sub(m.baseTime) - (timestamp - m.baseTimestamp)
+
+	// Drop arrivals outside of the window
+	for len(m.arrivals) > 0 && now.Sub(m.arrivals[0].time) > arrivalHistoryWindow {
+		m.arrivals = m.arrivals[1:]
+	}
+	m.arrivals = append(m.arrivals, arrival{time: now, delay: delay})
+
+	minDelay := delay
+	for _, a := range m.arrivals {
+		minDelay = min(minDelay, a.delay)
+	}
+	relativeDelay := delay - minDelay
+
+	m.addToHistogram(int(relativeDelay / delayBucketSize))
+
+	target := time.Duration(m.quantileBucket()+1) * delayBucketSize
+	m.target = max(target, packetDuration)
+}
+
+// addToHistogram adds a sample to the forgetting histogram.
+// Reference: libwebrtc modules/audio_coding/neteq/histogram.cc Add()
+func (m *delayManager) addToHistogram(bucket int) {
+	bucket = min(max(bucket, 0), delayBucketCount-1)
+	for i := range m.histogram {
+		m.histogram[i] *= delayForgetFactor
+	}
+	m.histogram[bucket] += 1 - delayForgetFactor
+}
+
+// quantileBucket returns the first bucket at which the cumulative probability reaches the quantile.
+// Reference: libwebrtc histogram.cc Quantile()
+func (m *delayManager) quantileBucket() int {
+	sum := 0.0
+	for i, p := range m.histogram {
+		sum += p
+		if sum >= delayQuantile {
+			return i
+		}
+	}
+
+	return len(m.histogram) - 1
+}
+
+// targetDelay returns the current target delay within the delay bounds.
+func (m *delayManager) targetDelay() time.Duration {
+	return min(max(m.target, m.minDelay), m.maxDelay)
+}
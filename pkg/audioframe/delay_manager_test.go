@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package audioframe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelayManager_Initial(t *testing.T) {
+	m := newDelayManager()
+	assert.Equal(t, initialTargetDelay, m.targetDelay())
+}
+
+func TestDelayManager_AdaptsToJitter(t *testing.T) {
+	m := newDelayManager()
+	start := time.Unix(0, 0)
+	packetDuration := 20 * time.Millisecond
+
+	// Steady arrivals converge to a single packet
+	for i := 0; i < 500; i++ {
+		ts := time.Duration(i) * packetDuration
+		m.update(ts, packetDuration, start.Add(ts))
+	}
+	assert.Equal(t, packetDuration, m.targetDelay())
+
+	// Every 10th packet is 100ms late
+	for i := 500; i < 1000; i++ {
+		ts := time.Duration(i) * packetDuration
+		arrival := start.Add(ts)
+		if i%10 == 0 {
+			arrival = arrival.Add(100 * time.Millisecond)
+		}
+		m.update(ts, packetDuration, arrival)
+	}
+	assert.Equal(t, 120*time.Millisecond, m.targetDelay())
+}
+
+func TestDelayManager_Bounds(t *testing.T) {
+	m := newDelayManager()
+	m.minDelay = 100 * time.Millisecond
+	assert.Equal(t, 100*time.Millisecond, m.targetDelay())
+
+	m.minDelay = 0
+	m.maxDelay = 50 * time.Millisecond
+	assert.Equal(t, 50*time.Millisecond, m.targetDelay())
+}
@@ -0,0 +1,19 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package audioframe
+
+import "errors"
+
+var (
+	errOpusShortPacket     = errors.New("opus packet too short")
+	errOpusInvalidLength   = errors.New("invalid opus frame length")
+	errOpusInvalidDuration = errors.New("invalid opus packet duration")
+	errG711ShortPacket     = errors.New("G.711 packet too short")
+
+	errInvalidSampleRate = errors.New("sample rate must be positive")
+	errInvalidDelay      = errors.New("invalid delay bounds")
+	errInvalidMaxPackets = errors.New("max packets must be positive")
+	errNoDecoder         = errors.New("no audio decoder for stream")
+	errUnknownStream     = errors.New("unknown stream")
+)
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package audioframe
+
+const (
+	// g711SampleRate is the RTP clock rate of PCMU and PCMA (RFC 3551 Section 4.5.14).
+	g711SampleRate = 8000
+	// g711MinChunkMs is the minimum duration of a frame when splitting long payloads.
+	// Reference: libwebrtc legacy_encoded_audio_frame.cc SplitBySamples
+	g711MinChunkMs = 20
+)
+
+// G711Decoder parses PCMU and PCMA RTP payloads.
+// G.711 has one byte per sample and channel. Long payloads are split into frames
+// of at least 20 ms, so that the jitter buffer can discard or stretch parts of them.
+// Reference: libwebrtc modules/audio_coding/codecs/g711/audio_decoder_pcm.cc
+type G711Decoder struct {
+	channels int
+}
+
+// NewG711Decoder creates a new G711Decoder for the given number of channels.
+func NewG711Decoder(channels int) *G711Decoder {
+	if channels < 1 {
+		channels = 1
+	}
+
+	return &G711Decoder{channels: channels}
+}
+
+// SampleRate returns the RTP clock rate of G.711, which is always 8 kHz.
+func (d *G711Decoder) SampleRate() int {
+	return g711SampleRate
+}
+
+// ParsePayload splits a G.711 payload into frames.
+// Reference: libwebrtc legacy_encoded_audio_frame.cc LegacyEncodedAudioFrame::SplitBySamples()
+func (d *G711Decoder) ParsePayload(payload []byte, timestamp uint32) ([]*EncodedAudioFrame, error) {
+	if len(payload) < d.channels {
+		return nil, errG711ShortPacket
+	}
+
+	bytesPerMs := g711SampleRate / 1000 * d.channels
+	minChunkSize := bytesPerMs * g711MinChunkMs
+
+	splitSize := len(payload)
+	for splitSize >= 2*minChunkSize {
+		splitSize /= 2
+	}
+	// Keep whole samples of all channels
+	splitSize -= splitSize % d.channels
+
+	var frames []*EncodedAudioFrame
+	for offset := 0; offset < len(payload); offset += splitSize {
+		end := min(offset+splitSize, len(payload))
+		frames = append(frames, &EncodedAudioFrame{
+			Timestamp: timestamp + uint32(offset/d.channels), //nolint:gosec // G115
+			Data:      payload[offset:end],
+			Samples:   (end - offset) / d.channels,
+		})
+	}
+
+	return frames, nil
+}
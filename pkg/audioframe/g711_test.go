@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package audioframe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestG711Decoder_ParsePayload(t *testing.T) {
+	decoder := NewG711Decoder(1)
+	assert.Equal(t, 8000, decoder.SampleRate())
+
+	// 20ms is kept as one frame
+	frames, err := decoder.ParsePayload(make([]byte, 160), 1000)
+	require.NoError(t, err)
+	require.Len(t, frames, 1)
+	assert.Equal(t, uint32(1000), frames[0].Timestamp)
+	assert.Equal(t, 160, frames[0].Samples)
+
+	// 60ms is split in 30ms frames
+	frames, err = decoder.ParsePayload(make([]byte, 480), 1000)
+	require.NoError(t, err)
+	require.Len(t, frames, 2)
+	assert.Equal(t, uint32(1000), frames[0].Timestamp)
+	assert.Equal(t, 240, frames[0].Samples)
+	assert.Equal(t, uint32(1240), frames[1].Timestamp)
+	assert.Equal(t, 240, frames[1].Samples)
+
+	// 80ms is split in 20ms frames
+	frames, err = decoder.ParsePayload(make([]byte, 640), 0)
+	require.NoError(t, err)
+	assert.Len(t, frames, 4)
+
+	_, err = decoder.ParsePayload(nil, 0)
+	assert.ErrorIs(t, err, errG711ShortPacket)
+}
+
+func TestG711Decoder_Stereo(t *testing.T) {
+	decoder := NewG711Decoder(2)
+
+	frames, err := decoder.ParsePayload(make([]byte, 960), 0)
+	require.NoError(t, err)
+	require.Len(t, frames, 2)
+	assert.Equal(t, 240, frames[0].Samples)
+	assert.Len(t, frames[0].Data, 480)
+	assert.Equal(t, uint32(240), frames[1].Timestamp)
+}
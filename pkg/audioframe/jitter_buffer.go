@@ -0,0 +1,438 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package audioframe
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pion/rtp"
+)
+
+const (
+	// outputDuration is the duration of audio played out per pull.
+	// Reference: libwebrtc neteq_impl.cc kOutputSizeMs
+	outputDuration = 10 * time.Millisecond
+	// defaultMaxPackets is the default maximum number of buffered frames.
+	// Reference: libwebrtc api/neteq/neteq.h Config::max_packets_in_buffer
+	defaultMaxPackets = 200
+	// timeStretchDuration is the duration removed by Accelerate or added by PreemptiveExpand,
+	// about one pitch period.
+	timeStretchDuration = 5 * time.Millisecond
+	// maxWaitForPacket is the number of consecutive Expand outputs after which a
+	// future packet is played out instead of waiting for the missing one.
+	// Reference: libwebrtc decision_logic.cc kMaxWaitForPacketMs
+	maxWaitForPacket = 10
+	// delayAdjustmentGranularity is the margin between the low and high buffer level limits.
+	// Reference: libwebrtc decision_logic.cc kDelayAdjustmentGranularityMs
+	delayAdjustmentGranularity = 20 * time.Millisecond
+	// decelerationTargetLevelOffset limits how far below the target the low limit can be.
+	// Reference: libwebrtc decision_logic.cc kDecelerationTargetLevelOffsetMs
+	decelerationTargetLevelOffset = 85 * time.Millisecond
+	// obsoleteHorizon is how far from the playout position a frame may be before
+	// it is considered to belong to a new stream.
+	// Reference: libwebrtc neteq_impl.cc five_seconds_samples
+	obsoleteHorizon = 5 * time.Second
+)
+
+// bufferedFrame is a frame in the jitter buffer with its unwrapped timestamp.
+type bufferedFrame struct {
+	frame     *EncodedAudioFrame
+	timestamp int64
+}
+
+// AudioJitterBuffer buffers audio frames by timestamp and decides, for each 10 ms
+// of playout, which frames to decode and which playout operation to apply.
+// This is similar to libwebrtc's NetEq (modules/audio_coding/neteq/neteq_impl.cc)
+// without the decoding and signal processing, which are left to the application.
+//
+// For each GetAudio call:
+//   - If previously returned audio still covers the output, nothing is decoded
+//   - If the expected frame is available, it is returned for Normal playout, or for
+//     Accelerate/PreemptiveExpand when the buffer level is above/below the target delay
+//   - If the expected frame is missing, Expand is returned. When a later frame has been
+//     waited for long enough, it is returned with Merge
+//   - Frames following an Expand are returned with Merge
+//
+// The target delay adapts to the packet arrival jitter, see delayManager.
+//
+// This struct is safe for concurrent use.
+type AudioJitterBuffer struct {
+	mu sync.Mutex
+
+	decoder       AudioDecoder
+	sampleRate    int
+	outputSamples int
+	maxPackets    int
+	delay         *delayManager
+	now           func() time.Time
+
+	unwrapper timestampUnwrapper
+	// frames are the buffered frames sorted by timestamp.
+	frames []*bufferedFrame
+
+	started         bool
+	playoutStarted  bool
+	nextTimestamp   int64
+	playoutTime     int64
+	ahead           int
+	lastOperation   Operation
+	expandedSamples int
+	expands         int
+
+	discardedFrames int
+}
+
+// NewAudioJitterBuffer creates a new AudioJitterBuffer for frames parsed by the decoder.
+func NewAudioJitterBuffer(decoder AudioDecoder, opts ...AudioJitterBufferOption) (*AudioJitterBuffer, error) {
+	if decoder == nil {
+		return nil, errNoDecoder
+	}
+	if decoder.SampleRate() <= 0 {
+		return nil, errInvalidSampleRate
+	}
+
+	b := &AudioJitterBuffer{
+		decoder:       decoder,
+		sampleRate:    decoder.SampleRate(),
+		outputSamples: decoder.SampleRate() * int(outputDuration/time.Millisecond) / 1000,
+		maxPackets:    defaultMaxPackets,
+		delay:         newDelayManager(),
+		now:           time.Now,
+	}
+
+	for _, opt := range opts {
+		if err := opt(b); err != nil {
+			return nil, err
+		}
+	}
+
+	return b, nil
+}
+
+// InsertPacket parses an audio RTP packet and buffers its frames.
+// Reference: libwebrtc neteq_impl.cc InsertPacketInternal()
+func (b *AudioJitterBuffer) InsertPacket(pkt *rtp.Packet) error {
+	payload := make([]byte, len(pkt.Payload))
+	copy(payload, pkt.Payload)
+
+	frames, err := b.decoder.ParsePayload(payload, pkt.Timestamp)
+	if err != nil {
+		return err
+	}
+
+	now := b.now()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	packetTimestamp := b.unwrapper.unwrap(pkt.Timestamp)
+	if !b.started {
+		b.started = true
+		b.nextTimestamp = packetTimestamp
+		b.playoutTime = packetTimestamp
+	}
+
+	packetSamples := 0
+	for _, frame := range frames {
+		frame.SequenceNumber = pkt.SequenceNumber
+		frame.PayloadType = pkt.PayloadType
+		frame.ArrivalTime = now
+		if !frame.Redundant {
+			packetSamples += frame.Samples
+		}
+
+		b.insertFrame(frame, packetTimestamp+int64(int32(frame.Timestamp-pkt.Timestamp))) //nolint:gosec // G115
+	}
+
+	b.delay.update(b.samplesToDuration(packetTimestamp), b.samplesToDuration(int64(packetSamples)), now)
+
+	return nil
+}
+
+// insertFrame inserts a frame by timestamp. Primary frames replace redundant frames
+// with the same timestamp, other duplicates are dropped.
+// This method should be called with mu held.
+// Reference: libwebrtc packet_buffer.cc InsertPacket()
+func (b *AudioJitterBuffer) insertFrame(frame *EncodedAudioFrame, timestamp int64) {
+	if !b.playoutStarted && timestamp < b.nextTimestamp {
+		// Reordered before the start of the playout
+		b.nextTimestamp = timestamp
+		b.playoutTime = timestamp
+	}
+
+	if b.isObsolete(timestamp) {
+		b.discardedFrames++
+
+		return
+	}
+
+	if len(b.frames) >= b.maxPackets {
+		// Reference: libwebrtc packet_buffer.cc, the buffer is flushed when full
+		b.discardedFrames += len(b.frames)
+		b.frames = nil
+	}
+
+	idx := sort.Search(len(b.frames), func(i int) bool { return b.frames[i].timestamp >= timestamp })
+	if idx < len(b.frames) && b.frames[idx].timestamp == timestamp {
+		if b.frames[idx].frame.Redundant && !frame.Redundant {
+			b.frames[idx].frame = frame
+		} else {
+			b.discardedFrames++
+		}
+
+		return
+	}
+
+	b.frames = append(b.frames, nil)
+	copy(b.frames[idx+1:], b.frames[idx:])
+	b.frames[idx] = &bufferedFrame{frame: frame, timestamp: timestamp}
+}
+
+// GetAudio returns the playout decision for the next 10 ms of audio.
+// It must be called every 10 ms.
+// Reference: libwebrtc neteq_impl.cc GetAudioInternal()
+func (b *AudioJitterBuffer) GetAudio() *AudioOutput {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	out := &AudioOutput{Operation: OperationExpand, Samples: b.outputSamples}
+
+	if !b.playoutStarted {
+		// Wait until the buffer reaches the target delay before starting playout
+		if !b.started || b.bufferLevel() < b.delay.targetDelay() {
+			out.Timestamp = uint32(b.playoutTime) //nolint:gosec // G115
+
+			return out
+		}
+		// The first frames are played out as is
+		b.playoutStarted = true
+		b.lastOperation = OperationNormal
+		b.takeFrames(out)
+	}
+
+	out.Operation = OperationNormal
+	if b.ahead < b.outputSamples {
+		b.decide(out)
+	}
+
+	out.Timestamp = uint32(b.playoutTime) //nolint:gosec // G115
+	// Audio missing from short frames is concealed by the application
+	b.ahead = max(b.ahead-b.outputSamples, 0)
+	b.playoutTime = min(b.playoutTime+int64(b.outputSamples), b.nextTimestamp)
+
+	return out
+}
+
+// decide chooses the operation for an output that needs new audio and collects its frames.
+// This method should be called with mu held.
+// Reference: libwebrtc decision_logic.cc GetDecision()
+func (b *AudioJitterBuffer) decide(out *AudioOutput) {
+	b.discardObsoleteFrames()
+
+	if len(b.frames) == 0 {
+		b.expand(out)
+
+		return
+	}
+
+	next := b.frames[0]
+	switch {
+	case next.timestamp == b.nextTimestamp:
+		b.expectedFrameAvailable(out)
+	case next.timestamp < b.nextTimestamp || next.timestamp-b.nextTimestamp > b.durationToSamples(obsoleteHorizon):
+		// New stream: restart the playout at the frame
+		b.nextTimestamp = next.timestamp
+		b.playoutTime = next.timestamp
+		b.takeFrames(out)
+		out.Operation = OperationNormal
+		b.lastOperation = OperationNormal
+	default:
+		b.futureFrameAvailable(out, next)
+	}
+}
+
+// expectedFrameAvailable handles the case where the next frame continues the playout.
+// Reference: libwebrtc decision_logic.cc ExpectedPacketAvailable()
+func (b *AudioJitterBuffer) expectedFrameAvailable(out *AudioOutput) {
+	if b.lastOperation == OperationExpand {
+		b.merge(out)
+
+		return
+	}
+
+	target := b.delay.targetDelay()
+	lowLimit := max(target*3/4, target-decelerationTargetLevelOffset)
+	highLimit := max(target, lowLimit+delayAdjustmentGranularity)
+	level := b.bufferLevel()
+
+	operation := OperationNormal
+	switch {
+	case level >= highLimit:
+		operation = OperationAccelerate
+	case level < lowLimit:
+		operation = OperationPreemptiveExpand
+	}
+
+	b.takeFrames(out)
+
+	stretch := b.durationToSamples(timeStretchDuration)
+	switch operation {
+	case OperationAccelerate:
+		if b.ahead-int(stretch) < b.outputSamples {
+			// Not enough audio to remove a pitch period
+			operation = OperationNormal
+
+			break
+		}
+		b.ahead -= int(stretch)
+		out.TimeStretchSamples = int(stretch)
+	case OperationPreemptiveExpand:
+		b.ahead += int(stretch)
+		out.TimeStretchSamples = int(stretch)
+	default:
+	}
+
+	out.Operation = operation
+	b.lastOperation = operation
+}
+
+// futureFrameAvailable handles the case where frames are missing before the next frame.
+// Reference: libwebrtc decision_logic.cc FuturePacketAvailable()
+func (b *AudioJitterBuffer) futureFrameAvailable(out *AudioOutput, next *bufferedFrame) {
+	gap := next.timestamp - b.nextTimestamp
+	waitedEnough := int64(b.expandedSamples) >= gap || b.expands >= maxWaitForPacket
+	if b.lastOperation == OperationExpand && (waitedEnough || b.bufferLevel() > 2*b.delay.targetDelay()) {
+		// Give up on the missing frames
+		b.nextTimestamp = next.timestamp
+		b.playoutTime = next.timestamp
+		b.merge(out)
+
+		return
+	}
+
+	b.expand(out)
+}
+
+// expand returns concealment audio for the output.
+// Reference: libwebrtc neteq_impl.cc DoExpand()
+func (b *AudioJitterBuffer) expand(out *AudioOutput) {
+	out.Operation = OperationExpand
+	b.ahead += b.outputSamples
+	b.expandedSamples += b.outputSamples
+	b.expands++
+	b.lastOperation = OperationExpand
+}
+
+// merge returns the next frames to be merged with the preceding concealment audio.
+// Reference: libwebrtc neteq_impl.cc DoMerge()
+func (b *AudioJitterBuffer) merge(out *AudioOutput) {
+	b.takeFrames(out)
+	out.Operation = OperationMerge
+	b.lastOperation = OperationMerge
+}
+
+// takeFrames moves consecutive frames starting at the next timestamp to the output
+// until the output is covered.
+// This method should be called with mu held.
+// Reference: libwebrtc neteq_impl.cc ExtractPackets()
+func (b *AudioJitterBuffer) takeFrames(out *AudioOutput) {
+	for len(b.frames) > 0 && b.frames[0].timestamp == b.nextTimestamp && b.ahead < b.outputSamples {
+		frame := b.frames[0]
+		b.frames = b.frames[1:]
+
+		out.Frames = append(out.Frames, frame.frame)
+		b.ahead += frame.frame.Samples
+		b.nextTimestamp += int64(frame.frame.Samples)
+	}
+
+	b.expandedSamples = 0
+	b.expands = 0
+}
+
+// discardObsoleteFrames drops frames before the next timestamp.
+// This method should be called with mu held.
+// Reference: libwebrtc packet_buffer.cc DiscardOldPackets()
+func (b *AudioJitterBuffer) discardObsoleteFrames() {
+	for len(b.frames) > 0 && b.isObsolete(b.frames[0].timestamp) {
+		b.frames = b.frames[1:]
+		b.discardedFrames++
+	}
+}
+
+// isObsolete reports whether a frame starts before the next timestamp,
+// unless it is so old that it belongs to a new stream.
+// This method should be called with mu held.
+// Reference: libwebrtc packet_buffer.cc IsObsoleteTimestamp()
+func (b *AudioJitterBuffer) isObsolete(timestamp int64) bool {
+	return b.started && timestamp < b.nextTimestamp &&
+		b.nextTimestamp-timestamp <= b.durationToSamples(obsoleteHorizon)
+}
+
+// bufferLevel returns the duration of audio from the playout position to the end
+// of the last buffered frame.
+// This method should be called with mu held.
+// Reference: libwebrtc neteq_impl.cc GetDecision() (span of the packet buffer and sync buffer)
+func (b *AudioJitterBuffer) bufferLevel() time.Duration {
+	samples := int64(b.ahead)
+	if len(b.frames) > 0 {
+		last := b.frames[len(b.frames)-1]
+		samples += max(0, last.timestamp+int64(last.frame.Samples)-b.nextTimestamp)
+	}
+
+	return b.samplesToDuration(samples)
+}
+
+// TargetDelay returns the current target delay.
+func (b *AudioJitterBuffer) TargetDelay() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.delay.targetDelay()
+}
+
+// BufferLevel returns the duration of buffered audio, including returned audio not yet played out.
+func (b *AudioJitterBuffer) BufferLevel() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.bufferLevel()
+}
+
+// DiscardedFrames returns the number of frames discarded because they arrived too late,
+// were duplicates, or were flushed from a full buffer.
+func (b *AudioJitterBuffer) DiscardedFrames() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.discardedFrames
+}
+
+func (b *AudioJitterBuffer) samplesToDuration(samples int64) time.Duration {
+	return time.Duration(samples * int64(time.Second) / int64(b.sampleRate))
+}
+
+func (b *AudioJitterBuffer) durationToSamples(d time.Duration) int64 {
+	return int64(d) * int64(b.sampleRate) / int64(time.Second)
+}
+
+// timestampUnwrapper unwraps 32-bit RTP timestamps to int64.
+type timestampUnwrapper struct {
+	last    int64
+	started bool
+}
+
+func (u *timestampUnwrapper) unwrap(ts uint32) int64 {
+	if !u.started {
+		u.last = int64(ts)
+		u.started = true
+
+		return u.last
+	}
+
+	u.last += int64(int32(ts - uint32(u.last))) //nolint:gosec // G115
+
+	return u.last
+}
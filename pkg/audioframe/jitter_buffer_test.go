@@ -0,0 +1,312 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package audioframe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const opusSamplesPer20ms = 960
+
+var (
+	// SILK WB 20ms, VAD set.
+	opusPayload = []byte{0x48, 0x80, 0x01}
+	// SILK WB 20ms with LBRR data.
+	opusFECPayload = []byte{0x48, 0x40, 0x01}
+)
+
+// testAudioBuffer drives an AudioJitterBuffer with a manual clock.
+type testAudioBuffer struct {
+	t      *testing.T
+	buffer *AudioJitterBuffer
+	now    time.Time
+}
+
+func newTestAudioBuffer(t *testing.T, opts ...AudioJitterBufferOption) *testAudioBuffer {
+	t.Helper()
+
+	buffer, err := NewAudioJitterBuffer(NewOpusDecoder(), opts...)
+	require.NoError(t, err)
+
+	tb := &testAudioBuffer{t: t, buffer: buffer, now: time.Unix(0, 0)}
+	buffer.now = func() time.Time { return tb.now }
+
+	return tb
+}
+
+// insert inserts the 20ms packet with the given sequence number.
+func (tb *testAudioBuffer) insert(seq uint16, payload []byte) {
+	tb.t.Helper()
+
+	require.NoError(tb.t, tb.buffer.InsertPacket(&rtp.Packet{
+		Header: rtp.Header{
+			SequenceNumber: seq,
+			Timestamp:      uint32(seq) * opusSamplesPer20ms,
+			PayloadType:    111,
+		},
+		Payload: payload,
+	}))
+}
+
+// get pulls 10ms of audio and advances the clock.
+func (tb *testAudioBuffer) get() *AudioOutput {
+	out := tb.buffer.GetAudio()
+	tb.now = tb.now.Add(outputDuration)
+
+	return out
+}
+
+// run simulates packets arriving every 20ms, skipping lost packets, and returns the outputs.
+func (tb *testAudioBuffer) run(packets int, lost map[uint16]bool) []*AudioOutput {
+	tb.t.Helper()
+
+	var outputs []*AudioOutput
+	for i := 0; i < 2*packets; i++ {
+		seq := uint16(i / 2) //nolint:gosec // G115
+		if i%2 == 0 && !lost[seq] {
+			tb.insert(seq, opusPayload)
+		}
+		outputs = append(outputs, tb.get())
+	}
+
+	return outputs
+}
+
+func TestNewAudioJitterBuffer(t *testing.T) {
+	_, err := NewAudioJitterBuffer(nil)
+	assert.ErrorIs(t, err, errNoDecoder)
+
+	_, err = NewAudioJitterBuffer(NewOpusDecoder(), WithDelayBounds(time.Second, time.Millisecond))
+	assert.ErrorIs(t, err, errInvalidDelay)
+
+	_, err = NewAudioJitterBuffer(NewOpusDecoder(), WithMaxPackets(0))
+	assert.ErrorIs(t, err, errInvalidMaxPackets)
+
+	buffer, err := NewAudioJitterBuffer(NewOpusDecoder())
+	require.NoError(t, err)
+	assert.Equal(t, initialTargetDelay, buffer.TargetDelay())
+	assert.Equal(t, 480, buffer.GetAudio().Samples)
+}
+
+func TestAudioJitterBuffer_WaitsForTargetDelay(t *testing.T) {
+	tb := newTestAudioBuffer(t)
+
+	outputs := tb.run(4, nil)
+	for i := 0; i < 6; i++ {
+		assert.Equal(t, OperationExpand, outputs[i].Operation, "output %d", i)
+		assert.Empty(t, outputs[i].Frames)
+	}
+
+	// 80ms are buffered, the first frame is played out
+	assert.Equal(t, OperationNormal, outputs[6].Operation)
+	require.Len(t, outputs[6].Frames, 1)
+	assert.Equal(t, uint32(0), outputs[6].Frames[0].Timestamp)
+	assert.Equal(t, uint8(111), outputs[6].Frames[0].PayloadType)
+	assert.Equal(t, uint32(0), outputs[6].Timestamp)
+
+	// The second half of the frame covers the next output
+	assert.Equal(t, OperationNormal, outputs[7].Operation)
+	assert.Empty(t, outputs[7].Frames)
+	assert.Equal(t, uint32(480), outputs[7].Timestamp)
+}
+
+func TestAudioJitterBuffer_SteadyPlayout(t *testing.T) {
+	tb := newTestAudioBuffer(t, WithDelayBounds(40*time.Millisecond, 40*time.Millisecond))
+
+	outputs := tb.run(50, nil)
+	var frames []*EncodedAudioFrame
+	for i, out := range outputs {
+		if i >= 3 {
+			assert.NotEqual(t, OperationExpand, out.Operation, "output %d", i)
+		}
+		frames = append(frames, out.Frames...)
+	}
+	assert.Len(t, frames, 49)
+
+	for i, frame := range frames {
+		assert.Equal(t, uint16(i), frame.SequenceNumber) //nolint:gosec // G115
+	}
+	assert.Zero(t, tb.buffer.DiscardedFrames())
+}
+
+func TestAudioJitterBuffer_ExpandAndMerge(t *testing.T) {
+	tb := newTestAudioBuffer(t, WithDelayBounds(40*time.Millisecond, 40*time.Millisecond))
+
+	outputs := tb.run(20, map[uint16]bool{10: true})
+
+	var ops []Operation
+	var merged *AudioOutput
+	for _, out := range outputs[4:] {
+		ops = append(ops, out.Operation)
+		if out.Operation == OperationMerge {
+			merged = out
+		}
+	}
+	assert.Contains(t, ops, OperationExpand)
+	require.NotNil(t, merged, "Operations: %v", ops)
+	require.Len(t, merged.Frames, 1)
+	assert.Equal(t, uint16(11), merged.Frames[0].SequenceNumber)
+	assert.Equal(t, uint32(11*opusSamplesPer20ms), merged.Timestamp)
+
+	// The lost packet arrives after it was concealed
+	tb.insert(10, opusPayload)
+	assert.Equal(t, 1, tb.buffer.DiscardedFrames())
+}
+
+func TestAudioJitterBuffer_ExpandOnUnderrun(t *testing.T) {
+	tb := newTestAudioBuffer(t, WithDelayBounds(40*time.Millisecond, 40*time.Millisecond))
+
+	tb.run(10, nil)
+	for i := 0; i < 5; i++ {
+		tb.get()
+	}
+	out := tb.get()
+	assert.Equal(t, OperationExpand, out.Operation)
+	assert.Empty(t, out.Frames)
+
+	// Playout continues at the next packet
+	tb.insert(10, opusPayload)
+	out = tb.get()
+	assert.Equal(t, OperationMerge, out.Operation)
+	require.Len(t, out.Frames, 1)
+	assert.Equal(t, uint16(10), out.Frames[0].SequenceNumber)
+}
+
+func TestAudioJitterBuffer_Accelerate(t *testing.T) {
+	tb := newTestAudioBuffer(t, WithDelayBounds(0, 40*time.Millisecond))
+
+	// A burst of 200ms
+	for seq := uint16(0); seq < 10; seq++ {
+		tb.insert(seq, opusPayload)
+	}
+
+	out := tb.get()
+	assert.Equal(t, OperationNormal, out.Operation)
+	tb.get()
+
+	out = tb.get()
+	assert.Equal(t, OperationAccelerate, out.Operation)
+	assert.Equal(t, 240, out.TimeStretchSamples)
+	require.Len(t, out.Frames, 1)
+	assert.Equal(t, uint16(1), out.Frames[0].SequenceNumber)
+
+	// The buffer level drains towards the target delay
+	for i := 0; i < 30; i++ {
+		tb.get()
+	}
+	assert.Less(t, tb.buffer.BufferLevel(), 100*time.Millisecond)
+}
+
+func TestAudioJitterBuffer_PreemptiveExpand(t *testing.T) {
+	tb := newTestAudioBuffer(t, WithDelayBounds(100*time.Millisecond, 100*time.Millisecond))
+
+	for seq := uint16(0); seq < 5; seq++ {
+		tb.insert(seq, opusPayload)
+	}
+
+	var stretched *AudioOutput
+	for i := 0; i < 6 && stretched == nil; i++ {
+		if out := tb.get(); out.Operation == OperationPreemptiveExpand {
+			stretched = out
+		}
+	}
+	require.NotNil(t, stretched)
+	assert.Equal(t, 240, stretched.TimeStretchSamples)
+	assert.Len(t, stretched.Frames, 1)
+}
+
+func TestAudioJitterBuffer_RedundantFrames(t *testing.T) {
+	t.Run("Recovers loss", func(t *testing.T) {
+		tb := newTestAudioBuffer(t, WithDelayBounds(40*time.Millisecond, 40*time.Millisecond))
+
+		var frames []*EncodedAudioFrame
+		for i := 0; i < 40; i++ {
+			seq := uint16(i / 2) //nolint:gosec // G115
+			if i%2 == 0 {
+				switch seq {
+				case 10:
+				case 11:
+					tb.insert(seq, opusFECPayload)
+				default:
+					tb.insert(seq, opusPayload)
+				}
+			}
+			out := tb.get()
+			if i >= 3 {
+				assert.NotEqual(t, OperationExpand, out.Operation, "output %d", i)
+			}
+			frames = append(frames, out.Frames...)
+		}
+
+		require.Greater(t, len(frames), 11)
+		assert.True(t, frames[10].Redundant)
+		assert.Equal(t, uint32(10*opusSamplesPer20ms), frames[10].Timestamp)
+		assert.False(t, frames[11].Redundant)
+	})
+
+	t.Run("Replaced by primary", func(t *testing.T) {
+		tb := newTestAudioBuffer(t, WithDelayBounds(0, 0))
+
+		tb.insert(1, opusFECPayload)
+		tb.insert(0, opusPayload)
+		tb.insert(0, opusPayload)
+		assert.Equal(t, 1, tb.buffer.DiscardedFrames(), "Only the duplicate primary is discarded")
+
+		out := tb.get()
+		require.Len(t, out.Frames, 1)
+		assert.False(t, out.Frames[0].Redundant)
+		assert.Equal(t, uint32(0), out.Frames[0].Timestamp)
+	})
+}
+
+func TestAudioJitterBuffer_Reordering(t *testing.T) {
+	tb := newTestAudioBuffer(t, WithDelayBounds(40*time.Millisecond, 40*time.Millisecond))
+
+	tb.insert(1, opusPayload)
+	tb.insert(0, opusPayload)
+
+	out := tb.get()
+	require.Len(t, out.Frames, 1)
+	assert.Equal(t, uint16(0), out.Frames[0].SequenceNumber)
+	tb.get()
+	out = tb.get()
+	require.Len(t, out.Frames, 1)
+	assert.Equal(t, uint16(1), out.Frames[0].SequenceNumber)
+}
+
+func TestAudioJitterBuffer_FlushWhenFull(t *testing.T) {
+	tb := newTestAudioBuffer(t, WithMaxPackets(2))
+
+	tb.insert(0, opusPayload)
+	tb.insert(1, opusPayload)
+	tb.insert(2, opusPayload)
+	assert.Equal(t, 2, tb.buffer.DiscardedFrames())
+}
+
+func TestAudioJitterBuffer_TimestampJump(t *testing.T) {
+	tb := newTestAudioBuffer(t, WithDelayBounds(0, 0))
+
+	tb.insert(0, opusPayload)
+	tb.get()
+	tb.get()
+
+	// A new stream far in the future restarts the playout
+	tb.insert(1000, opusPayload)
+	out := tb.get()
+	assert.Equal(t, OperationNormal, out.Operation)
+	require.Len(t, out.Frames, 1)
+	assert.Equal(t, uint32(1000*opusSamplesPer20ms), out.Timestamp)
+}
+
+func TestAudioJitterBuffer_InvalidPayload(t *testing.T) {
+	tb := newTestAudioBuffer(t)
+
+	assert.Error(t, tb.buffer.InsertPacket(&rtp.Packet{}))
+	assert.Zero(t, tb.buffer.BufferLevel())
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package audioframe
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pion/logging"
+)
+
+// ReceiverInterceptorOption can be used to configure ReceiverInterceptor.
+type ReceiverInterceptorOption func(r *ReceiverInterceptor) error
+
+// WithAudioDecoder registers an AudioDecoder factory for a MIME type, such as "audio/opus".
+// It replaces the built-in decoder for that MIME type.
+func WithAudioDecoder(mimeType string, factory AudioDecoderFactory) ReceiverInterceptorOption {
+	return func(r *ReceiverInterceptor) error {
+		r.decoderFactories[strings.ToLower(mimeType)] = factory
+		return nil
+	}
+}
+
+// WithJitterBufferOptions sets the options of the AudioJitterBuffer created for each stream.
+func WithJitterBufferOptions(opts ...AudioJitterBufferOption) ReceiverInterceptorOption {
+	return func(r *ReceiverInterceptor) error {
+		r.bufferOpts = opts
+		return nil
+	}
+}
+
+// WithLog sets a logger for the interceptor.
+func WithLog(log logging.LeveledLogger) ReceiverInterceptorOption {
+	return func(r *ReceiverInterceptor) error {
+		r.log = log
+		return nil
+	}
+}
+
+// WithLoggerFactory sets a logger factory for the interceptor.
+func WithLoggerFactory(loggerFactory logging.LoggerFactory) ReceiverInterceptorOption {
+	return func(r *ReceiverInterceptor) error {
+		r.loggerFactory = loggerFactory
+		return nil
+	}
+}
+
+// AudioJitterBufferOption can be used to configure AudioJitterBuffer.
+type AudioJitterBufferOption func(b *AudioJitterBuffer) error
+
+// WithDelayBounds bounds the target delay.
+// Default is 0 to 2s.
+func WithDelayBounds(minDelay, maxDelay time.Duration) AudioJitterBufferOption {
+	return func(b *AudioJitterBuffer) error {
+		if minDelay < 0 || maxDelay < minDelay {
+			return errInvalidDelay
+		}
+		b.delay.minDelay = minDelay
+		b.delay.maxDelay = maxDelay
+		return nil
+	}
+}
+
+// WithMaxPackets sets the maximum number of buffered frames. The buffer is flushed when full.
+// Default is 200.
+func WithMaxPackets(n int) AudioJitterBufferOption {
+	return func(b *AudioJitterBuffer) error {
+		if n <= 0 {
+			return errInvalidMaxPackets
+		}
+		b.maxPackets = n
+		return nil
+	}
+}
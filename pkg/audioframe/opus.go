@@ -0,0 +1,273 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package audioframe
+
+// opusSampleRate is the RTP clock rate of Opus (RFC 7587 Section 4.1).
+const opusSampleRate = 48000
+
+const (
+	opusTOCConfigShift   = 3
+	opusTOCStereoBit     = 0x04
+	opusTOCCodeMask      = 0x03
+	opusFrameCountMask   = 0x3F
+	opusCode3VBRBit      = 0x80
+	opusCode3PaddingBit  = 0x40
+	opusMaxPacketSamples = 120 * opusSampleRate / 1000
+	// opusFirstCELTConfig is the first configuration of the CELT-only mode, which has no LBRR frames.
+	opusFirstCELTConfig = 16
+	// opusFirstHybridConfig is the first configuration of the hybrid mode.
+	opusFirstHybridConfig = 12
+)
+
+// OpusDecoder parses Opus RTP payloads (RFC 7587).
+// Packets carrying in-band FEC (LBRR frames) also yield a redundant frame for the
+// preceding frame duration.
+// Reference: libwebrtc modules/audio_coding/codecs/opus/audio_decoder_opus.cc
+type OpusDecoder struct{}
+
+// NewOpusDecoder creates a new OpusDecoder.
+func NewOpusDecoder() *OpusDecoder {
+	return &OpusDecoder{}
+}
+
+// SampleRate returns the RTP clock rate of Opus, which is always 48 kHz.
+func (d *OpusDecoder) SampleRate() int {
+	return opusSampleRate
+}
+
+// ParsePayload returns the Opus packet as one frame, preceded by a redundant frame
+// if the packet carries in-band FEC.
+// Reference: libwebrtc audio_decoder_opus.cc ParsePayload()
+func (d *OpusDecoder) ParsePayload(payload []byte, timestamp uint32) ([]*EncodedAudioFrame, error) {
+	samples, err := opusPacketSamples(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var frames []*EncodedAudioFrame
+	if opusPacketHasFEC(payload) {
+		fecSamples := opusSamplesPerFrame(payload[0])
+		frames = append(frames, &EncodedAudioFrame{
+			Timestamp: timestamp - uint32(fecSamples), //nolint:gosec // G115
+			Data:      payload,
+			Samples:   fecSamples,
+			Redundant: true,
+		})
+	}
+
+	frames = append(frames, &EncodedAudioFrame{
+		Timestamp: timestamp,
+		Data:      payload,
+		Samples:   samples,
+	})
+
+	return frames, nil
+}
+
+// opusSamplesPerFrame returns the number of samples at 48 kHz of each frame of a packet.
+// Reference: RFC 6716 Section 3.1, Table 2
+func opusSamplesPerFrame(toc byte) int {
+	config := int(toc >> opusTOCConfigShift)
+
+	switch {
+	case config >= opusFirstCELTConfig:
+		// 2.5, 5, 10, 20 ms
+		return (opusSampleRate / 400) << (config & 0x3)
+	case config >= opusFirstHybridConfig:
+		// 10, 20 ms
+		return (opusSampleRate / 100) << (config & 0x1)
+	case config&0x3 == 3:
+		// 60 ms
+		return opusSampleRate * 60 / 1000
+	default:
+		// 10, 20, 40 ms
+		return (opusSampleRate / 100) << (config & 0x3)
+	}
+}
+
+// opusFrameCount returns the number of frames of an Opus packet.
+// Reference: RFC 6716 Section 3.2
+func opusFrameCount(payload []byte) (int, error) {
+	if len(payload) == 0 {
+		return 0, errOpusShortPacket
+	}
+
+	switch payload[0] & opusTOCCodeMask {
+	case 0:
+		return 1, nil
+	case 1, 2:
+		return 2, nil
+	default:
+		if len(payload) < 2 {
+			return 0, errOpusShortPacket
+		}
+
+		return int(payload[1] & opusFrameCountMask), nil
+	}
+}
+
+// opusPacketSamples returns the duration of an Opus packet in samples at 48 kHz.
+// Reference: libopus opus_packet_get_nb_samples()
+func opusPacketSamples(payload []byte) (int, error) {
+	count, err := opusFrameCount(payload)
+	if err != nil {
+		return 0, err
+	}
+
+	samples := count * opusSamplesPerFrame(payload[0])
+	if samples == 0 || samples > opusMaxPacketSamples {
+		return 0, errOpusInvalidDuration
+	}
+
+	return samples, nil
+}
+
+// opusFrameLength reads a frame length coded in one or two bytes.
+// Returns the length and the number of bytes used.
+// Reference: RFC 6716 Section 3.2.1
+func opusFrameLength(data []byte) (int, int, error) {
+	if len(data) < 1 {
+		return 0, 0, errOpusShortPacket
+	}
+	if data[0] < 252 {
+		return int(data[0]), 1, nil
+	}
+	if len(data) < 2 {
+		return 0, 0, errOpusShortPacket
+	}
+
+	return int(data[0]) + 4*int(data[1]), 2, nil
+}
+
+// opusFirstFrame returns the first frame of an Opus packet.
+// Reference: RFC 6716 Section 3.2
+//
+//nolint:cyclop
+func opusFirstFrame(payload []byte) ([]byte, error) {
+	if len(payload) < 1 {
+		return nil, errOpusShortPacket
+	}
+
+	switch payload[0] & opusTOCCodeMask {
+	case 0:
+		return payload[1:], nil
+	case 1:
+		if (len(payload)-1)%2 != 0 {
+			return nil, errOpusInvalidLength
+		}
+
+		return payload[1 : 1+(len(payload)-1)/2], nil
+	case 2:
+		length, n, err := opusFrameLength(payload[1:])
+		if err != nil {
+			return nil, err
+		}
+		if 1+n+length > len(payload) {
+			return nil, errOpusInvalidLength
+		}
+
+		return payload[1+n : 1+n+length], nil
+	}
+
+	// Code 3: arbitrary number of frames
+	if len(payload) < 2 {
+		return nil, errOpusShortPacket
+	}
+	count := int(payload[1] & opusFrameCountMask)
+	if count == 0 {
+		return nil, errOpusInvalidDuration
+	}
+
+	offset := 2
+	end := len(payload)
+	if payload[1]&opusCode3PaddingBit != 0 {
+		for {
+			if offset >= len(payload) {
+				return nil, errOpusShortPacket
+			}
+			padding := int(payload[offset])
+			offset++
+			if padding == 255 {
+				end -= 254
+			} else {
+				end -= padding
+
+				break
+			}
+		}
+	}
+	if end < offset {
+		return nil, errOpusInvalidLength
+	}
+
+	if payload[1]&opusCode3VBRBit == 0 {
+		// CBR: all frames have the same length
+		if (end-offset)%count != 0 {
+			return nil, errOpusInvalidLength
+		}
+
+		return payload[offset : offset+(end-offset)/count], nil
+	}
+
+	// VBR: the lengths of all but the last frame precede the frames
+	lengths := make([]int, count-1)
+	for i := range lengths {
+		length, n, err := opusFrameLength(payload[offset:end])
+		if err != nil {
+			return nil, err
+		}
+		lengths[i] = length
+		offset += n
+	}
+	first := end - offset
+	if len(lengths) > 0 {
+		first = lengths[0]
+	}
+	if offset+first > end {
+		return nil, errOpusInvalidLength
+	}
+
+	return payload[offset : offset+first], nil
+}
+
+// opusPacketHasFEC reports whether an Opus packet carries LBRR data of the previous frame.
+// Reference: libwebrtc modules/audio_coding/codecs/opus/opus_interface.cc WebRtcOpus_PacketHasFec()
+func opusPacketHasFEC(payload []byte) bool {
+	if len(payload) < 1 || int(payload[0]>>opusTOCConfigShift) >= opusFirstCELTConfig {
+		return false
+	}
+
+	// Number of 20 ms SILK frames per Opus frame
+	var silkFrames int
+	switch opusSamplesPerFrame(payload[0]) * 1000 / opusSampleRate {
+	case 10, 20:
+		silkFrames = 1
+	case 40:
+		silkFrames = 2
+	case 60:
+		silkFrames = 3
+	default:
+		return false
+	}
+
+	frame, err := opusFirstFrame(payload)
+	if err != nil || len(frame) == 0 {
+		return false
+	}
+
+	channels := 1
+	if payload[0]&opusTOCStereoBit != 0 {
+		channels = 2
+	}
+
+	// The first bits of a frame are one VAD flag per SILK frame followed by the LBRR flag,
+	// for the mid channel and then for the side channel.
+	for n := 0; n < channels; n++ {
+		if frame[0]&(0x80>>((n+1)*(silkFrames+1)-1)) != 0 {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package audioframe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpusPacketSamples(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		payload []byte
+		samples int
+	}{
+		{"SILK NB 10ms", []byte{0x00, 0x00}, 480},
+		{"SILK WB 20ms", []byte{0x48, 0x00}, 960},
+		{"SILK WB 40ms", []byte{0x50, 0x00}, 1920},
+		{"SILK WB 60ms", []byte{0x58, 0x00}, 2880},
+		{"Hybrid FB 10ms", []byte{0x70, 0x00}, 480},
+		{"Hybrid FB 20ms", []byte{0x78, 0x00}, 960},
+		{"CELT FB 2.5ms", []byte{0xE0, 0x00}, 120},
+		{"CELT FB 20ms", []byte{0xF8, 0x00}, 960},
+		{"Two frames", []byte{0xF9, 0x00, 0x00}, 1920},
+		{"Three frames", []byte{0xFB, 0x03, 0x00, 0x00, 0x00}, 2880},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			samples, err := opusPacketSamples(test.payload)
+			require.NoError(t, err)
+			assert.Equal(t, test.samples, samples)
+		})
+	}
+
+	_, err := opusPacketSamples(nil)
+	assert.ErrorIs(t, err, errOpusShortPacket)
+
+	_, err = opusPacketSamples([]byte{0xFB, 0x07})
+	assert.ErrorIs(t, err, errOpusInvalidDuration, "Packets are at most 120ms")
+}
+
+func TestOpusPacketHasFEC(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		payload []byte
+		fec     bool
+	}{
+		{"Mono LBRR", []byte{0x48, 0x40}, true},
+		{"Mono VAD only", []byte{0x48, 0x80}, false},
+		{"Stereo side channel LBRR", []byte{0x4C, 0x10}, true},
+		{"40ms LBRR", []byte{0x50, 0x20}, true},
+		{"40ms VAD only", []byte{0x50, 0xC0}, false},
+		{"CELT", []byte{0xF8, 0xFF}, false},
+		{"DTX", []byte{0x48}, false},
+		{"Code 1", []byte{0x49, 0x40, 0x00}, true},
+		{"Code 2", []byte{0x4A, 0x01, 0x40, 0x22, 0x33}, true},
+		{"Code 3 CBR", []byte{0x4B, 0x02, 0x40, 0x00}, true},
+		{"Code 3 VBR", []byte{0x4B, 0x82, 0x02, 0x40, 0x00, 0x11}, true},
+		{"Code 3 VBR padding", []byte{0x4B, 0xC2, 0x01, 0x01, 0x40, 0x11, 0x00}, true},
+		{"Code 2 invalid length", []byte{0x4A, 0x05, 0x40}, false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.fec, opusPacketHasFEC(test.payload))
+		})
+	}
+}
+
+func TestOpusDecoder_ParsePayload(t *testing.T) {
+	decoder := NewOpusDecoder()
+	assert.Equal(t, 48000, decoder.SampleRate())
+
+	frames, err := decoder.ParsePayload([]byte{0x48, 0x80, 0x01}, 96000)
+	require.NoError(t, err)
+	require.Len(t, frames, 1)
+	assert.Equal(t, uint32(96000), frames[0].Timestamp)
+	assert.Equal(t, 960, frames[0].Samples)
+	assert.False(t, frames[0].Redundant)
+
+	// In-band FEC yields a redundant frame for the previous 20ms
+	payload := []byte{0x48, 0x40, 0x01}
+	frames, err = decoder.ParsePayload(payload, 96000)
+	require.NoError(t, err)
+	require.Len(t, frames, 2)
+	assert.True(t, frames[0].Redundant)
+	assert.Equal(t, uint32(95040), frames[0].Timestamp)
+	assert.Equal(t, 960, frames[0].Samples)
+	assert.Equal(t, payload, frames[0].Data)
+	assert.False(t, frames[1].Redundant)
+	assert.Equal(t, uint32(96000), frames[1].Timestamp)
+
+	_, err = decoder.ParsePayload(nil, 0)
+	assert.Error(t, err)
+}
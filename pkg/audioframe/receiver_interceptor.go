@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package audioframe
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/logging"
+	"github.com/pion/rtp"
+)
+
+// AudioDecoderFactory creates the AudioDecoder for a stream.
+type AudioDecoderFactory func(info *interceptor.StreamInfo) (AudioDecoder, error)
+
+// NewPeerConnectionCallback receives the ReceiverInterceptor created for the
+// PeerConnection with id.
+type NewPeerConnectionCallback func(id string, receiver *ReceiverInterceptor)
+
+// ReceiverInterceptorFactory is a interceptor.Factory for ReceiverInterceptor.
+type ReceiverInterceptorFactory struct {
+	opts              []ReceiverInterceptorOption
+	addPeerConnection NewPeerConnectionCallback
+}
+
+// NewReceiverInterceptor returns a new ReceiverInterceptorFactory.
+func NewReceiverInterceptor(opts ...ReceiverInterceptorOption) (*ReceiverInterceptorFactory, error) {
+	return &ReceiverInterceptorFactory{opts: opts}, nil
+}
+
+// OnNewPeerConnection sets a callback that is called when a new ReceiverInterceptor
+// is created. It can be used to pull audio with ReceiverInterceptor.GetAudio.
+func (f *ReceiverInterceptorFactory) OnNewPeerConnection(cb NewPeerConnectionCallback) {
+	f.addPeerConnection = cb
+}
+
+// NewInterceptor constructs a new ReceiverInterceptor.
+func (f *ReceiverInterceptorFactory) NewInterceptor(id string) (interceptor.Interceptor, error) {
+	r := &ReceiverInterceptor{
+		buffers: make(map[uint32]*AudioJitterBuffer),
+		decoderFactories: map[string]AudioDecoderFactory{
+			"audio/opus": newOpusDecoderForStream,
+			"audio/pcmu": newG711DecoderForStream,
+			"audio/pcma": newG711DecoderForStream,
+		},
+	}
+
+	for _, opt := range f.opts {
+		if err := opt(r); err != nil {
+			return nil, err
+		}
+	}
+
+	if r.loggerFactory == nil {
+		r.loggerFactory = logging.NewDefaultLoggerFactory()
+	}
+	if r.log == nil {
+		r.log = r.loggerFactory.NewLogger("audioframe")
+	}
+
+	if f.addPeerConnection != nil {
+		f.addPeerConnection(id, r)
+	}
+
+	return r, nil
+}
+
+// ReceiverInterceptor buffers incoming audio RTP packets in an AudioJitterBuffer per stream.
+// Packets are passed through unchanged.
+//
+// Usage, every 10 ms for each audio SSRC:
+//
+//	out, err := receiver.GetAudio(ssrc)
+//	switch out.Operation {
+//	case audioframe.OperationExpand:
+//	    // Generate 10 ms of concealment audio
+//	default:
+//	    // Decode out.Frames and apply the operation
+//	}
+//
+// Opus, PCMU and PCMA are supported by default, other codecs can be added with WithAudioDecoder.
+//
+// Reference: libwebrtc audio/channel_receive.cc
+type ReceiverInterceptor struct {
+	interceptor.NoOp
+
+	buffers          map[uint32]*AudioJitterBuffer
+	buffersMu        sync.Mutex
+	decoderFactories map[string]AudioDecoderFactory
+	bufferOpts       []AudioJitterBufferOption
+	log              logging.LeveledLogger
+	loggerFactory    logging.LoggerFactory
+}
+
+// BindRemoteStream lets you modify any incoming RTP packets.
+// It is called once per RemoteStream. Only packets with the payload type of the stream
+// are inserted into its jitter buffer.
+func (r *ReceiverInterceptor) BindRemoteStream(
+	info *interceptor.StreamInfo,
+	reader interceptor.RTPReader,
+) interceptor.RTPReader {
+	factory, ok := r.decoderFactories[strings.ToLower(info.MimeType)]
+	if !ok {
+		return reader
+	}
+
+	decoder, err := factory(info)
+	if err != nil {
+		r.log.Warnf("Failed to create audio decoder for SSRC %d: %v", info.SSRC, err)
+
+		return reader
+	}
+	buffer, err := NewAudioJitterBuffer(decoder, r.bufferOpts...)
+	if err != nil {
+		r.log.Warnf("Failed to create audio jitter buffer for SSRC %d: %v", info.SSRC, err)
+
+		return reader
+	}
+
+	r.buffersMu.Lock()
+	r.buffers[info.SSRC] = buffer
+	r.buffersMu.Unlock()
+
+	return interceptor.RTPReaderFunc(func(b []byte, a interceptor.Attributes) (int, interceptor.Attributes, error) {
+		n, attrs, err := reader.Read(b, a)
+		if err != nil {
+			return n, attrs, err
+		}
+
+		pkt := &rtp.Packet{}
+		if err := pkt.Unmarshal(b[:n]); err != nil {
+			return n, attrs, nil // Pass through on parse error
+		}
+
+		// Comfort noise, telephone events and RED use other payload types on the same SSRC
+		if pkt.PayloadType != info.PayloadType {
+			return n, attrs, nil
+		}
+
+		if err := buffer.InsertPacket(pkt); err != nil {
+			r.log.Debugf("Dropping audio packet for SSRC %d: %v", info.SSRC, err)
+		}
+
+		return n, attrs, nil
+	})
+}
+
+// UnbindRemoteStream is called when the Stream is removed.
+func (r *ReceiverInterceptor) UnbindRemoteStream(info *interceptor.StreamInfo) {
+	r.buffersMu.Lock()
+	defer r.buffersMu.Unlock()
+	delete(r.buffers, info.SSRC)
+}
+
+// Close closes the interceptor.
+func (r *ReceiverInterceptor) Close() error {
+	r.buffersMu.Lock()
+	defer r.buffersMu.Unlock()
+	r.buffers = make(map[uint32]*AudioJitterBuffer)
+
+	return nil
+}
+
+// GetAudio returns the playout decision for the next 10 ms of audio of the stream
+// with the given SSRC. It must be called every 10 ms.
+// Reference: libwebrtc audio/channel_receive.cc GetAudioFrameWithInfo
+func (r *ReceiverInterceptor) GetAudio(ssrc uint32) (*AudioOutput, error) {
+	buffer, ok := r.JitterBuffer(ssrc)
+	if !ok {
+		return nil, errUnknownStream
+	}
+
+	return buffer.GetAudio(), nil
+}
+
+// JitterBuffer returns the AudioJitterBuffer of the stream with the given SSRC.
+func (r *ReceiverInterceptor) JitterBuffer(ssrc uint32) (*AudioJitterBuffer, bool) {
+	r.buffersMu.Lock()
+	defer r.buffersMu.Unlock()
+	buffer, ok := r.buffers[ssrc]
+
+	return buffer, ok
+}
+
+func newOpusDecoderForStream(*interceptor.StreamInfo) (AudioDecoder, error) {
+	return NewOpusDecoder(), nil
+}
+
+func newG711DecoderForStream(info *interceptor.StreamInfo) (AudioDecoder, error) {
+	return NewG711Decoder(int(info.Channels)), nil
+}
@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package audioframe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// packetReader returns a reader that yields the given packets in order.
+func packetReader(t *testing.T, packets []*rtp.Packet) interceptor.RTPReader {
+	t.Helper()
+
+	return interceptor.RTPReaderFunc(func(b []byte, a interceptor.Attributes) (int, interceptor.Attributes, error) {
+		pkt := packets[0]
+		packets = packets[1:]
+
+		n, err := pkt.MarshalTo(b)
+		require.NoError(t, err)
+
+		return n, a, nil
+	})
+}
+
+func newTestReceiver(t *testing.T, opts ...ReceiverInterceptorOption) *ReceiverInterceptor {
+	t.Helper()
+
+	factory, err := NewReceiverInterceptor(opts...)
+	require.NoError(t, err)
+
+	var receiver *ReceiverInterceptor
+	factory.OnNewPeerConnection(func(_ string, r *ReceiverInterceptor) {
+		receiver = r
+	})
+
+	i, err := factory.NewInterceptor("")
+	require.NoError(t, err)
+	require.Same(t, i, receiver)
+
+	return receiver
+}
+
+func TestReceiverInterceptor_GetAudio(t *testing.T) {
+	receiver := newTestReceiver(t, WithJitterBufferOptions(WithDelayBounds(0, 0)))
+	defer func() { assert.NoError(t, receiver.Close()) }()
+
+	info := &interceptor.StreamInfo{SSRC: 1234, MimeType: "audio/opus", ClockRate: 48000, Channels: 2}
+	reader := receiver.BindRemoteStream(info, packetReader(t, []*rtp.Packet{
+		{Header: rtp.Header{Version: 2, SSRC: 1234, SequenceNumber: 1, Timestamp: 960}, Payload: opusPayload},
+	}))
+
+	buf := make([]byte, 1500)
+	n, _, err := reader.Read(buf, interceptor.Attributes{})
+	require.NoError(t, err)
+
+	// Packets are passed through unchanged
+	pkt := &rtp.Packet{}
+	require.NoError(t, pkt.Unmarshal(buf[:n]))
+	assert.Equal(t, opusPayload, pkt.Payload)
+
+	out, err := receiver.GetAudio(1234)
+	require.NoError(t, err)
+	assert.Equal(t, OperationNormal, out.Operation)
+	assert.Equal(t, 480, out.Samples)
+	require.Len(t, out.Frames, 1)
+	assert.Equal(t, uint16(1), out.Frames[0].SequenceNumber)
+
+	_, err = receiver.GetAudio(5678)
+	assert.ErrorIs(t, err, errUnknownStream)
+
+	receiver.UnbindRemoteStream(info)
+	_, err = receiver.GetAudio(1234)
+	assert.ErrorIs(t, err, errUnknownStream)
+}
+
+func TestReceiverInterceptor_OtherPayloadTypes(t *testing.T) {
+	receiver := newTestReceiver(t, WithJitterBufferOptions(WithDelayBounds(0, 0)))
+	defer func() { assert.NoError(t, receiver.Close()) }()
+
+	info := &interceptor.StreamInfo{
+		SSRC: 1234, MimeType: "audio/opus", PayloadType: 111, ClockRate: 48000, Channels: 2,
+	}
+	reader := receiver.BindRemoteStream(info, packetReader(t, []*rtp.Packet{
+		// Comfort noise and a telephone event are passed through without being decoded
+		{
+			Header:  rtp.Header{Version: 2, SSRC: 1234, PayloadType: 13, SequenceNumber: 1, Timestamp: 960},
+			Payload: []byte{0x40},
+		},
+		{
+			Header:  rtp.Header{Version: 2, SSRC: 1234, PayloadType: 101, SequenceNumber: 2, Timestamp: 960},
+			Payload: []byte{0x01, 0x0a, 0x00, 0xa0},
+		},
+		{
+			Header:  rtp.Header{Version: 2, SSRC: 1234, PayloadType: 111, SequenceNumber: 3, Timestamp: 1920},
+			Payload: opusPayload,
+		},
+	}))
+
+	buf := make([]byte, 1500)
+	for j := 0; j < 3; j++ {
+		_, _, err := reader.Read(buf, interceptor.Attributes{})
+		require.NoError(t, err)
+	}
+
+	out, err := receiver.GetAudio(1234)
+	require.NoError(t, err)
+	require.Len(t, out.Frames, 1)
+	assert.Equal(t, uint16(3), out.Frames[0].SequenceNumber)
+}
+
+func TestReceiverInterceptor_G711(t *testing.T) {
+	receiver := newTestReceiver(t)
+
+	info := &interceptor.StreamInfo{SSRC: 1234, MimeType: "audio/PCMU", ClockRate: 8000}
+	reader := receiver.BindRemoteStream(info, packetReader(t, []*rtp.Packet{
+		{Header: rtp.Header{Version: 2, SSRC: 1234}, Payload: make([]byte, 160)},
+	}))
+
+	_, _, err := reader.Read(make([]byte, 1500), interceptor.Attributes{})
+	require.NoError(t, err)
+
+	buffer, ok := receiver.JitterBuffer(1234)
+	require.True(t, ok)
+	assert.Equal(t, 20*time.Millisecond, buffer.BufferLevel())
+}
+
+func TestReceiverInterceptor_Passthrough(t *testing.T) {
+	receiver := newTestReceiver(t)
+
+	info := &interceptor.StreamInfo{SSRC: 1234, MimeType: "video/VP8", ClockRate: 90000}
+	reader := receiver.BindRemoteStream(info, packetReader(t, []*rtp.Packet{
+		{Header: rtp.Header{Version: 2, SSRC: 1234}, Payload: []byte{0x01}},
+	}))
+
+	_, _, err := reader.Read(make([]byte, 1500), interceptor.Attributes{})
+	require.NoError(t, err)
+
+	_, err = receiver.GetAudio(1234)
+	assert.ErrorIs(t, err, errUnknownStream)
+}
+
+type testDecoder struct{}
+
+func (testDecoder) ParsePayload(payload []byte, timestamp uint32) ([]*EncodedAudioFrame, error) {
+	return []*EncodedAudioFrame{{Timestamp: timestamp, Data: payload, Samples: 320}}, nil
+}
+
+func (testDecoder) SampleRate() int {
+	return 16000
+}
+
+func TestReceiverInterceptor_WithAudioDecoder(t *testing.T) {
+	receiver := newTestReceiver(t, WithAudioDecoder("audio/L16", func(*interceptor.StreamInfo) (AudioDecoder, error) {
+		return testDecoder{}, nil
+	}))
+
+	info := &interceptor.StreamInfo{SSRC: 1234, MimeType: "audio/l16", ClockRate: 16000}
+	reader := receiver.BindRemoteStream(info, packetReader(t, []*rtp.Packet{
+		{Header: rtp.Header{Version: 2, SSRC: 1234}, Payload: []byte{0x01, 0x02}},
+	}))
+
+	_, _, err := reader.Read(make([]byte, 1500), interceptor.Attributes{})
+	require.NoError(t, err)
+
+	buffer, ok := receiver.JitterBuffer(1234)
+	require.True(t, ok)
+	assert.Equal(t, 20*time.Millisecond, buffer.BufferLevel())
+}
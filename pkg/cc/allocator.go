@@ -0,0 +1,406 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package cc
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+const (
+	// Reference: libwebrtc call/bitrate_allocator.cc
+	// A suspended stream or layer is resumed only when its minimum bitrate plus a toggle
+	// bitrate fits, so that it does not toggle on and off with small changes of the
+	// target. The toggle bitrate is defaultToggleFactor of the minimum bitrate, but at
+	// least minToggleBitrate.
+	defaultToggleFactor = 0.1
+	minToggleBitrate    = 20_000
+)
+
+var (
+	// ErrStreamExists is returned when a stream is added to a BitrateAllocator twice.
+	ErrStreamExists = errors.New("stream already exists")
+	// ErrInvalidStreamConfig is returned for a StreamConfig with inconsistent bitrates.
+	ErrInvalidStreamConfig = errors.New("invalid stream config")
+)
+
+// LayerConfig configures a simulcast layer of a stream.
+type LayerConfig struct {
+	// MinBitrate is the bitrate the layer needs to be sent.
+	MinBitrate int
+	// TargetBitrate is the bitrate the layer is allocated before the next layer is sent.
+	TargetBitrate int
+	// MaxBitrate is the bitrate the layer can use, if it is the highest layer sent.
+	MaxBitrate int
+}
+
+// StreamConfig configures a stream of a BitrateAllocator.
+type StreamConfig struct {
+	// MinBitrate is the bitrate the stream needs to be sent. Streams whose minimum does
+	// not fit into the target bitrate are suspended.
+	MinBitrate int
+	// MaxBitrate is the bitrate the stream can use.
+	MaxBitrate int
+	// Priority is the weight of the stream when the target bitrate above the minimum
+	// bitrates is distributed, and streams with higher priority get their minimum first.
+	// A zero priority is treated as 1.
+	Priority float64
+	// EnforceMinBitrate allocates the minimum bitrate to the stream even if it does not
+	// fit, instead of suspending the stream. It is useful for audio.
+	EnforceMinBitrate bool
+	// Layers are the simulcast layers of the stream, from the lowest to the highest. Layers
+	// are sent in order as long as they fit. If set, the minimum and maximum bitrate of the
+	// stream are derived from the layers.
+	Layers []LayerConfig
+	// OnAllocationChange is called when the allocation of the stream changes.
+	OnAllocationChange func(Allocation)
+}
+
+// Allocation is the bitrate allocated to a stream.
+type Allocation struct {
+	// Bitrate is the total bitrate allocated to the stream in bits per second.
+	Bitrate int
+	// Layers holds the bitrates of the simulcast layers of the stream. Layers that are not
+	// sent have a zero bitrate.
+	Layers []int
+	// Suspended is true if the stream does not fit into the target bitrate.
+	Suspended bool
+}
+
+func (a Allocation) equal(b Allocation) bool {
+	if a.Bitrate != b.Bitrate || a.Suspended != b.Suspended || len(a.Layers) != len(b.Layers) {
+		return false
+	}
+	for i := range a.Layers {
+		if a.Layers[i] != b.Layers[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// clone returns a copy of the allocation that does not share its layers.
+func (a Allocation) clone() Allocation {
+	if a.Layers != nil {
+		a.Layers = append([]int(nil), a.Layers...)
+	}
+
+	return a
+}
+
+type allocatedStream struct {
+	id         string
+	config     StreamConfig
+	minBitrate int
+	maxBitrate int
+	allocation Allocation
+	allocated  bool
+}
+
+// BitrateAllocatorOption configures a BitrateAllocator.
+type BitrateAllocatorOption func(*BitrateAllocator) error
+
+// BitrateAllocatorToggleFactor sets the fraction of the minimum bitrate that has to fit in
+// addition to the minimum bitrate to resume a suspended stream or layer.
+func BitrateAllocatorToggleFactor(factor float64) BitrateAllocatorOption {
+	return func(a *BitrateAllocator) error {
+		a.toggleFactor = factor
+
+		return nil
+	}
+}
+
+// BitrateAllocator distributes the target bitrate of a BandwidthEstimator among the
+// streams of a PeerConnection. Streams get their minimum bitrate in the order of their
+// priority, and the rest of the target bitrate is distributed in proportion to their
+// priority, up to their maximum bitrate. Within a stream with simulcast layers, lower
+// layers get their target bitrate before higher layers are sent.
+//
+// The allocator is updated with the target bitrate of the estimator:
+//
+//	estimator.OnTargetBitrateChange(allocator.SetTargetBitrate)
+type BitrateAllocator struct {
+	lock          sync.Mutex
+	streams       []*allocatedStream
+	targetBitrate int
+	toggleFactor  float64
+}
+
+// NewBitrateAllocator returns a new BitrateAllocator.
+func NewBitrateAllocator(opts ...BitrateAllocatorOption) (*BitrateAllocator, error) {
+	allocator := &BitrateAllocator{
+		toggleFactor: defaultToggleFactor,
+	}
+	for _, opt := range opts {
+		if err := opt(allocator); err != nil {
+			return nil, err
+		}
+	}
+
+	return allocator, nil
+}
+
+// AddStream adds a stream and reallocates the target bitrate.
+func (a *BitrateAllocator) AddStream(id string, config StreamConfig) error {
+	stream := &allocatedStream{
+		id:         id,
+		config:     config,
+		minBitrate: config.MinBitrate,
+		maxBitrate: config.MaxBitrate,
+	}
+	if stream.config.Priority <= 0 {
+		stream.config.Priority = 1
+	}
+	if len(config.Layers) > 0 {
+		stream.minBitrate = config.Layers[0].MinBitrate
+		stream.maxBitrate = config.Layers[len(config.Layers)-1].MaxBitrate
+		for i, layer := range config.Layers {
+			if layer.MinBitrate < 0 || layer.MinBitrate > layer.TargetBitrate || layer.TargetBitrate > layer.MaxBitrate {
+				return fmt.Errorf("%w: layer %v of %v", ErrInvalidStreamConfig, i, id)
+			}
+			if i < len(config.Layers)-1 {
+				stream.maxBitrate += layer.TargetBitrate
+			}
+		}
+	}
+	if stream.minBitrate < 0 || stream.minBitrate > stream.maxBitrate {
+		return fmt.Errorf("%w: %v", ErrInvalidStreamConfig, id)
+	}
+
+	a.lock.Lock()
+	for _, s := range a.streams {
+		if s.id == id {
+			a.lock.Unlock()
+
+			return fmt.Errorf("%w: %v", ErrStreamExists, id)
+		}
+	}
+	a.streams = append(a.streams, stream)
+	notify := a.allocate()
+	a.lock.Unlock()
+
+	notify()
+
+	return nil
+}
+
+// RemoveStream removes a stream and reallocates the target bitrate.
+func (a *BitrateAllocator) RemoveStream(id string) {
+	a.lock.Lock()
+	for i, s := range a.streams {
+		if s.id == id {
+			a.streams = append(a.streams[:i], a.streams[i+1:]...)
+
+			break
+		}
+	}
+	notify := a.allocate()
+	a.lock.Unlock()
+
+	notify()
+}
+
+// SetTargetBitrate sets the bitrate to distribute among the streams in bits per second.
+func (a *BitrateAllocator) SetTargetBitrate(bitrate int) {
+	a.lock.Lock()
+	a.targetBitrate = bitrate
+	notify := a.allocate()
+	a.lock.Unlock()
+
+	notify()
+}
+
+// GetAllocation returns the current allocation of a stream.
+func (a *BitrateAllocator) GetAllocation(id string) (Allocation, bool) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	for _, s := range a.streams {
+		if s.id == id {
+			return s.allocation.clone(), true
+		}
+	}
+
+	return Allocation{}, false
+}
+
+// toggleBitrate returns the bitrate that has to fit in addition to the minimum bitrate
+// to resume a suspended stream or layer.
+func (a *BitrateAllocator) toggleBitrate(minBitrate int) int {
+	return max(int(a.toggleFactor*float64(minBitrate)), minToggleBitrate)
+}
+
+// allocate distributes the target bitrate and returns a function that calls the
+// callbacks of the streams whose allocation changed. The callbacks and allocations are
+// captured with the lock held, so the function can be called after it is released.
+// This method should be called with lock held.
+func (a *BitrateAllocator) allocate() func() {
+	byPriority := make([]*allocatedStream, len(a.streams))
+	copy(byPriority, a.streams)
+	sort.SliceStable(byPriority, func(i, j int) bool {
+		return byPriority[i].config.Priority > byPriority[j].config.Priority
+	})
+
+	// Minimum bitrates in the order of priority
+	remaining := a.targetBitrate
+	bitrates := make(map[*allocatedStream]int, len(a.streams))
+	var active []*allocatedStream
+	for _, s := range byPriority {
+		required := s.minBitrate
+		if s.allocated && s.allocation.Suspended {
+			required += a.toggleBitrate(s.minBitrate)
+		}
+		if !s.config.EnforceMinBitrate && required > remaining {
+			continue
+		}
+		bitrates[s] = s.minBitrate
+		remaining = max(remaining-s.minBitrate, 0)
+		active = append(active, s)
+	}
+
+	// The rest in proportion to priority. Simulcast streams may not use all of their share
+	// if the next layer does not fit, so the rest is distributed again with their maximum
+	// reduced to what they use.
+	maxBitrates := make(map[*allocatedStream]int, len(active))
+	for _, s := range active {
+		maxBitrates[s] = s.maxBitrate
+	}
+	var layers map[*allocatedStream][]int
+	var extra map[*allocatedStream]int
+	for range active {
+		extra = distribute(remaining, active, bitrates, maxBitrates)
+		layers = make(map[*allocatedStream][]int, len(active))
+		reduced := false
+		for _, s := range active {
+			if len(s.config.Layers) == 0 {
+				continue
+			}
+			layers[s] = a.allocateLayers(s, bitrates[s]+extra[s])
+			used := 0
+			for _, bitrate := range layers[s] {
+				used += bitrate
+			}
+			if used < bitrates[s]+extra[s] {
+				maxBitrates[s] = used
+				reduced = true
+			}
+		}
+		if !reduced {
+			break
+		}
+	}
+
+	var notifications []func()
+	for _, s := range a.streams {
+		allocation := Allocation{Suspended: true}
+		if len(s.config.Layers) > 0 {
+			allocation.Layers = make([]int, len(s.config.Layers))
+		}
+		if bitrate, ok := bitrates[s]; ok {
+			allocation.Bitrate = min(bitrate+extra[s], maxBitrates[s])
+			allocation.Suspended = false
+			if layers[s] != nil {
+				allocation.Layers = layers[s]
+			}
+		}
+		changed := !s.allocated || !allocation.equal(s.allocation)
+		s.allocation = allocation
+		s.allocated = true
+		if changed && s.config.OnAllocationChange != nil {
+			callback, allocation := s.config.OnAllocationChange, allocation.clone()
+			notifications = append(notifications, func() {
+				callback(allocation)
+			})
+		}
+	}
+
+	return func() {
+		for _, notify := range notifications {
+			notify()
+		}
+	}
+}
+
+// distribute distributes bitrate among streams in proportion to their priority, without
+// exceeding their maximum bitrate, and returns the bitrate of each stream in addition to
+// its base bitrate.
+func distribute(
+	bitrate int, streams []*allocatedStream, base, maxBitrates map[*allocatedStream]int,
+) map[*allocatedStream]int {
+	extra := make(map[*allocatedStream]int, len(streams))
+	open := make([]*allocatedStream, 0, len(streams))
+	for _, s := range streams {
+		if maxBitrates[s] > base[s] {
+			open = append(open, s)
+		}
+	}
+
+	// Streams whose share exceeds their maximum are capped, and the share of the others
+	// is computed again, until all shares fit.
+	for len(open) > 0 && bitrate > 0 {
+		weight := 0.0
+		for _, s := range open {
+			weight += s.config.Priority
+		}
+		capped := false
+		for i := 0; i < len(open); i++ {
+			s := open[i]
+			room := maxBitrates[s] - base[s] - extra[s]
+			if float64(bitrate)*s.config.Priority/weight >= float64(room) {
+				extra[s] += room
+				bitrate -= room
+				open = append(open[:i], open[i+1:]...)
+				capped = true
+
+				break
+			}
+		}
+		if capped {
+			continue
+		}
+		for _, s := range open {
+			extra[s] += int(float64(bitrate) * s.config.Priority / weight)
+		}
+
+		break
+	}
+
+	return extra
+}
+
+// allocateLayers returns the bitrates of the simulcast layers of a stream for its
+// bitrate. Each layer is sent if the lower layers get their target bitrate, and its
+// minimum bitrate fits, plus the toggle bitrate if it was not sent before. The highest
+// layer sent gets the rest of the bitrate, up to its maximum.
+// This method should be called with lock held.
+func (a *BitrateAllocator) allocateLayers(s *allocatedStream, bitrate int) []int {
+	layers := s.config.Layers
+	result := make([]int, len(layers))
+
+	sent, lower := 0, 0
+	for i, layer := range layers {
+		required := lower + layer.MinBitrate
+		wasSent := s.allocated && !s.allocation.Suspended && s.allocation.Layers[i] > 0
+		if i > 0 && !wasSent {
+			required += a.toggleBitrate(layer.MinBitrate)
+		}
+		if i > 0 && required > bitrate {
+			break
+		}
+		sent = i + 1
+		lower += layer.TargetBitrate
+	}
+
+	rest := bitrate
+	for i := 0; i < sent-1; i++ {
+		result[i] = layers[i].TargetBitrate
+		rest -= layers[i].TargetBitrate
+	}
+	result[sent-1] = min(rest, layers[sent-1].MaxBitrate)
+
+	return result
+}
@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package cc
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitrateAllocator(t *testing.T) {
+	t.Run("distributes by priority up to maximum", func(t *testing.T) {
+		allocator, err := NewBitrateAllocator()
+		require.NoError(t, err)
+
+		var audio []Allocation
+		require.NoError(t, allocator.AddStream("audio", StreamConfig{
+			MinBitrate: 20_000, MaxBitrate: 64_000, Priority: 4,
+			OnAllocationChange: func(a Allocation) { audio = append(audio, a) },
+		}))
+		require.NoError(t, allocator.AddStream("video", StreamConfig{
+			MinBitrate: 100_000, MaxBitrate: 2_000_000, Priority: 1,
+		}))
+		require.NoError(t, allocator.AddStream("screen", StreamConfig{
+			MinBitrate: 100_000, MaxBitrate: 2_000_000, Priority: 2,
+		}))
+		assert.ErrorIs(t, allocator.AddStream("audio", StreamConfig{}), ErrStreamExists)
+
+		allocator.SetTargetBitrate(1_000_000)
+		// The audio share of (1000000-220000)*4/7 exceeds its maximum, the rest is split 1:2
+		assert.Equal(t, Allocation{Bitrate: 64_000}, audio[len(audio)-1])
+		video, _ := allocator.GetAllocation("video")
+		screen, _ := allocator.GetAllocation("screen")
+		assert.Equal(t, 100_000+(1_000_000-64_000-200_000)/3, video.Bitrate)
+		assert.Equal(t, 100_000+(1_000_000-64_000-200_000)*2/3, screen.Bitrate)
+
+		allocator.RemoveStream("screen")
+		video, _ = allocator.GetAllocation("video")
+		assert.Equal(t, 1_000_000-64_000, video.Bitrate)
+		_, ok := allocator.GetAllocation("screen")
+		assert.False(t, ok)
+	})
+
+	t.Run("suspends streams with hysteresis", func(t *testing.T) {
+		allocator, err := NewBitrateAllocator()
+		require.NoError(t, err)
+		require.NoError(t, allocator.AddStream("audio", StreamConfig{
+			MinBitrate: 30_000, MaxBitrate: 30_000, Priority: 2, EnforceMinBitrate: true,
+		}))
+		var video []Allocation
+		require.NoError(t, allocator.AddStream("video", StreamConfig{
+			MinBitrate: 300_000, MaxBitrate: 1_000_000,
+			OnAllocationChange: func(a Allocation) { video = append(video, a) },
+		}))
+
+		// The minimum of audio is enforced
+		allocator.SetTargetBitrate(10_000)
+		audio, _ := allocator.GetAllocation("audio")
+		assert.Equal(t, Allocation{Bitrate: 30_000}, audio)
+		assert.Equal(t, Allocation{Suspended: true}, video[len(video)-1])
+
+		// Video resumes only with the toggle bitrate of max(10%, 20kbps)
+		allocator.SetTargetBitrate(30_000 + 320_000)
+		assert.True(t, video[len(video)-1].Suspended)
+		allocator.SetTargetBitrate(30_000 + 330_000)
+		assert.Equal(t, Allocation{Bitrate: 330_000}, video[len(video)-1])
+
+		// It is suspended when the minimum does not fit anymore
+		allocator.SetTargetBitrate(30_000 + 300_000)
+		assert.Equal(t, Allocation{Bitrate: 300_000}, video[len(video)-1])
+		allocator.SetTargetBitrate(30_000 + 299_000)
+		assert.True(t, video[len(video)-1].Suspended)
+	})
+
+	t.Run("simulcast layers", func(t *testing.T) {
+		allocator, err := NewBitrateAllocator()
+		require.NoError(t, err)
+		var simulcast []Allocation
+		require.NoError(t, allocator.AddStream("simulcast", StreamConfig{
+			Layers: []LayerConfig{
+				{MinBitrate: 50_000, TargetBitrate: 150_000, MaxBitrate: 200_000},
+				{MinBitrate: 150_000, TargetBitrate: 500_000, MaxBitrate: 700_000},
+				{MinBitrate: 600_000, TargetBitrate: 2_000_000, MaxBitrate: 2_500_000},
+			},
+			OnAllocationChange: func(a Allocation) { simulcast = append(simulcast, a) },
+		}))
+		require.NoError(t, allocator.AddStream("video", StreamConfig{MinBitrate: 0, MaxBitrate: 10_000_000}))
+
+		// share returns the target bitrate at which the simulcast stream gets bitrate, half of
+		// the target above its minimum
+		share := func(bitrate int) int {
+			return 2*bitrate - 50_000
+		}
+
+		allocator.SetTargetBitrate(share(75_000))
+		assert.Equal(t, Allocation{Bitrate: 75_000, Layers: []int{75_000, 0, 0}}, simulcast[len(simulcast)-1])
+
+		// The second layer needs the target of the first plus its minimum and the toggle bitrate
+		allocator.SetTargetBitrate(share(300_000))
+		assert.Equal(t, Allocation{Bitrate: 200_000, Layers: []int{200_000, 0, 0}}, simulcast[len(simulcast)-1])
+		// The rest goes to the other stream
+		video, _ := allocator.GetAllocation("video")
+		assert.Equal(t, share(300_000)-200_000, video.Bitrate)
+
+		allocator.SetTargetBitrate(share(400_000))
+		assert.Equal(t, Allocation{Bitrate: 400_000, Layers: []int{150_000, 250_000, 0}}, simulcast[len(simulcast)-1])
+
+		// The second layer stays on until its minimum does not fit anymore
+		allocator.SetTargetBitrate(share(310_000))
+		assert.Equal(t, Allocation{Bitrate: 310_000, Layers: []int{150_000, 160_000, 0}}, simulcast[len(simulcast)-1])
+		allocator.SetTargetBitrate(share(290_000))
+		assert.Equal(t, Allocation{Bitrate: 200_000, Layers: []int{200_000, 0, 0}}, simulcast[len(simulcast)-1])
+
+		allocator.SetTargetBitrate(10_000_000)
+		assert.Equal(t, Allocation{
+			Bitrate: 3_150_000, Layers: []int{150_000, 500_000, 2_500_000},
+		}, simulcast[len(simulcast)-1])
+	})
+
+	t.Run("concurrent updates", func(t *testing.T) {
+		allocator, err := NewBitrateAllocator()
+		require.NoError(t, err)
+
+		var lock sync.Mutex
+		total := 0
+		onChange := func(a Allocation) {
+			lock.Lock()
+			defer lock.Unlock()
+			for _, bitrate := range a.Layers {
+				total += bitrate
+			}
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				allocator.SetTargetBitrate(100_000 + i*10_000)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				assert.NoError(t, allocator.AddStream(fmt.Sprint(i), StreamConfig{
+					Layers: []LayerConfig{
+						{MinBitrate: 10_000, TargetBitrate: 20_000, MaxBitrate: 30_000},
+						{MinBitrate: 50_000, TargetBitrate: 100_000, MaxBitrate: 150_000},
+					},
+					OnAllocationChange: onChange,
+				}))
+			}
+		}()
+		wg.Wait()
+
+		for i := 0; i < 20; i++ {
+			allocation, ok := allocator.GetAllocation(fmt.Sprint(i))
+			require.True(t, ok)
+			assert.Len(t, allocation.Layers, 2)
+		}
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		allocator, err := NewBitrateAllocator()
+		require.NoError(t, err)
+		assert.ErrorIs(t, allocator.AddStream("a", StreamConfig{MinBitrate: 2, MaxBitrate: 1}), ErrInvalidStreamConfig)
+		assert.ErrorIs(t, allocator.AddStream("b", StreamConfig{
+			Layers: []LayerConfig{{MinBitrate: 100, TargetBitrate: 50, MaxBitrate: 200}},
+		}), ErrInvalidStreamConfig)
+	})
+}
@@ -29,6 +29,12 @@ type BandwidthEstimator interface {
 	Close() error
 }
 
+// RTCPWriterBinder is implemented by BandwidthEstimators that send RTCP feedback,
+// for example TMMBN in response to TMMBR. The interceptor binds its RTCP writer to them.
+type RTCPWriterBinder interface {
+	BindRTCPWriter(writer interceptor.RTCPWriter)
+}
+
 // NewPeerConnectionCallback returns the BandwidthEstimator for the
 // PeerConnection with id.
 type NewPeerConnectionCallback func(id string, estimator BandwidthEstimator)
@@ -124,6 +130,16 @@ func (c *Interceptor) BindRTCPReader(reader interceptor.RTCPReader) interceptor.
 	})
 }
 
+// BindRTCPWriter lets you modify any outgoing RTCP packets. It is called once per PeerConnection. The returned method
+// will be called once per packet batch.
+func (c *Interceptor) BindRTCPWriter(writer interceptor.RTCPWriter) interceptor.RTCPWriter {
+	if binder, ok := c.estimator.(RTCPWriterBinder); ok {
+		binder.BindRTCPWriter(writer)
+	}
+
+	return writer
+}
+
 // BindLocalStream lets you modify any outgoing RTP packets. It is called once
 // for per LocalStream. The returned method will be called once per rtp packet.
 func (c *Interceptor) BindLocalStream(
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package gcc
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// Reference: libwebrtc modules/congestion_controller/goog_cc/alr_detector.cc
+	// alrBandwidthUsageRatio is the fraction of the estimate the sender has to use to
+	// not be application limited.
+	alrBandwidthUsageRatio = 0.65
+	// alrStartBudgetLevelRatio is the unused fraction of the budget that starts the ALR.
+	alrStartBudgetLevelRatio = 0.80
+	// alrStopBudgetLevelRatio is the unused fraction of the budget that stops the ALR.
+	alrStopBudgetLevelRatio = 0.50
+	// alrBudgetWindow is the time the unused budget can build up over.
+	alrBudgetWindow = 500 * time.Millisecond
+)
+
+// alrDetector detects application limited regions, in which the sender sends less than
+// the estimate allows, because the application does not produce enough data. It keeps a
+// budget at a fraction of the estimate, spends it with the bytes the pacer sends, and
+// is in the ALR while most of the budget remains unused.
+// Reference: libwebrtc modules/congestion_controller/goog_cc/alr_detector.cc
+type alrDetector struct {
+	lock sync.Mutex
+
+	targetRate     int
+	bytesRemaining float64
+	lastSend       time.Time
+	startTime      time.Time
+}
+
+func newALRDetector(bitrate int) *alrDetector {
+	return &alrDetector{
+		targetRate: int(alrBandwidthUsageRatio * float64(bitrate)),
+	}
+}
+
+// onBytesSent spends the budget with a packet sent at now, and refills it for the time
+// since the previous update. It is called with zero bytes periodically, so that the
+// budget builds up while nothing is sent.
+func (d *alrDetector) onBytesSent(size int, now time.Time) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.lastSend.IsZero() {
+		d.lastSend = now
+
+		return
+	}
+	delta := now.Sub(d.lastSend)
+	d.lastSend = now
+
+	maxBytes := d.maxBytes()
+	d.bytesRemaining = max(d.bytesRemaining-float64(size), -maxBytes)
+	d.bytesRemaining = min(d.bytesRemaining+float64(d.targetRate)*delta.Seconds()/8, maxBytes)
+
+	ratio := 0.0
+	if maxBytes > 0 {
+		ratio = d.bytesRemaining / maxBytes
+	}
+	if ratio > alrStartBudgetLevelRatio && d.startTime.IsZero() {
+		d.startTime = now
+	} else if ratio < alrStopBudgetLevelRatio && !d.startTime.IsZero() {
+		d.startTime = time.Time{}
+	}
+}
+
+// setEstimatedBitrate updates the budget to a new estimate.
+func (d *alrDetector) setEstimatedBitrate(bitrate int) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.targetRate = int(alrBandwidthUsageRatio * float64(bitrate))
+	maxBytes := d.maxBytes()
+	d.bytesRemaining = min(max(d.bytesRemaining, -maxBytes), maxBytes)
+}
+
+// applicationLimited reports whether the sender is in an ALR.
+func (d *alrDetector) applicationLimited() bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return !d.startTime.IsZero()
+}
+
+// maxBytes returns the size of the budget.
+// This method should be called with lock held.
+func (d *alrDetector) maxBytes() float64 {
+	return float64(d.targetRate) * alrBudgetWindow.Seconds() / 8
+}
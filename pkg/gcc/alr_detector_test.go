@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package gcc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestALRDetector(t *testing.T) {
+	now := time.Time{}.Add(time.Hour)
+	detector := newALRDetector(1_000_000)
+
+	// send at the given bitrate for a duration, in packets every 10ms
+	send := func(bitrate int, duration time.Duration) {
+		for elapsed := time.Duration(0); elapsed < duration; elapsed += 10 * time.Millisecond {
+			now = now.Add(10 * time.Millisecond)
+			detector.onBytesSent(bitrate/8/100, now)
+		}
+	}
+
+	send(1_000_000, time.Second)
+	assert.False(t, detector.applicationLimited())
+
+	// Sending a fifth of the estimate builds up the unused budget
+	send(200_000, 2*time.Second)
+	assert.True(t, detector.applicationLimited())
+
+	// The budget has to be used again before the ALR ends
+	send(700_000, 100*time.Millisecond)
+	assert.True(t, detector.applicationLimited())
+	send(1_000_000, time.Second)
+	assert.False(t, detector.applicationLimited())
+
+	// A lower estimate is used by the sender
+	send(200_000, 2*time.Second)
+	assert.True(t, detector.applicationLimited())
+	detector.setEstimatedBitrate(250_000)
+	send(200_000, 2*time.Second)
+	assert.False(t, detector.applicationLimited())
+}
+
+func TestALRDetector_IdleSender(t *testing.T) {
+	now := time.Time{}.Add(time.Hour)
+	detector := newALRDetector(1_000_000)
+
+	// The periodic updates without packets build up the unused budget
+	for elapsed := time.Duration(0); elapsed < time.Second; elapsed += 5 * time.Millisecond {
+		now = now.Add(5 * time.Millisecond)
+		detector.onBytesSent(0, now)
+	}
+	assert.True(t, detector.applicationLimited())
+}
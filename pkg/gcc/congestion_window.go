@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package gcc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pion/interceptor/internal/cc"
+)
+
+const (
+	// Reference: libwebrtc modules/congestion_controller/goog_cc/goog_cc_network_control.cc
+	// acceptedQueueDelay is the queuing delay the congestion window allows for in addition
+	// to the RTT.
+	acceptedQueueDelay = 350 * time.Millisecond
+	// minCongestionWindow is two full size packets.
+	minCongestionWindow = 2 * 1500
+
+	// Reference: libwebrtc modules/congestion_controller/goog_cc/congestion_window_pushback_controller.cc
+	// minPushbackBitrate is the lowest bitrate pushback reduces the target to.
+	minPushbackBitrate = 30_000
+
+	// outstandingTimeout is how long a packet without feedback counts as outstanding, so
+	// that lost feedback does not stall sending forever.
+	outstandingTimeout = 2 * time.Second
+	// pausedCheckInterval is how often a paused pacer checks if packets timed out, which
+	// resumes it when feedback stopped arriving.
+	pausedCheckInterval = 100 * time.Millisecond
+)
+
+// congestionWindow wraps a Pacer and limits the data in flight. The window is the data
+// the estimate allows within the RTT, and the bytes sent but not yet reported by feedback
+// are outstanding. While the outstanding bytes fill the window, the target bitrate of the
+// pacer is pushed back, or the pacer is paused with a zero target bitrate. A paused pacer
+// is resumed by feedback, or once the outstanding packets time out.
+type congestionWindow struct {
+	Pacer
+
+	lock          sync.Mutex
+	adapter       *cc.FeedbackAdapter
+	pause         bool
+	targetBitrate int
+	pacingBitrate int
+	window        int
+	outstanding   int
+	ratio         float64
+
+	timeout       time.Duration
+	checkInterval time.Duration
+	checkTimer    *time.Timer
+	closed        bool
+}
+
+func newCongestionWindow(pacer Pacer, adapter *cc.FeedbackAdapter, initialBitrate int, pause bool) *congestionWindow {
+	return &congestionWindow{
+		Pacer:         pacer,
+		adapter:       adapter,
+		pause:         pause,
+		targetBitrate: initialBitrate,
+		pacingBitrate: initialBitrate,
+		ratio:         1,
+		timeout:       outstandingTimeout,
+		checkInterval: pausedCheckInterval,
+	}
+}
+
+// Close stops checking the window and closes the pacer.
+func (w *congestionWindow) Close() error {
+	w.lock.Lock()
+	w.closed = true
+	if w.checkTimer != nil {
+		w.checkTimer.Stop()
+		w.checkTimer = nil
+	}
+	w.lock.Unlock()
+
+	return w.Pacer.Close()
+}
+
+// SetTargetBitrate sets the target bitrate of the pacer before pushback.
+func (w *congestionWindow) SetTargetBitrate(rate int) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.targetBitrate = rate
+	w.apply()
+}
+
+// update recomputes the window from the estimate and RTT, and adjusts the pushback to how
+// full the window is.
+func (w *congestionWindow) update(estimate int, rtt time.Duration, now time.Time) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.window = max(minCongestionWindow, int(float64(estimate)*(rtt+acceptedQueueDelay).Seconds()/8))
+	w.outstanding = w.adapter.OutstandingBytes(now.Add(-w.timeout))
+
+	fill := float64(w.outstanding) / float64(w.window)
+	switch {
+	case fill > 1.5:
+		w.ratio *= 0.9
+	case fill > 1:
+		w.ratio *= 0.95
+	case fill < 0.1:
+		w.ratio = 1
+	default:
+		w.ratio = min(w.ratio*1.05, 1)
+	}
+	w.apply()
+}
+
+// onPacketSent pauses the pacer as soon as a sent packet fills the window.
+func (w *congestionWindow) onPacketSent(now time.Time) {
+	if !w.pause {
+		return
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.window == 0 {
+		return
+	}
+	w.outstanding = w.adapter.OutstandingBytes(now.Add(-w.timeout))
+	w.apply()
+}
+
+// check updates the outstanding bytes of a paused pacer without feedback.
+func (w *congestionWindow) check() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.checkTimer = nil
+	if w.closed {
+		return
+	}
+	w.outstanding = w.adapter.OutstandingBytes(time.Now().Add(-w.timeout))
+	w.apply()
+}
+
+// stats returns the window, the outstanding bytes at now and the pushback ratio.
+func (w *congestionWindow) stats(now time.Time) (int, int, float64) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.window, w.adapter.OutstandingBytes(now.Add(-w.timeout)), w.ratio
+}
+
+// apply sets the target bitrate of the pacer after pushback. The pushback does not reduce
+// the bitrate below minPushbackBitrate, unless the target is lower already.
+// This method should be called with lock held.
+func (w *congestionWindow) apply() {
+	rate := int(w.ratio * float64(w.targetBitrate))
+	if rate < minPushbackBitrate {
+		rate = min(w.targetBitrate, minPushbackBitrate)
+	}
+	if w.pause && w.window > 0 && w.outstanding >= w.window {
+		rate = 0
+		if w.checkTimer == nil && !w.closed {
+			w.checkTimer = time.AfterFunc(w.checkInterval, w.check)
+		}
+	}
+	if rate != w.pacingBitrate {
+		w.pacingBitrate = rate
+		w.Pacer.SetTargetBitrate(rate)
+	}
+}
@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package gcc
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/interceptor/internal/cc"
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockRatePacer records the target bitrates it is set to.
+type mockRatePacer struct {
+	NoOpPacer
+	lock  sync.Mutex
+	rates []int
+}
+
+func (p *mockRatePacer) SetTargetBitrate(rate int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.rates = append(p.rates, rate)
+}
+
+func (p *mockRatePacer) lastRate() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if len(p.rates) == 0 {
+		return -1
+	}
+
+	return p.rates[len(p.rates)-1]
+}
+
+func TestCongestionWindow(t *testing.T) {
+	now := time.Time{}.Add(time.Hour)
+	send := func(t *testing.T, adapter *cc.FeedbackAdapter, first uint16, count int) {
+		t.Helper()
+		for i := 0; i < count; i++ {
+			header := &rtp.Header{SequenceNumber: first + uint16(i), SSRC: 1} //nolint:gosec // G115
+			require.NoError(t, adapter.OnSent(now, header, 1000, nil))
+		}
+	}
+	acknowledge := func(adapter *cc.FeedbackAdapter, first uint16, count int) {
+		blocks := make([]rtcp.CCFeedbackMetricBlock, count)
+		for i := range blocks {
+			blocks[i].Received = true
+		}
+		adapter.OnRFC8888Feedback(now, &rtcp.CCFeedbackReport{
+			ReportBlocks: []rtcp.CCFeedbackReportBlock{{MediaSSRC: 1, BeginSequence: first, MetricBlocks: blocks}},
+		})
+	}
+
+	t.Run("pushback", func(t *testing.T) {
+		adapter := cc.NewFeedbackAdapter()
+		pacer := &mockRatePacer{}
+		window := newCongestionWindow(pacer, adapter, 1_000_000, false)
+
+		// 1 Mbit/s over 50ms RTT and 350ms accepted queue delay is a window of 50000 bytes
+		send(t, adapter, 0, 60)
+		window.update(1_000_000, 50*time.Millisecond, now)
+		size, outstanding, ratio := window.stats(now)
+		assert.Equal(t, 50_000, size)
+		assert.Equal(t, 60_000, outstanding)
+		assert.InDelta(t, 0.95, ratio, 1e-9)
+		assert.Equal(t, []int{950_000}, pacer.rates)
+
+		send(t, adapter, 60, 20)
+		window.update(1_000_000, 50*time.Millisecond, now)
+		assert.Equal(t, []int{950_000, 855_000}, pacer.rates)
+
+		// The target bitrate is pushed back by the same ratio
+		window.SetTargetBitrate(500_000)
+		assert.Equal(t, 427_500, pacer.rates[len(pacer.rates)-1])
+
+		// Emptying the window removes the pushback
+		acknowledge(adapter, 0, 80)
+		window.update(500_000, 50*time.Millisecond, now)
+		assert.Equal(t, 500_000, pacer.rates[len(pacer.rates)-1])
+	})
+
+	t.Run("pushback does not go below minimum", func(t *testing.T) {
+		adapter := cc.NewFeedbackAdapter()
+		pacer := &mockRatePacer{}
+		window := newCongestionWindow(pacer, adapter, 40_000, false)
+
+		send(t, adapter, 0, 10)
+		for i := 0; i < 5; i++ {
+			window.update(40_000, 50*time.Millisecond, now)
+		}
+		assert.Equal(t, []int{36_000, 32_400, 30_000}, pacer.rates)
+	})
+
+	t.Run("pause when full", func(t *testing.T) {
+		adapter := cc.NewFeedbackAdapter()
+		pacer := &mockRatePacer{}
+		window := newCongestionWindow(pacer, adapter, 1_000_000, true)
+		window.checkInterval = time.Hour
+		defer func() {
+			assert.NoError(t, window.Close())
+		}()
+
+		send(t, adapter, 0, 10)
+		window.update(1_000_000, 50*time.Millisecond, now)
+		assert.Empty(t, pacer.rates)
+
+		send(t, adapter, 10, 40)
+		window.onPacketSent(now)
+		assert.Equal(t, []int{0}, pacer.rates)
+
+		acknowledge(adapter, 0, 10)
+		window.update(1_000_000, 50*time.Millisecond, now)
+		assert.Equal(t, []int{0, 1_000_000}, pacer.rates)
+
+		// Packets without feedback stop counting as outstanding after a timeout
+		send(t, adapter, 50, 10)
+		window.onPacketSent(now)
+		assert.Equal(t, []int{0, 1_000_000, 0}, pacer.rates)
+		window.onPacketSent(now.Add(outstandingTimeout + time.Millisecond))
+		assert.Equal(t, []int{0, 1_000_000, 0, 1_000_000}, pacer.rates)
+	})
+
+	t.Run("resume without feedback", func(t *testing.T) {
+		adapter := cc.NewFeedbackAdapter()
+		pacer := &mockRatePacer{}
+		window := newCongestionWindow(pacer, adapter, 1_000_000, true)
+		window.timeout = 50 * time.Millisecond
+		window.checkInterval = 10 * time.Millisecond
+		defer func() {
+			assert.NoError(t, window.Close())
+		}()
+
+		sent := time.Now()
+		for i := 0; i < 50; i++ {
+			header := &rtp.Header{SequenceNumber: uint16(i), SSRC: 1} //nolint:gosec // G115
+			require.NoError(t, adapter.OnSent(sent, header, 1000, nil))
+		}
+		window.update(1_000_000, 50*time.Millisecond, sent)
+		window.onPacketSent(sent)
+		assert.Equal(t, 0, pacer.lastRate())
+
+		// Feedback never arrives, the pacer resumes once the packets time out
+		assert.Eventually(t, func() bool {
+			return pacer.lastRate() == 1_000_000
+		}, time.Second, 5*time.Millisecond)
+		assert.GreaterOrEqual(t, time.Since(sent), window.timeout)
+	})
+}
+
+func TestSendSideBWECongestionWindowStats(t *testing.T) {
+	bwe, err := NewSendSideBWE(SendSideBWEPacer(NewNoOpPacer()), SendSideBWECongestionWindow(false))
+	require.NoError(t, err)
+
+	writer := bwe.AddStream(&interceptor.StreamInfo{SSRC: 1}, &mockRTPRecorder{})
+	_, err = writer.Write(&rtp.Header{SSRC: 1}, make([]byte, 1000), nil)
+	require.NoError(t, err)
+
+	stats := bwe.GetStats()
+	assert.Equal(t, 1000, stats["outstandingBytes"])
+	require.NoError(t, bwe.Close())
+}
@@ -45,6 +45,14 @@ type delayControllerConfig struct {
 	initialBitrate int
 	minBitrate     int
 	maxBitrate     int
+
+	// trendlineWindowSize selects the trendline estimator if non-zero, and the
+	// Kalman filter otherwise.
+	trendlineWindowSize int
+	trendlineSmoothing  float64
+
+	// applicationLimited freezes increases of the target while it reports true, if set.
+	applicationLimited func() bool
 }
 
 func newDelayController(delayConfig delayControllerConfig, loggerFactory logging.LoggerFactory) *delayController {
@@ -70,9 +78,15 @@ func newDelayController(delayConfig delayControllerConfig, loggerFactory logging
 			}
 		},
 	)
+	rateController.applicationLimited = delayConfig.applicationLimited
 	delayController.rateController = rateController
 	overuseDetector := newOveruseDetector(newAdaptiveThreshold(), 10*time.Millisecond, rateController.onDelayStats)
-	slopeEstimator := newSlopeEstimator(newKalman(), overuseDetector.onDelayStats)
+	onArrivalGroup := newSlopeEstimator(newKalman(), overuseDetector.onDelayStats).onArrivalGroup
+	if delayConfig.trendlineWindowSize > 0 {
+		onArrivalGroup = newTrendlineEstimator(
+			delayConfig.trendlineWindowSize, delayConfig.trendlineSmoothing, overuseDetector.onDelayStats,
+		).onArrivalGroup
+	}
 	arrivalGroupAccumulator := newArrivalGroupAccumulator()
 
 	rc := newRateCalculator(500 * time.Millisecond)
@@ -80,7 +94,7 @@ func newDelayController(delayConfig delayControllerConfig, loggerFactory logging
 	delayController.wg.Add(2)
 	go func() {
 		defer delayController.wg.Done()
-		arrivalGroupAccumulator.run(ackPipe, slopeEstimator.onArrivalGroup)
+		arrivalGroupAccumulator.run(ackPipe, onArrivalGroup)
 	}()
 	go func() {
 		defer delayController.wg.Done()
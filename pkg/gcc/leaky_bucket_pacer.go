@@ -10,12 +10,17 @@ import (
 	"time"
 
 	"github.com/pion/interceptor"
+	"github.com/pion/interceptor/internal/padding"
 	"github.com/pion/logging"
 	"github.com/pion/rtp"
 )
 
 var errLeakyBucketPacerPoolCastFailed = errors.New("failed to access leaky bucket pacer pool, cast failed")
 
+// maxPaddingIntervals is the number of pacing intervals the budget of padding is carried
+// over for while nothing is sent, so that padding is not sent in a burst after an idle period.
+const maxPaddingIntervals = 2
+
 type item struct {
 	header     *rtp.Header
 	payload    *[]byte
@@ -24,11 +29,17 @@ type item struct {
 }
 
 // LeakyBucketPacer implements a leaky bucket pacing algorithm.
+//
+// If a padding rate is set, the pacer sends padding when its queue is empty, up to the
+// padding rate or the target bitrate, whichever is lower. Padding retransmits recently
+// sent packets on the RTX SSRC of a stream, or is sent in padding-only packets on the RTX
+// SSRC if there are no recent packets.
 type LeakyBucketPacer struct {
 	log logging.LeveledLogger
 
 	f                 float64
 	targetBitrate     int
+	paddingRate       int
 	targetBitrateLock sync.Mutex
 
 	pacingInterval time.Duration
@@ -41,6 +52,9 @@ type LeakyBucketPacer struct {
 	writerLock   sync.RWMutex
 
 	pool *sync.Pool
+
+	padding       *padding.Generator
+	paddingBudget *padding.Budget
 }
 
 // NewLeakyBucketPacer initializes a new LeakyBucketPacer.
@@ -59,6 +73,8 @@ func newLeakyBucketPacer(initialBitrate int, loggerFactory logging.LoggerFactory
 		done:           make(chan struct{}),
 		ssrcToWriter:   map[uint32]interceptor.RTPWriter{},
 		pool:           &sync.Pool{},
+		padding:        padding.NewGenerator(),
+		paddingBudget:  &padding.Budget{},
 	}
 	pacer.pool = &sync.Pool{
 		New: func() any {
@@ -80,12 +96,28 @@ func (p *LeakyBucketPacer) AddStream(ssrc uint32, writer interceptor.RTPWriter)
 	p.ssrcToWriter[ssrc] = writer
 }
 
+// AddStreamInfo registers the RTX SSRC and payload type of a local stream, which carry
+// the padding of the stream. The writer of the RTX SSRC has to be added with AddStream.
+func (p *LeakyBucketPacer) AddStreamInfo(info *interceptor.StreamInfo) {
+	p.padding.AddStream(info)
+}
+
 // SetTargetBitrate updates the target bitrate at which the pacer is allowed to
 // send packets. The pacer may exceed this limit by p.f.
 func (p *LeakyBucketPacer) SetTargetBitrate(rate int) {
 	p.targetBitrateLock.Lock()
 	defer p.targetBitrateLock.Unlock()
 	p.targetBitrate = int(p.f * float64(rate))
+	p.paddingBudget.SetRate(min(p.paddingRate, rate))
+}
+
+// SetPaddingRate sets the rate up to which the pacer sends padding. The padding does not
+// exceed the target bitrate. A zero rate disables padding.
+func (p *LeakyBucketPacer) SetPaddingRate(rate int) {
+	p.targetBitrateLock.Lock()
+	defer p.targetBitrateLock.Unlock()
+	p.paddingRate = rate
+	p.paddingBudget.SetRate(min(rate, int(float64(p.targetBitrate)/p.f)))
 }
 
 func (p *LeakyBucketPacer) getTargetBitrate() int {
@@ -130,6 +162,7 @@ func (p *LeakyBucketPacer) Run() {
 			return
 		case now := <-ticker.C:
 			budget := int(float64(now.Sub(lastSent).Milliseconds()) * float64(p.getTargetBitrate()) / 8000.0)
+			p.paddingBudget.Update(now)
 			p.qLock.Lock()
 			for p.queue.Len() != 0 && budget > 0 {
 				p.log.Infof("budget=%v, len(queue)=%v, targetBitrate=%v", budget, p.queue.Len(), p.getTargetBitrate())
@@ -152,21 +185,65 @@ func (p *LeakyBucketPacer) Run() {
 					continue
 				}
 
+				p.padding.OnSent(next.header, (*next.payload)[:next.size], now)
 				n, err := writer.Write(next.header, (*next.payload)[:next.size], next.attributes)
 				if err != nil {
 					p.log.Errorf("failed to write packet: %v", err)
 				}
 				lastSent = now
 				budget -= n
+				p.paddingBudget.Consume(next.header.MarshalSize() + next.size + int(next.header.PaddingSize))
 
 				p.pool.Put(next.payload)
 				p.qLock.Lock()
 			}
+			empty := p.queue.Len() == 0
 			p.qLock.Unlock()
+
+			if empty && budget > 0 {
+				elapsed := min(now.Sub(lastSent), maxPaddingIntervals*p.pacingInterval)
+				limit := int(float64(elapsed.Milliseconds()) * float64(p.getTargetBitrate()) / 8000.0)
+				if p.sendPadding(now, min(budget, limit)) {
+					lastSent = now
+				}
+			}
 		}
 	}
 }
 
+// sendPadding sends padding packets while the padding budget and the given budget in
+// bytes allow, and returns true if any padding was sent.
+func (p *LeakyBucketPacer) sendPadding(now time.Time, budget int) bool {
+	sent := false
+	for budget > 0 && p.paddingBudget.Available() {
+		header, payload, ok := p.padding.Next(now)
+		if !ok {
+			break
+		}
+		p.writerLock.RLock()
+		writer, ok := p.ssrcToWriter[header.SSRC]
+		p.writerLock.RUnlock()
+		if !ok {
+			p.log.Warnf("no writer found for padding ssrc: %v", header.SSRC)
+
+			break
+		}
+
+		p.padding.OnSent(header, payload, now)
+		if _, err := writer.Write(header, payload, nil); err != nil {
+			p.log.Errorf("failed to write padding: %v", err)
+
+			break
+		}
+		size := header.MarshalSize() + len(payload) + int(header.PaddingSize)
+		p.paddingBudget.Consume(size)
+		budget -= size
+		sent = true
+	}
+
+	return sent
+}
+
 // Close closes the LeakyBucketPacer.
 func (p *LeakyBucketPacer) Close() error {
 	close(p.done)
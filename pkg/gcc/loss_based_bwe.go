@@ -30,6 +30,13 @@ type LossStats struct {
 	AverageLoss   float64
 }
 
+// lossEstimator is implemented by the loss based bandwidth estimators.
+type lossEstimator interface {
+	getEstimate(wantedRate int) LossStats
+	onProbeResult(bitrate int)
+	updateLossEstimate(results []cc.Acknowledgment)
+}
+
 type lossBasedBandwidthEstimator struct {
 	lock           sync.Mutex
 	maxBitrate     int
@@ -71,6 +78,15 @@ func (e *lossBasedBandwidthEstimator) getEstimate(wantedRate int) LossStats {
 	}
 }
 
+// onProbeResult raises the estimate to a probe result, so that it does not limit the
+// delay based estimate adopting the probe result.
+func (e *lossBasedBandwidthEstimator) onProbeResult(bitrate int) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.bitrate = max(e.bitrate, clampInt(bitrate, e.minBitrate, e.maxBitrate))
+}
+
 func (e *lossBasedBandwidthEstimator) updateLossEstimate(results []cc.Acknowledgment) {
 	if len(results) == 0 {
 		return
@@ -0,0 +1,278 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package gcc
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pion/interceptor/internal/cc"
+	"github.com/pion/logging"
+)
+
+const (
+	// Reference: libwebrtc modules/congestion_controller/goog_cc/loss_based_bwe_v2.cc
+	observationDurationLowerBound = 250 * time.Millisecond
+	observationWindowSize         = 20
+	temporalWeightFactor          = 0.9
+
+	inherentLossLowerBound = 1.0e-3
+	// The inherent loss is bounded by inherentLossUpperBoundOffset plus
+	// inherentLossUpperBoundBandwidthBalance divided by the bandwidth, so that high loss
+	// at high bandwidths is attributed to congestion.
+	inherentLossUpperBoundOffset           = 0.05
+	inherentLossUpperBoundBandwidthBalance = 75_000
+	newtonIterations                       = 1
+	newtonStepSize                         = 0.75
+
+	higherBandwidthBiasFactor    = 0.0002
+	higherLogBandwidthBiasFactor = 0.02
+
+	// lossProbabilityBound keeps the loss probabilities of the model away from 0 and 1.
+	lossProbabilityBound = 1.0e-6
+)
+
+// candidateFactors are the factors of the current estimate that are evaluated as candidates.
+var candidateFactors = []float64{1.02, 1.0, 0.95} //nolint:gochecknoglobals
+
+// lossObservation aggregates the acknowledgments of packets sent during at least
+// observationDurationLowerBound.
+type lossObservation struct {
+	packets     int
+	lost        int
+	size        int
+	lostSize    int
+	sendingRate float64
+	firstSend   time.Time
+	lastSend    time.Time
+}
+
+// lossCandidate is a bandwidth with the inherent loss that best explains the observations at it.
+type lossCandidate struct {
+	bandwidth    float64
+	inherentLoss float64
+}
+
+// lossBasedBandwidthEstimatorV2 fits a loss model to the observed loss at the acknowledged
+// send rates. The model assumes an inherent, random loss, plus the loss caused by sending
+// above the bandwidth of the link. Of a set of candidate bandwidths, the one that best
+// explains the recent observations becomes the estimate. Unlike the threshold based
+// estimator, random loss is attributed to the inherent loss and does not decrease the estimate.
+// Reference: libwebrtc modules/congestion_controller/goog_cc/loss_based_bwe_v2.cc
+type lossBasedBandwidthEstimatorV2 struct {
+	lock       sync.Mutex
+	minBitrate int
+	maxBitrate int
+
+	bitrate      int
+	delayBitrate int
+	inherentLoss float64
+	averageLoss  float64
+
+	partial      lossObservation
+	observations []lossObservation
+
+	log logging.LeveledLogger
+}
+
+func newLossBasedBWEV2(
+	initialBitrate, minBitrate, maxBitrate int, loggerFactory logging.LoggerFactory,
+) *lossBasedBandwidthEstimatorV2 {
+	return &lossBasedBandwidthEstimatorV2{
+		minBitrate:   minBitrate,
+		maxBitrate:   maxBitrate,
+		bitrate:      initialBitrate,
+		inherentLoss: inherentLossLowerBound,
+		log:          loggerFactory.NewLogger("gcc_loss_controller_v2"),
+	}
+}
+
+func (e *lossBasedBandwidthEstimatorV2) getEstimate(wantedRate int) LossStats {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.delayBitrate = wantedRate
+	if e.bitrate <= 0 {
+		e.bitrate = clampInt(wantedRate, e.minBitrate, e.maxBitrate)
+	}
+
+	return LossStats{
+		TargetBitrate: min(wantedRate, e.bitrate),
+		AverageLoss:   e.averageLoss,
+	}
+}
+
+// onProbeResult raises the estimate to a probe result, so that it does not limit the
+// delay based estimate adopting the probe result.
+func (e *lossBasedBandwidthEstimatorV2) onProbeResult(bitrate int) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.bitrate = max(e.bitrate, clampInt(bitrate, e.minBitrate, e.maxBitrate))
+}
+
+func (e *lossBasedBandwidthEstimatorV2) updateLossEstimate(results []cc.Acknowledgment) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	for _, ack := range results {
+		if ack.Departure.IsZero() {
+			continue
+		}
+		o := &e.partial
+		if o.packets == 0 || ack.Departure.Before(o.firstSend) {
+			o.firstSend = ack.Departure
+		}
+		if o.packets == 0 || ack.Departure.After(o.lastSend) {
+			o.lastSend = ack.Departure
+		}
+		o.packets++
+		o.size += ack.Size
+		if ack.Arrival.IsZero() {
+			o.lost++
+			o.lostSize += ack.Size
+		}
+
+		if duration := o.lastSend.Sub(o.firstSend); duration >= observationDurationLowerBound {
+			o.sendingRate = float64(8*o.size) / duration.Seconds()
+			e.addObservation(*o)
+			e.partial = lossObservation{}
+		}
+	}
+}
+
+// addObservation adds a completed observation and updates the estimate.
+// This method should be called with lock held.
+func (e *lossBasedBandwidthEstimatorV2) addObservation(o lossObservation) {
+	e.observations = append(e.observations, o)
+	if len(e.observations) > observationWindowSize {
+		e.observations = e.observations[1:]
+	}
+
+	lost, packets, weight := 0.0, 0.0, 1.0
+	for i := len(e.observations) - 1; i >= 0; i-- {
+		lost += weight * float64(e.observations[i].lost)
+		packets += weight * float64(e.observations[i].packets)
+		weight *= temporalWeightFactor
+	}
+	e.averageLoss = lost / packets
+
+	best := lossCandidate{}
+	bestObjective := math.Inf(-1)
+	for _, candidate := range e.candidates() {
+		for i := 0; i < newtonIterations; i++ {
+			candidate.inherentLoss = e.newtonStep(candidate)
+		}
+		if objective := e.objective(candidate); objective > bestObjective {
+			best, bestObjective = candidate, objective
+		}
+	}
+
+	bitrate := clampInt(int(best.bandwidth), e.minBitrate, e.maxBitrate)
+	// Loss above the inherent loss is not explained by the model, so the estimate is not
+	// increased until it is.
+	if best.inherentLoss < e.averageLoss {
+		bitrate = min(bitrate, e.bitrate)
+	}
+	if bitrate != e.bitrate {
+		e.log.Infof(
+			"loss controller v2 estimate: %v, inherentLoss: %v, averageLoss: %v", bitrate, best.inherentLoss, e.averageLoss,
+		)
+	}
+	e.bitrate = bitrate
+	e.inherentLoss = best.inherentLoss
+}
+
+// candidates returns the bandwidths to evaluate: factors of the current estimate, the
+// delay based estimate and the acknowledged rate of the latest observation. Candidates
+// above the delay based estimate are capped to it, since they would not be used.
+// This method should be called with lock held.
+func (e *lossBasedBandwidthEstimatorV2) candidates() []lossCandidate {
+	bandwidths := make([]float64, 0, len(candidateFactors)+2)
+	for _, factor := range candidateFactors {
+		bandwidths = append(bandwidths, factor*float64(e.bitrate))
+	}
+	if e.delayBitrate > 0 {
+		bandwidths = append(bandwidths, float64(e.delayBitrate))
+	}
+	latest := e.observations[len(e.observations)-1]
+	duration := latest.lastSend.Sub(latest.firstSend)
+	bandwidths = append(bandwidths, float64(8*(latest.size-latest.lostSize))/duration.Seconds())
+
+	candidates := make([]lossCandidate, 0, len(bandwidths))
+	for _, bandwidth := range bandwidths {
+		if e.delayBitrate > 0 {
+			bandwidth = min(bandwidth, float64(e.delayBitrate))
+		}
+		candidates = append(candidates, lossCandidate{bandwidth: bandwidth, inherentLoss: e.inherentLoss})
+	}
+
+	return candidates
+}
+
+// lossProbability returns the loss the model predicts for sending at sendingRate over
+// a link with the bandwidth and inherent loss of the candidate.
+func lossProbability(candidate lossCandidate, sendingRate float64) float64 {
+	probability := candidate.inherentLoss
+	if sendingRate > candidate.bandwidth {
+		probability += (1 - candidate.inherentLoss) * (sendingRate - candidate.bandwidth) / sendingRate
+	}
+
+	return min(max(probability, lossProbabilityBound), 1-lossProbabilityBound)
+}
+
+// objective returns the temporally weighted log likelihood of the observations under the
+// model of a candidate, biased towards higher bandwidths.
+// This method should be called with lock held.
+func (e *lossBasedBandwidthEstimatorV2) objective(candidate lossCandidate) float64 {
+	kbps := candidate.bandwidth / 1000
+	bias := higherBandwidthBiasFactor*kbps + higherLogBandwidthBiasFactor*math.Log(1+kbps)
+
+	objective, weight := 0.0, 1.0
+	for i := len(e.observations) - 1; i >= 0; i-- {
+		o := e.observations[i]
+		probability := lossProbability(candidate, o.sendingRate)
+		received := float64(o.packets - o.lost)
+		objective += weight * (float64(o.lost)*math.Log(probability) + received*math.Log(1-probability))
+		objective += weight * bias * float64(o.packets)
+		weight *= temporalWeightFactor
+	}
+
+	return objective
+}
+
+// newtonStep returns the inherent loss of a candidate after a Newton's method step
+// towards the maximum of the objective.
+// This method should be called with lock held.
+func (e *lossBasedBandwidthEstimatorV2) newtonStep(candidate lossCandidate) float64 {
+	first, second, weight := 0.0, 0.0, 1.0
+	for i := len(e.observations) - 1; i >= 0; i-- {
+		o := e.observations[i]
+		probability := lossProbability(candidate, o.sendingRate)
+		// derivative of the loss probability with respect to the inherent loss
+		derivative := 1.0
+		if o.sendingRate > candidate.bandwidth {
+			derivative = candidate.bandwidth / o.sendingRate
+		}
+		lost := float64(o.lost)
+		received := float64(o.packets - o.lost)
+		first += weight * derivative * (lost/probability - received/(1-probability))
+		second -= weight * derivative * derivative *
+			(lost/(probability*probability) + received/((1-probability)*(1-probability)))
+		weight *= temporalWeightFactor
+	}
+	if second >= 0 {
+		return candidate.inherentLoss
+	}
+
+	inherentLoss := candidate.inherentLoss - newtonStepSize*first/second
+
+	upperBound := 1.0
+	if candidate.bandwidth > 0 {
+		upperBound = min(inherentLossUpperBoundOffset+inherentLossUpperBoundBandwidthBalance/candidate.bandwidth, 1)
+	}
+
+	return min(max(inherentLoss, inherentLossLowerBound), upperBound)
+}
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package gcc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/interceptor/internal/cc"
+	"github.com/pion/logging"
+	"github.com/stretchr/testify/assert"
+)
+
+// lossAcks returns the acknowledgments of 1250 byte packets sent at bitrate for
+// 300ms from start, of which every lossEvery-th packet was lost, or none if lossEvery is 0.
+func lossAcks(start time.Time, bitrate, lossEvery int) []cc.Acknowledgment {
+	interval := time.Duration(float64(time.Second) * 1250 * 8 / float64(bitrate))
+	var acks []cc.Acknowledgment
+	for i := 0; time.Duration(i)*interval < 300*time.Millisecond; i++ {
+		ack := cc.Acknowledgment{
+			SequenceNumber: uint16(i), //nolint:gosec // G115
+			Size:           1250,
+			Departure:      start.Add(time.Duration(i) * interval),
+		}
+		if lossEvery == 0 || i%lossEvery != 0 {
+			ack.Arrival = ack.Departure.Add(50 * time.Millisecond)
+		}
+		acks = append(acks, ack)
+	}
+
+	return acks
+}
+
+func TestLossBasedBWEV2(t *testing.T) {
+	t.Run("random loss does not limit the estimate", func(t *testing.T) {
+		estimator := newLossBasedBWEV2(1_000_000, 10_000, 50_000_000, logging.NewDefaultLoggerFactory())
+		start := time.Time{}.Add(time.Hour)
+
+		for i := 0; i < 20; i++ {
+			estimator.getEstimate(2_000_000)
+			estimator.updateLossEstimate(lossAcks(start.Add(time.Duration(i)*300*time.Millisecond), 1_000_000, 20))
+		}
+
+		stats := estimator.getEstimate(2_000_000)
+		assert.Equal(t, 2_000_000, stats.TargetBitrate)
+		assert.InDelta(t, 0.065, stats.AverageLoss, 0.01)
+		assert.InDelta(t, 0.065, estimator.inherentLoss, 0.01)
+
+		// The threshold based estimator does not increase at the same loss
+		v1 := newLossBasedBWE(1_000_000, logging.NewDefaultLoggerFactory())
+		for i := 0; i < 20; i++ {
+			v1.updateLossEstimate(lossAcks(start.Add(time.Duration(i)*300*time.Millisecond), 1_000_000, 20))
+		}
+		assert.Equal(t, 1_000_000, v1.getEstimate(2_000_000).TargetBitrate)
+	})
+
+	t.Run("congestion loss decreases the estimate", func(t *testing.T) {
+		estimator := newLossBasedBWEV2(2_000_000, 10_000, 50_000_000, logging.NewDefaultLoggerFactory())
+		start := time.Time{}.Add(time.Hour)
+
+		for i := 0; i < 5; i++ {
+			estimator.getEstimate(2_000_000)
+			estimator.updateLossEstimate(lossAcks(start.Add(time.Duration(i)*300*time.Millisecond), 1_000_000, 0))
+		}
+		assert.Equal(t, 2_000_000, estimator.getEstimate(2_000_000).TargetBitrate)
+
+		// Sending at 2 Mbit/s over a 1 Mbit/s link loses half of the packets
+		for i := 5; i < 10; i++ {
+			estimator.getEstimate(2_000_000)
+			estimator.updateLossEstimate(lossAcks(start.Add(time.Duration(i)*300*time.Millisecond), 2_000_000, 2))
+		}
+		assert.InDelta(t, 1_000_000, estimator.getEstimate(2_000_000).TargetBitrate, 100_000)
+	})
+
+	t.Run("short feedback is aggregated", func(t *testing.T) {
+		estimator := newLossBasedBWEV2(1_000_000, 10_000, 50_000_000, logging.NewDefaultLoggerFactory())
+		acks := lossAcks(time.Time{}.Add(time.Hour), 1_000_000, 2)
+
+		estimator.updateLossEstimate(acks[:10])
+		assert.Empty(t, estimator.observations)
+		estimator.updateLossEstimate(acks[10:])
+		assert.Len(t, estimator.observations, 1)
+	})
+}
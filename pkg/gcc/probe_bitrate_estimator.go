@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package gcc
+
+import (
+	"time"
+
+	"github.com/pion/interceptor/internal/cc"
+)
+
+const (
+	// Reference: libwebrtc modules/congestion_controller/goog_cc/probe_bitrate_estimator.cc
+	// minReceivedProbesRatio is the fraction of the probe packets that has to be
+	// acknowledged to estimate the bitrate of a cluster.
+	minReceivedProbesRatio = 0.8
+	// maxProbeInterval bounds the send and receive intervals of a valid cluster.
+	maxProbeInterval = time.Second
+	// minRatioForUnsaturatedLink is the ratio of receive to send rate below which the
+	// link is considered saturated by the probe.
+	minRatioForUnsaturatedLink = 0.9
+	// targetUtilizationFraction is the fraction of the receive rate used as estimate
+	// when the link was saturated.
+	targetUtilizationFraction = 0.95
+	// maxTrackedProbeClusters bounds the number of clusters awaiting feedback.
+	maxTrackedProbeClusters = 5
+)
+
+// probeClusterFeedback aggregates the acknowledged packets of a probe cluster.
+type probeClusterFeedback struct {
+	minPackets int
+	received   int
+
+	firstSend     time.Time
+	lastSend      time.Time
+	lastSendSize  int
+	firstRecv     time.Time
+	lastRecv      time.Time
+	firstRecvSize int
+	size          int
+}
+
+// probeBitrateEstimator computes the bitrate of probe clusters from the transport wide
+// feedback of their packets.
+// Reference: libwebrtc modules/congestion_controller/goog_cc/probe_bitrate_estimator.cc
+type probeBitrateEstimator struct {
+	packets  map[uint16]int
+	clusters map[int]*probeClusterFeedback
+	order    []int
+}
+
+func newProbeBitrateEstimator() *probeBitrateEstimator {
+	return &probeBitrateEstimator{
+		packets:  map[uint16]int{},
+		clusters: map[int]*probeClusterFeedback{},
+	}
+}
+
+// onProbeSent records that the packet with the transport sequence number seq was sent
+// as part of the given cluster.
+func (e *probeBitrateEstimator) onProbeSent(seq uint16, cluster probeCluster) {
+	if _, ok := e.clusters[cluster.id]; !ok {
+		e.clusters[cluster.id] = &probeClusterFeedback{minPackets: cluster.minPackets}
+		e.order = append(e.order, cluster.id)
+		if len(e.order) > maxTrackedProbeClusters {
+			e.forget(e.order[0])
+			e.order = e.order[1:]
+		}
+	}
+	e.packets[seq] = cluster.id
+}
+
+// forget removes a cluster and its packets.
+func (e *probeBitrateEstimator) forget(id int) {
+	delete(e.clusters, id)
+	for seq, clusterID := range e.packets {
+		if clusterID == id {
+			delete(e.packets, seq)
+		}
+	}
+}
+
+// onAcknowledgments returns the estimated bitrate of each cluster with new feedback
+// that was received well enough to estimate it.
+func (e *probeBitrateEstimator) onAcknowledgments(acks []cc.Acknowledgment) map[int]int {
+	updated := map[int]bool{}
+	for _, ack := range acks {
+		id, ok := e.packets[ack.SequenceNumber]
+		if !ok || ack.SSRC != 0 {
+			continue
+		}
+		delete(e.packets, ack.SequenceNumber)
+		cluster, ok := e.clusters[id]
+		if !ok || ack.Arrival.IsZero() {
+			continue
+		}
+		cluster.add(ack)
+		updated[id] = true
+	}
+
+	results := map[int]int{}
+	for id := range updated {
+		if bitrate, ok := e.clusters[id].estimate(); ok {
+			results[id] = bitrate
+		}
+	}
+
+	return results
+}
+
+func (f *probeClusterFeedback) add(ack cc.Acknowledgment) {
+	if f.received == 0 || ack.Departure.Before(f.firstSend) {
+		f.firstSend = ack.Departure
+	}
+	if f.received == 0 || !ack.Departure.Before(f.lastSend) {
+		f.lastSend = ack.Departure
+		f.lastSendSize = ack.Size
+	}
+	if f.received == 0 || ack.Arrival.Before(f.firstRecv) {
+		f.firstRecv = ack.Arrival
+		f.firstRecvSize = ack.Size
+	}
+	if f.received == 0 || ack.Arrival.After(f.lastRecv) {
+		f.lastRecv = ack.Arrival
+	}
+	f.size += ack.Size
+	f.received++
+}
+
+// estimate returns the bitrate the link sustained during the cluster. The size of the
+// last sent packet does not count towards the send rate, and the size of the first
+// received packet does not count towards the receive rate.
+func (f *probeClusterFeedback) estimate() (int, bool) {
+	if float64(f.received) < minReceivedProbesRatio*float64(f.minPackets) {
+		return 0, false
+	}
+
+	sendInterval := f.lastSend.Sub(f.firstSend)
+	recvInterval := f.lastRecv.Sub(f.firstRecv)
+	if sendInterval <= 0 || sendInterval > maxProbeInterval || recvInterval <= 0 || recvInterval > maxProbeInterval {
+		return 0, false
+	}
+
+	sendRate := float64(8*(f.size-f.lastSendSize)) / sendInterval.Seconds()
+	recvRate := float64(8*(f.size-f.firstRecvSize)) / recvInterval.Seconds()
+	if recvRate < minRatioForUnsaturatedLink*sendRate {
+		return int(targetUtilizationFraction * recvRate), true
+	}
+
+	return int(min(sendRate, recvRate)), true
+}
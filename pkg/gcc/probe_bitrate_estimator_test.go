@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package gcc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/interceptor/internal/cc"
+	"github.com/stretchr/testify/assert"
+)
+
+func probeAcks(first uint16, count, size int, sendInterval, recvInterval time.Duration) []cc.Acknowledgment {
+	start := time.Time{}.Add(time.Hour)
+	acks := make([]cc.Acknowledgment, count)
+	for i := range acks {
+		acks[i] = cc.Acknowledgment{
+			SequenceNumber: first + uint16(i), //nolint:gosec // G115
+			Size:           size,
+			Departure:      start.Add(time.Duration(i) * sendInterval),
+			Arrival:        time.Time{}.Add(time.Duration(i) * recvInterval),
+		}
+	}
+
+	return acks
+}
+
+func TestProbeBitrateEstimator(t *testing.T) {
+	cluster := probeCluster{id: 1, bitrate: 1_000_000, minBytes: 1000, minPackets: 5}
+
+	t.Run("unsaturated link", func(t *testing.T) {
+		estimator := newProbeBitrateEstimator()
+		for i := uint16(10); i < 15; i++ {
+			estimator.onProbeSent(i, cluster)
+		}
+
+		// 4 intervals of 1000 bytes in 40ms
+		results := estimator.onAcknowledgments(probeAcks(10, 5, 1000, 10*time.Millisecond, 10*time.Millisecond))
+		assert.Equal(t, map[int]int{1: 800_000}, results)
+	})
+
+	t.Run("saturated link", func(t *testing.T) {
+		estimator := newProbeBitrateEstimator()
+		for i := uint16(10); i < 15; i++ {
+			estimator.onProbeSent(i, cluster)
+		}
+
+		results := estimator.onAcknowledgments(probeAcks(10, 5, 1000, 10*time.Millisecond, 20*time.Millisecond))
+		assert.Equal(t, map[int]int{1: 380_000}, results)
+	})
+
+	t.Run("too few packets received", func(t *testing.T) {
+		estimator := newProbeBitrateEstimator()
+		for i := uint16(10); i < 15; i++ {
+			estimator.onProbeSent(i, cluster)
+		}
+
+		acks := probeAcks(10, 5, 1000, 10*time.Millisecond, 10*time.Millisecond)
+		assert.Empty(t, estimator.onAcknowledgments(acks[:3]))
+		assert.Len(t, estimator.onAcknowledgments(acks[3:]), 1)
+	})
+
+	t.Run("ignores packets outside clusters", func(t *testing.T) {
+		estimator := newProbeBitrateEstimator()
+		estimator.onProbeSent(10, cluster)
+
+		assert.Empty(t, estimator.onAcknowledgments(probeAcks(20, 5, 1000, 10*time.Millisecond, 10*time.Millisecond)))
+	})
+}
@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package gcc
+
+import (
+	"time"
+)
+
+const (
+	// Reference: libwebrtc modules/congestion_controller/goog_cc/probe_controller.cc
+	firstExponentialProbeScale   = 3.0
+	secondExponentialProbeScale  = 6.0
+	furtherExponentialProbeScale = 2.0
+	// furtherProbeThreshold is the fraction of the probed bitrate a probe result has to
+	// reach to continue probing at a higher bitrate.
+	furtherProbeThreshold = 0.7
+
+	// bitrateDropThreshold is the fraction of the previous estimate below which a
+	// decrease counts as a large drop.
+	bitrateDropThreshold = 0.66
+	// probeFractionAfterDrop is the fraction of the estimate before a large drop that
+	// is probed after the drop.
+	probeFractionAfterDrop = 0.85
+	// bitrateDropTimeout is how long after a large drop a probe may be sent.
+	bitrateDropTimeout = 5 * time.Second
+	// probeAfterDropDelay is how long the estimate has to stop decreasing after a large
+	// drop before probing, so that probes are not sent into a congested link.
+	probeAfterDropDelay = time.Second
+	// minTimeBetweenDropProbes limits the probes after large drops.
+	minTimeBetweenDropProbes = 5 * time.Second
+
+	// Reference: libwebrtc modules/pacing/bitrate_prober.cc
+	minProbePackets  = 5
+	minProbeDuration = 15 * time.Millisecond
+)
+
+// probeCluster is a burst of packets sent at a bitrate above the estimate, to measure
+// if the link can sustain the higher bitrate.
+type probeCluster struct {
+	id         int
+	bitrate    int
+	minBytes   int
+	minPackets int
+}
+
+// probeController decides when to probe and at which bitrates. It probes at multiples
+// of the estimate at startup, continues probing while the probe results reach the probed
+// bitrate, and probes again after large estimate drops.
+// Reference: libwebrtc modules/congestion_controller/goog_cc/probe_controller.cc
+type probeController struct {
+	minBitrate int
+	maxBitrate int
+
+	started  bool
+	nextID   int
+	estimate int
+
+	// waitingID is the cluster whose result decides about further probing.
+	waiting        bool
+	waitingID      int
+	waitingBitrate int
+
+	dropTime          time.Time
+	bitrateBeforeDrop int
+	lastDecrease      time.Time
+	lastDropProbe     time.Time
+}
+
+func newProbeController(minBitrate, maxBitrate int) *probeController {
+	return &probeController{
+		minBitrate: minBitrate,
+		maxBitrate: maxBitrate,
+	}
+}
+
+// onStart returns the initial probe clusters at multiples of the estimate.
+func (c *probeController) onStart(estimate int) []probeCluster {
+	if c.started {
+		return nil
+	}
+	c.started = true
+	c.estimate = estimate
+
+	return c.initiateProbing(
+		int(firstExponentialProbeScale*float64(estimate)),
+		int(secondExponentialProbeScale*float64(estimate)),
+	)
+}
+
+// onEstimate tracks the estimate to detect large drops.
+func (c *probeController) onEstimate(estimate int, now time.Time) {
+	if estimate < c.estimate {
+		c.lastDecrease = now
+		if float64(estimate) < bitrateDropThreshold*float64(c.estimate) {
+			c.dropTime = now
+			c.bitrateBeforeDrop = max(c.bitrateBeforeDrop, c.estimate)
+			c.waiting = false
+		}
+	}
+	c.estimate = estimate
+}
+
+// onProbeResult returns a probe cluster at a higher bitrate if the result of the
+// awaited cluster shows that the link sustained most of the probed bitrate.
+func (c *probeController) onProbeResult(id, bitrate int) []probeCluster {
+	if !c.waiting || id != c.waitingID {
+		return nil
+	}
+	c.waiting = false
+	if float64(bitrate) < furtherProbeThreshold*float64(c.waitingBitrate) {
+		return nil
+	}
+
+	return c.initiateProbing(int(furtherExponentialProbeScale * float64(bitrate)))
+}
+
+// process returns a probe cluster if the estimate recovered too slowly after a large drop.
+func (c *probeController) process(now time.Time) []probeCluster {
+	if c.dropTime.IsZero() {
+		return nil
+	}
+	if now.Sub(c.dropTime) > bitrateDropTimeout {
+		c.dropTime = time.Time{}
+		c.bitrateBeforeDrop = 0
+
+		return nil
+	}
+	if now.Sub(c.lastDecrease) < probeAfterDropDelay ||
+		(!c.lastDropProbe.IsZero() && now.Sub(c.lastDropProbe) < minTimeBetweenDropProbes) {
+		return nil
+	}
+
+	bitrate := int(probeFractionAfterDrop * float64(c.bitrateBeforeDrop))
+	c.dropTime = time.Time{}
+	c.bitrateBeforeDrop = 0
+	if bitrate <= c.estimate {
+		return nil
+	}
+	c.lastDropProbe = now
+	clusters := c.initiateProbing(bitrate)
+	// Probes after drops only recover the previous estimate
+	c.waiting = false
+
+	return clusters
+}
+
+// initiateProbing returns clusters for the given bitrates up to the maximum bitrate,
+// and waits for the result of the last one to probe further.
+func (c *probeController) initiateProbing(bitrates ...int) []probeCluster {
+	var clusters []probeCluster
+	for _, bitrate := range bitrates {
+		bitrate = min(bitrate, c.maxBitrate)
+		if bitrate <= c.estimate || bitrate < c.minBitrate {
+			continue
+		}
+		clusters = append(clusters, probeCluster{
+			id:         c.nextID,
+			bitrate:    bitrate,
+			minBytes:   int(float64(bitrate) * minProbeDuration.Seconds() / 8),
+			minPackets: minProbePackets,
+		})
+		c.nextID++
+	}
+
+	c.waiting = false
+	if len(clusters) > 0 && clusters[len(clusters)-1].bitrate < c.maxBitrate {
+		last := clusters[len(clusters)-1]
+		c.waiting = true
+		c.waitingID = last.id
+		c.waitingBitrate = last.bitrate
+	}
+
+	return clusters
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package gcc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProbeController(t *testing.T) {
+	t.Run("exponential probing at start", func(t *testing.T) {
+		controller := newProbeController(10_000, 5_000_000)
+
+		clusters := controller.onStart(300_000)
+		assert.Len(t, clusters, 2)
+		assert.Equal(t, 900_000, clusters[0].bitrate)
+		assert.Equal(t, 1_800_000, clusters[1].bitrate)
+		assert.Equal(t, minProbePackets, clusters[0].minPackets)
+		assert.Equal(t, 1_800_000*15/1000/8, clusters[1].minBytes)
+		assert.Nil(t, controller.onStart(300_000))
+
+		// Only the result of the last cluster continues probing
+		assert.Nil(t, controller.onProbeResult(clusters[0].id, 900_000))
+		further := controller.onProbeResult(clusters[1].id, 1_600_000)
+		assert.Len(t, further, 1)
+		assert.Equal(t, 3_200_000, further[0].bitrate)
+
+		// A result far below the probed bitrate stops probing
+		assert.Nil(t, controller.onProbeResult(further[0].id, 1_000_000))
+	})
+
+	t.Run("probes are capped to max bitrate", func(t *testing.T) {
+		controller := newProbeController(10_000, 1_000_000)
+
+		clusters := controller.onStart(300_000)
+		assert.Len(t, clusters, 2)
+		assert.Equal(t, 900_000, clusters[0].bitrate)
+		assert.Equal(t, 1_000_000, clusters[1].bitrate)
+		assert.Nil(t, controller.onProbeResult(clusters[1].id, 1_000_000))
+	})
+
+	t.Run("probe after large drop", func(t *testing.T) {
+		controller := newProbeController(10_000, 5_000_000)
+		now := time.Time{}.Add(time.Hour)
+
+		controller.onStart(5_000_000)
+		controller.onEstimate(2_000_000, now)
+		assert.Nil(t, controller.process(now.Add(500*time.Millisecond)))
+
+		clusters := controller.process(now.Add(time.Second))
+		assert.Len(t, clusters, 1)
+		assert.Equal(t, 4_250_000, clusters[0].bitrate)
+		assert.Nil(t, controller.onProbeResult(clusters[0].id, 4_250_000))
+		assert.Nil(t, controller.process(now.Add(2*time.Second)))
+	})
+
+	t.Run("no probe after drop timeout", func(t *testing.T) {
+		controller := newProbeController(10_000, 5_000_000)
+		now := time.Time{}.Add(time.Hour)
+
+		controller.onStart(5_000_000)
+		controller.onEstimate(2_000_000, now)
+		for i := 1; i < 10; i++ {
+			controller.onEstimate(2_000_000-i*1000, now.Add(time.Duration(i)*600*time.Millisecond))
+		}
+		assert.Nil(t, controller.process(now.Add(6*time.Second)))
+		assert.Nil(t, controller.process(now.Add(7*time.Second)))
+	})
+}
@@ -0,0 +1,230 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package gcc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/interceptor/internal/cc"
+	"github.com/pion/interceptor/internal/padding"
+	"github.com/pion/logging"
+	"github.com/pion/rtp"
+)
+
+const (
+	// probePaddingSize is the padding of a probe packet, the padding length is a single byte.
+	probePaddingSize = 255
+	// probeClusterTimeout ends a cluster that could not send enough packets.
+	probeClusterTimeout = time.Second
+)
+
+// probeStream is a local stream whose RTX SSRC can carry probe padding.
+type probeStream struct {
+	rtxSSRC        uint32
+	rtxPayloadType uint8
+	timestamp      uint32
+}
+
+// prober sends the probe clusters of the probeController through the pacer and
+// estimates their bitrate from transport wide feedback.
+// Probe clusters raise the pacing rate to the probed bitrate, and are filled with
+// padding-only packets on the RTX SSRC of a stream. The transport wide sequence numbers
+// are assigned in send order, so that the padding is covered by the feedback.
+// The RTX sequence numbers are assigned when the packets leave the pacer as well, so that
+// the probe padding, the padding of the pacer and the retransmissions share one sequence
+// number space.
+type prober struct {
+	lock sync.Mutex
+
+	pacer      Pacer
+	controller *probeController
+	estimator  *probeBitrateEstimator
+	log        logging.LeveledLogger
+	rtx        *padding.Generator
+
+	transportSequence uint16
+	streams           map[uint32]*probeStream
+	paddingStream     *probeStream
+
+	estimate    int
+	queue       []probeCluster
+	active      *probeCluster
+	activeSince time.Time
+	sentBytes   int
+	sentPackets int
+	lastResult  int
+}
+
+func newProber(pacer Pacer, initialBitrate, minBitrate, maxBitrate int, log logging.LeveledLogger) *prober {
+	return &prober{
+		pacer:      pacer,
+		controller: newProbeController(minBitrate, maxBitrate),
+		estimator:  newProbeBitrateEstimator(),
+		log:        log,
+		rtx:        padding.NewGenerator(),
+		streams:    map[uint32]*probeStream{},
+		estimate:   initialBitrate,
+	}
+}
+
+// addStream registers a local stream. The first stream with an RTX SSRC and payload type
+// carries the padding.
+func (p *prober) addStream(info *interceptor.StreamInfo) {
+	if info.SSRCRetransmission == 0 || info.PayloadTypeRetransmission == 0 {
+		return
+	}
+	p.rtx.AddStream(info)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	stream := &probeStream{rtxSSRC: info.SSRCRetransmission, rtxPayloadType: info.PayloadTypeRetransmission}
+	p.streams[info.SSRC] = stream
+	if p.paddingStream == nil {
+		p.paddingStream = stream
+	}
+}
+
+// onPacketSent returns the transport sequence number of a packet leaving the pacer,
+// and accounts it to the active probe cluster. Packets on an RTX SSRC are renumbered.
+func (p *prober) onPacketSent(header *rtp.Header, payload []byte, size int, now time.Time) uint16 {
+	p.rtx.OnSent(header, payload, now)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	seq := p.transportSequence
+	p.transportSequence++
+
+	if stream, ok := p.streams[header.SSRC]; ok {
+		stream.timestamp = header.Timestamp
+	}
+
+	if p.active != nil {
+		p.estimator.onProbeSent(seq, *p.active)
+		p.sentBytes += size
+		p.sentPackets++
+		if p.sentBytes >= p.active.minBytes && p.sentPackets >= p.active.minPackets {
+			p.finishCluster()
+		}
+	}
+
+	return seq
+}
+
+// onEstimate updates the pacing rate to a new estimate.
+func (p *prober) onEstimate(bitrate int, now time.Time) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.controller.onEstimate(bitrate, now)
+	p.estimate = bitrate
+	p.pacer.SetTargetBitrate(p.pacingRate())
+}
+
+// onAcknowledgments returns the highest bitrate of the probe clusters estimated from acks.
+func (p *prober) onAcknowledgments(acks []cc.Acknowledgment) (int, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	best, ok := 0, false
+	for id, bitrate := range p.estimator.onAcknowledgments(acks) {
+		p.log.Infof("probe cluster %v result: %v", id, bitrate)
+		p.queue = append(p.queue, p.controller.onProbeResult(id, bitrate)...)
+		best, ok = max(best, bitrate), true
+	}
+	if ok {
+		p.lastResult = best
+	}
+
+	return best, ok
+}
+
+// process starts probing at startup and after large drops, and sends the padding
+// of the next probe cluster.
+func (p *prober) process(now time.Time) {
+	p.lock.Lock()
+	p.queue = append(p.queue, p.controller.onStart(p.estimate)...)
+	p.queue = append(p.queue, p.controller.process(now)...)
+
+	if p.active != nil && now.Sub(p.activeSince) > probeClusterTimeout {
+		p.log.Infof("probe cluster %v timed out", p.active.id)
+		p.finishCluster()
+	}
+	if p.active != nil || len(p.queue) == 0 {
+		p.lock.Unlock()
+
+		return
+	}
+
+	cluster := p.queue[0]
+	p.queue = p.queue[1:]
+	p.active = &cluster
+	p.activeSince = now
+	p.sentBytes = 0
+	p.sentPackets = 0
+	p.pacer.SetTargetBitrate(p.pacingRate())
+	padding := p.padding(cluster)
+	p.lock.Unlock()
+
+	for _, header := range padding {
+		if _, err := p.pacer.Write(header, nil, nil); err != nil {
+			p.log.Warnf("failed to send probe padding: %v", err)
+
+			return
+		}
+	}
+}
+
+// lastProbeResult returns the bitrate of the last estimated probe cluster.
+func (p *prober) lastProbeResult() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.lastResult
+}
+
+// finishCluster ends the active cluster and restores the pacing rate.
+// This method should be called with lock held.
+func (p *prober) finishCluster() {
+	p.active = nil
+	p.pacer.SetTargetBitrate(p.pacingRate())
+}
+
+// pacingRate returns the estimate, or the probed bitrate while probing.
+// This method should be called with lock held.
+func (p *prober) pacingRate() int {
+	if p.active != nil {
+		return max(p.estimate, p.active.bitrate)
+	}
+
+	return p.estimate
+}
+
+// padding returns the headers of the padding-only packets that fill a cluster, or none if
+// no stream has an RTX SSRC. Their sequence numbers are assigned by onPacketSent.
+// This method should be called with lock held.
+func (p *prober) padding(cluster probeCluster) []*rtp.Header {
+	stream := p.paddingStream
+	if stream == nil {
+		return nil
+	}
+
+	count := max(cluster.minPackets, (cluster.minBytes+probePaddingSize-1)/probePaddingSize)
+	headers := make([]*rtp.Header, count)
+	for i := range headers {
+		headers[i] = &rtp.Header{
+			Version:     2,
+			Padding:     true,
+			PaddingSize: probePaddingSize,
+			PayloadType: stream.rtxPayloadType,
+			Timestamp:   stream.timestamp,
+			SSRC:        stream.rtxSSRC,
+		}
+	}
+
+	return headers
+}
@@ -21,6 +21,8 @@ type rateController struct {
 	maxBitrate           int
 
 	dsWriter func(DelayStats)
+	// applicationLimited reports whether the sender uses less than the target, if set.
+	applicationLimited func() bool
 
 	lock               sync.Mutex
 	init               bool
@@ -85,10 +87,13 @@ func (c *rateController) updateRTT(rtt time.Duration) {
 func (c *rateController) onDelayStats(ds DelayStats) {
 	now := time.Now()
 
+	c.lock.Lock()
+
 	if !c.init {
 		c.delayStats = ds
 		c.delayStats.State = stateIncrease
 		c.init = true
+		c.lock.Unlock()
 
 		return
 	}
@@ -96,18 +101,25 @@ func (c *rateController) onDelayStats(ds DelayStats) {
 	c.delayStats.State = c.delayStats.State.transition(ds.Usage)
 
 	if c.delayStats.State == stateHold {
+		c.lock.Unlock()
+
 		return
 	}
 
 	var next DelayStats
 
-	c.lock.Lock()
-
 	switch c.delayStats.State {
 	case stateHold:
 		// should never occur due to check above, but makes the linter happy
 	case stateIncrease:
-		c.target = clampInt(c.increase(now), c.minBitrate, c.maxBitrate)
+		// While the sender is application limited, the link did not prove that it can carry
+		// the current target, so the target is not increased further.
+		// Reference: libwebrtc modules/remote_bitrate_estimator/aimd_rate_control.cc
+		if c.applicationLimited != nil && c.applicationLimited() {
+			c.lastUpdate = now
+		} else {
+			c.target = clampInt(c.increase(now), c.minBitrate, c.maxBitrate)
+		}
 		next = DelayStats{
 			Measurement:      c.delayStats.Measurement,
 			Estimate:         c.delayStats.Estimate,
@@ -136,6 +148,19 @@ func (c *rateController) onDelayStats(ds DelayStats) {
 	c.dsWriter(next)
 }
 
+// onProbeResult adopts the bitrate measured by a probe cluster as the new target.
+// Reference: libwebrtc modules/congestion_controller/goog_cc/delay_based_bwe.cc MaybeUpdateEstimate
+func (c *rateController) onProbeResult(bitrate int) {
+	c.lock.Lock()
+	c.target = clampInt(bitrate, c.minBitrate, c.maxBitrate)
+	c.lastUpdate = c.now()
+	next := c.delayStats
+	next.TargetBitrate = c.target
+	c.lock.Unlock()
+
+	c.dsWriter(next)
+}
+
 func (c *rateController) increase(now time.Time) int {
 	if c.latestDecreaseRate.average > 0 &&
 		float64(c.latestReceivedRate) > c.latestDecreaseRate.average-3*c.latestDecreaseRate.stdDeviation &&
@@ -77,3 +77,26 @@ func TestRateControllerRun(t *testing.T) {
 		})
 	}
 }
+
+func TestRateControllerApplicationLimited(t *testing.T) {
+	limited := true
+	var targets []int
+	rc := newRateController(time.Now, 100_000, 1_000, 50_000_000, func(ds DelayStats) {
+		targets = append(targets, ds.TargetBitrate)
+	})
+	rc.applicationLimited = func() bool {
+		return limited
+	}
+	rc.onReceivedRate(100_000)
+
+	for i := 0; i < 3; i++ {
+		rc.onDelayStats(DelayStats{Usage: usageNormal})
+	}
+	limited = false
+	rc.lastUpdate = time.Now().Add(-time.Second)
+	rc.onDelayStats(DelayStats{Usage: usageNormal})
+
+	// The first delay stats only initialize the controller
+	assert.Equal(t, []int{100_000, 100_000}, targets[:2])
+	assert.InDelta(t, 108_000, targets[2], 100)
+}
@@ -0,0 +1,214 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package gcc
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/pion/interceptor/internal/cc"
+	"github.com/pion/logging"
+)
+
+// ErrReceiveSideBWEClosed is raised when ReceiveSideBWE.OnPacketsReceived is called after
+// ReceiveSideBWE.Close.
+var ErrReceiveSideBWEClosed = errors.New("ReceiveSideBWE closed")
+
+// ReceivedPacket is a packet received from the sender whose bandwidth is estimated.
+type ReceivedPacket struct {
+	// Departure is the send time of the packet, for example from the abs-send-time header
+	// extension. Only the differences of send times are used, so the clock of the sender
+	// does not need to be synchronized.
+	Departure time.Time
+	// Arrival is the local time the packet was received at.
+	Arrival time.Time
+	// Size is the size of the packet in bytes.
+	Size int
+}
+
+// ReceiveSideBWE implements the delay based part of GCC at the receiver of a stream. It
+// estimates the bandwidth from the send and arrival times of received packets, so that
+// the receiver can report it to senders that do not estimate the bandwidth themselves,
+// for example with REMB.
+type ReceiveSideBWE struct {
+	delayController *delayController
+
+	onTargetBitrateChange func(bitrate int)
+
+	lock          sync.Mutex
+	latestStats   DelayStats
+	latestBitrate int
+	minBitrate    int
+	maxBitrate    int
+
+	close     chan struct{}
+	closeLock sync.RWMutex
+
+	loggerFactory logging.LoggerFactory
+}
+
+// ReceiveSideBWEOption configures a receiver side bandwidth estimator.
+type ReceiveSideBWEOption func(*ReceiveSideBWE) error
+
+// ReceiveSideBWEInitialBitrate sets the initial bitrate of the estimate.
+func ReceiveSideBWEInitialBitrate(rate int) ReceiveSideBWEOption {
+	return func(e *ReceiveSideBWE) error {
+		e.latestBitrate = rate
+
+		return nil
+	}
+}
+
+// ReceiveSideBWEMaxBitrate sets the maximum bitrate of the estimate.
+func ReceiveSideBWEMaxBitrate(rate int) ReceiveSideBWEOption {
+	return func(e *ReceiveSideBWE) error {
+		e.maxBitrate = rate
+
+		return nil
+	}
+}
+
+// ReceiveSideBWEMinBitrate sets the minimum bitrate of the estimate.
+func ReceiveSideBWEMinBitrate(rate int) ReceiveSideBWEOption {
+	return func(e *ReceiveSideBWE) error {
+		e.minBitrate = rate
+
+		return nil
+	}
+}
+
+// ReceiveSideBWELoggerFactory sets the logger factory of the estimator.
+func ReceiveSideBWELoggerFactory(factory logging.LoggerFactory) ReceiveSideBWEOption {
+	return func(e *ReceiveSideBWE) error {
+		e.loggerFactory = factory
+
+		return nil
+	}
+}
+
+// NewReceiveSideBWE creates a new receiver side bandwidth estimator.
+func NewReceiveSideBWE(opts ...ReceiveSideBWEOption) (*ReceiveSideBWE, error) {
+	receive := &ReceiveSideBWE{
+		latestBitrate: latestBitrate,
+		minBitrate:    minBitrate,
+		maxBitrate:    maxBitrate,
+		close:         make(chan struct{}),
+	}
+	for _, opt := range opts {
+		if err := opt(receive); err != nil {
+			return nil, err
+		}
+	}
+	if receive.loggerFactory == nil {
+		receive.loggerFactory = logging.NewDefaultLoggerFactory()
+	}
+	receive.delayController = newDelayController(delayControllerConfig{
+		nowFn:          time.Now,
+		initialBitrate: receive.latestBitrate,
+		minBitrate:     receive.minBitrate,
+		maxBitrate:     receive.maxBitrate,
+	}, receive.loggerFactory)
+
+	receive.delayController.onUpdate(receive.onDelayUpdate)
+
+	return receive, nil
+}
+
+// OnPacketsReceived adds received packets to the estimate, in the order of their arrival.
+func (e *ReceiveSideBWE) OnPacketsReceived(packets []ReceivedPacket) error {
+	e.closeLock.RLock()
+	defer e.closeLock.RUnlock()
+
+	if e.isClosed() {
+		return ErrReceiveSideBWEClosed
+	}
+
+	acks := make([]cc.Acknowledgment, 0, len(packets))
+	for _, p := range packets {
+		acks = append(acks, cc.Acknowledgment{
+			Size:      p.Size,
+			Departure: p.Departure,
+			Arrival:   p.Arrival,
+		})
+	}
+	e.delayController.updateDelayEstimate(acks)
+
+	return nil
+}
+
+// UpdateRTT sets the RTT to the sender, if it is known, which the estimate uses to time
+// its increases and decreases.
+func (e *ReceiveSideBWE) UpdateRTT(rtt time.Duration) {
+	e.delayController.updateRTT(rtt)
+}
+
+// GetTargetBitrate returns the current estimate in bits per second.
+func (e *ReceiveSideBWE) GetTargetBitrate() int {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	return e.latestBitrate
+}
+
+// GetStats returns some internal statistics of the bandwidth estimator.
+func (e *ReceiveSideBWE) GetStats() map[string]any {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	return map[string]any{
+		"delayTargetBitrate": e.latestStats.TargetBitrate,
+		"delayMeasurement":   float64(e.latestStats.Measurement.Microseconds()) / 1000.0,
+		"delayEstimate":      float64(e.latestStats.Estimate.Microseconds()) / 1000.0,
+		"delayThreshold":     float64(e.latestStats.Threshold.Microseconds()) / 1000.0,
+		"usage":              e.latestStats.Usage.String(),
+		"state":              e.latestStats.State.String(),
+	}
+}
+
+// OnTargetBitrateChange sets the callback that is called when the estimate in bits per
+// second changes.
+func (e *ReceiveSideBWE) OnTargetBitrateChange(f func(bitrate int)) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.onTargetBitrateChange = f
+}
+
+// isClosed returns true if ReceiveSideBWE is closed.
+func (e *ReceiveSideBWE) isClosed() bool {
+	select {
+	case <-e.close:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close stops and closes the bandwidth estimator.
+func (e *ReceiveSideBWE) Close() error {
+	e.closeLock.Lock()
+	defer e.closeLock.Unlock()
+
+	if e.isClosed() {
+		return nil
+	}
+	close(e.close)
+
+	return e.delayController.Close()
+}
+
+func (e *ReceiveSideBWE) onDelayUpdate(delayStats DelayStats) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.latestStats = delayStats
+	if delayStats.TargetBitrate == e.latestBitrate {
+		return
+	}
+	e.latestBitrate = delayStats.TargetBitrate
+	if e.onTargetBitrateChange != nil {
+		go e.onTargetBitrateChange(e.latestBitrate)
+	}
+}
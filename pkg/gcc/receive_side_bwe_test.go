@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package gcc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceiveSideBWE(t *testing.T) {
+	// receive adds 1200 byte packets sent every 10ms, whose one way delay grows by
+	// delayGrowth per packet, in batches of ten, since the overuse detector measures the
+	// duration of overuse in real time.
+	receive := func(t *testing.T, bwe *ReceiveSideBWE, count int, delayGrowth time.Duration) {
+		t.Helper()
+		start := time.Now()
+		for i := 0; i < count; i += 10 {
+			packets := make([]ReceivedPacket, 0, 10)
+			for j := i; j < i+10; j++ {
+				departure := start.Add(time.Duration(j) * 10 * time.Millisecond)
+				packets = append(packets, ReceivedPacket{
+					Departure: departure,
+					Arrival:   departure.Add(20*time.Millisecond + time.Duration(j)*delayGrowth),
+					Size:      1200,
+				})
+			}
+			require.NoError(t, bwe.OnPacketsReceived(packets))
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+
+	t.Run("increases on stable delay", func(t *testing.T) {
+		bwe, err := NewReceiveSideBWE(ReceiveSideBWEInitialBitrate(500_000))
+		require.NoError(t, err)
+		defer func() {
+			assert.NoError(t, bwe.Close())
+		}()
+
+		receive(t, bwe, 100, 0)
+		require.Eventually(t, func() bool {
+			return bwe.GetTargetBitrate() > 500_000
+		}, time.Second, time.Millisecond)
+		assert.Equal(t, "normal", bwe.GetStats()["usage"])
+	})
+
+	t.Run("decreases on growing delay", func(t *testing.T) {
+		bwe, err := NewReceiveSideBWE(ReceiveSideBWEInitialBitrate(5_000_000))
+		require.NoError(t, err)
+		defer func() {
+			assert.NoError(t, bwe.Close())
+		}()
+
+		changes := make(chan int, 100)
+		bwe.OnTargetBitrateChange(func(bitrate int) {
+			changes <- bitrate
+		})
+
+		receive(t, bwe, 100, 2*time.Millisecond)
+		require.Eventually(t, func() bool {
+			return bwe.GetTargetBitrate() < 5_000_000
+		}, time.Second, time.Millisecond)
+		// The decrease is reported, after increases before the overuse was detected
+		require.Eventually(t, func() bool {
+			return <-changes < 5_000_000
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("error after close", func(t *testing.T) {
+		bwe, err := NewReceiveSideBWE()
+		require.NoError(t, err)
+		require.NoError(t, bwe.Close())
+		assert.ErrorIs(t, bwe.OnPacketsReceived(nil), ErrReceiveSideBWEClosed)
+		assert.NoError(t, bwe.Close())
+	})
+}
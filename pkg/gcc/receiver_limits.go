@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package gcc
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/pion/interceptor/internal/tmmbr"
+)
+
+// Constraints that can limit the target bitrate, as reported in the stats.
+const (
+	limitedByDelay = "delay"
+	limitedByLoss  = "loss"
+	limitedByREMB  = "remb"
+	limitedByTMMBR = "tmmbr"
+)
+
+// receiverLimitTimeout is how long a REMB or TMMBR applies after it was received.
+// Receivers repeat their limits while they apply, so a limit that is not repeated
+// expires, for example when the receiver left.
+const receiverLimitTimeout = 10 * time.Second
+
+// tmmbrKey identifies the TMMBR entry of a receiver for a media stream.
+type tmmbrKey struct {
+	receiverSSRC uint32
+	mediaSSRC    uint32
+}
+
+// tmmbrOwner is the latest TMMBR entry of a receiver for a media stream.
+type tmmbrOwner struct {
+	entry    tmmbr.Entry
+	received time.Time
+}
+
+// receiverLimits tracks the maximum bitrates requested by receivers with REMB and TMMBR.
+// The latest REMB applies, and the lowest of the latest TMMBR entries of each receiver
+// for each local media stream. TMMBR limits a single media stream, but is applied to the
+// total target bitrate, since the estimate is not split between streams. Limits expire
+// after receiverLimitTimeout.
+type receiverLimits struct {
+	remb         int
+	rembReceived time.Time
+	owners       map[tmmbrKey]tmmbrOwner
+	// ssrcs are the local media streams, TMMBR entries for other streams are ignored.
+	ssrcs map[uint32]struct{}
+}
+
+func newReceiverLimits() *receiverLimits {
+	return &receiverLimits{
+		remb:   0,
+		owners: map[tmmbrKey]tmmbrOwner{},
+		ssrcs:  map[uint32]struct{}{},
+	}
+}
+
+// addStream adds a local media stream that receivers may limit with TMMBR.
+func (l *receiverLimits) addStream(ssrc uint32) {
+	l.ssrcs[ssrc] = struct{}{}
+}
+
+// onREMB sets the bitrate of the latest REMB.
+func (l *receiverLimits) onREMB(bitrate int, now time.Time) {
+	l.remb = bitrate
+	l.rembReceived = now
+}
+
+// rembBitrate returns the bitrate of the latest REMB, or 0 if it expired.
+func (l *receiverLimits) rembBitrate(now time.Time) int {
+	if now.Sub(l.rembReceived) >= receiverLimitTimeout {
+		return 0
+	}
+
+	return l.remb
+}
+
+// onTMMBR adds the entries of a TMMBR for local media streams, and returns a TMMBN for
+// each of those streams that notifies the receivers of the new bounding set.
+// See https://datatracker.ietf.org/doc/html/rfc5104#section-4.2.2
+func (l *receiverLimits) onTMMBR(request *tmmbr.Request, now time.Time) []*tmmbr.Notification {
+	l.expire(now)
+
+	var mediaSSRCs []uint32
+	for _, entry := range request.Entries {
+		if _, ok := l.ssrcs[entry.SSRC]; !ok {
+			continue
+		}
+		l.owners[tmmbrKey{receiverSSRC: request.SenderSSRC, mediaSSRC: entry.SSRC}] = tmmbrOwner{
+			entry:    tmmbr.Entry{SSRC: request.SenderSSRC, Bitrate: entry.Bitrate, Overhead: entry.Overhead},
+			received: now,
+		}
+		if !slices.Contains(mediaSSRCs, entry.SSRC) {
+			mediaSSRCs = append(mediaSSRCs, entry.SSRC)
+		}
+	}
+
+	notifications := make([]*tmmbr.Notification, 0, len(mediaSSRCs))
+	for _, ssrc := range mediaSSRCs {
+		notifications = append(notifications, &tmmbr.Notification{
+			SenderSSRC: ssrc,
+			Entries:    l.boundingSet(ssrc),
+		})
+	}
+
+	return notifications
+}
+
+// expire removes the TMMBR entries that timed out.
+func (l *receiverLimits) expire(now time.Time) {
+	for key, owner := range l.owners {
+		if now.Sub(owner.received) >= receiverLimitTimeout {
+			delete(l.owners, key)
+		}
+	}
+}
+
+// boundingSet returns the TMMBR entries for a media stream with the lowest bitrate. The
+// overhead of the entries is not taken into account.
+func (l *receiverLimits) boundingSet(mediaSSRC uint32) []tmmbr.Entry {
+	var lowest uint64
+	entries := []tmmbr.Entry{}
+	for key, owner := range l.owners {
+		if key.mediaSSRC != mediaSSRC {
+			continue
+		}
+		switch {
+		case len(entries) == 0 || owner.entry.Bitrate < lowest:
+			lowest = owner.entry.Bitrate
+			entries = append(entries[:0], owner.entry)
+		case owner.entry.Bitrate == lowest:
+			entries = append(entries, owner.entry)
+		}
+	}
+	slices.SortFunc(entries, func(a, b tmmbr.Entry) int {
+		return cmp.Compare(a.SSRC, b.SSRC)
+	})
+
+	return entries
+}
+
+// tmmbr returns the lowest bitrate requested by TMMBR, or false if no TMMBR applies.
+func (l *receiverLimits) tmmbr(now time.Time) (int, bool) {
+	l.expire(now)
+
+	lowest, ok := 0, false
+	for _, owner := range l.owners {
+		bitrate := int(min(owner.entry.Bitrate, math.MaxInt)) //nolint:gosec // G115
+		if !ok || bitrate < lowest {
+			lowest, ok = bitrate, true
+		}
+	}
+
+	return lowest, ok
+}
+
+// limit returns the maximum bitrate requested by receivers and the constraint that
+// requested it, or false if no receiver requested a maximum.
+func (l *receiverLimits) limit(now time.Time) (int, string, bool) {
+	remb := l.rembBitrate(now)
+	tmmbrBitrate, ok := l.tmmbr(now)
+	switch {
+	case ok && (remb == 0 || tmmbrBitrate < remb):
+		return tmmbrBitrate, limitedByTMMBR, true
+	case remb > 0:
+		return remb, limitedByREMB, true
+	default:
+		return 0, "", false
+	}
+}
@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package gcc
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/interceptor/internal/tmmbr"
+	"github.com/pion/rtcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceiverLimits(t *testing.T) {
+	now := time.Now()
+	limits := newReceiverLimits()
+	limits.addStream(1)
+	limits.addStream(2)
+	_, _, ok := limits.limit(now)
+	assert.False(t, ok)
+
+	limits.onREMB(2_000_000, now)
+	limit, limitedBy, ok := limits.limit(now)
+	assert.True(t, ok)
+	assert.Equal(t, 2_000_000, limit)
+	assert.Equal(t, limitedByREMB, limitedBy)
+
+	notifications := limits.onTMMBR(&tmmbr.Request{
+		SenderSSRC: 10, Entries: []tmmbr.Entry{{SSRC: 1, Bitrate: 1_000_000, Overhead: 40}},
+	}, now)
+	assert.Equal(t, []*tmmbr.Notification{{
+		SenderSSRC: 1, Entries: []tmmbr.Entry{{SSRC: 10, Bitrate: 1_000_000, Overhead: 40}},
+	}}, notifications)
+	limit, limitedBy, _ = limits.limit(now)
+	assert.Equal(t, 1_000_000, limit)
+	assert.Equal(t, limitedByTMMBR, limitedBy)
+
+	// A receiver with a higher limit is not in the bounding set
+	notifications = limits.onTMMBR(&tmmbr.Request{
+		SenderSSRC: 11, Entries: []tmmbr.Entry{{SSRC: 1, Bitrate: 1_500_000}},
+	}, now)
+	assert.Equal(t, []*tmmbr.Notification{{
+		SenderSSRC: 1, Entries: []tmmbr.Entry{{SSRC: 10, Bitrate: 1_000_000, Overhead: 40}},
+	}}, notifications)
+
+	// A receiver limits several media streams, each stream gets its bounding set
+	notifications = limits.onTMMBR(&tmmbr.Request{
+		SenderSSRC: 12, Entries: []tmmbr.Entry{{SSRC: 1, Bitrate: 1_000_000}, {SSRC: 2, Bitrate: 800_000}},
+	}, now)
+	assert.Equal(t, []*tmmbr.Notification{
+		{SenderSSRC: 1, Entries: []tmmbr.Entry{{SSRC: 10, Bitrate: 1_000_000, Overhead: 40}, {SSRC: 12, Bitrate: 1_000_000}}},
+		{SenderSSRC: 2, Entries: []tmmbr.Entry{{SSRC: 12, Bitrate: 800_000}}},
+	}, notifications)
+	limit, _, _ = limits.limit(now)
+	assert.Equal(t, 800_000, limit)
+
+	// Entries for streams of other media senders are ignored
+	notifications = limits.onTMMBR(&tmmbr.Request{
+		SenderSSRC: 13, Entries: []tmmbr.Entry{{SSRC: 3, Bitrate: 100_000}},
+	}, now)
+	assert.Empty(t, notifications)
+	limit, _, _ = limits.limit(now)
+	assert.Equal(t, 800_000, limit)
+
+	// The owners of the bounding sets raise their limits
+	limits.onTMMBR(&tmmbr.Request{SenderSSRC: 12, Entries: []tmmbr.Entry{{SSRC: 2, Bitrate: 3_000_000}}}, now)
+	notifications = limits.onTMMBR(&tmmbr.Request{
+		SenderSSRC: 10, Entries: []tmmbr.Entry{{SSRC: 1, Bitrate: 3_000_000}},
+	}, now)
+	assert.Equal(t, []*tmmbr.Notification{{
+		SenderSSRC: 1, Entries: []tmmbr.Entry{{SSRC: 12, Bitrate: 1_000_000}},
+	}}, notifications)
+
+	// The REMB is lower now
+	limit, limitedBy, _ = limits.limit(now)
+	assert.Equal(t, 1_000_000, limit)
+	assert.Equal(t, limitedByTMMBR, limitedBy)
+	limits.onREMB(500_000, now)
+	limit, limitedBy, _ = limits.limit(now)
+	assert.Equal(t, 500_000, limit)
+	assert.Equal(t, limitedByREMB, limitedBy)
+
+	// Limits that are not repeated expire
+	limits.onREMB(600_000, now.Add(receiverLimitTimeout/2))
+	limit, limitedBy, _ = limits.limit(now.Add(receiverLimitTimeout))
+	assert.Equal(t, 600_000, limit)
+	assert.Equal(t, limitedByREMB, limitedBy)
+	_, _, ok = limits.limit(now.Add(receiverLimitTimeout * 3 / 2))
+	assert.False(t, ok)
+}
+
+// mockRTCPRecorder records the RTCP packets written to it.
+type mockRTCPRecorder struct {
+	pkts []rtcp.Packet
+}
+
+func (m *mockRTCPRecorder) Write(pkts []rtcp.Packet, _ interceptor.Attributes) (int, error) {
+	m.pkts = append(m.pkts, pkts...)
+
+	return 0, nil
+}
+
+func TestSendSideBWE_ReceiverLimits(t *testing.T) {
+	bwe, err := NewSendSideBWE(SendSideBWEInitialBitrate(1_000_000), SendSideBWEPacer(NewNoOpPacer()))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, bwe.Close())
+	}()
+	writer := &mockRTCPRecorder{}
+	bwe.BindRTCPWriter(writer)
+	bwe.AddStream(&interceptor.StreamInfo{SSRC: 1}, &mockRTPRecorder{})
+	assert.Equal(t, limitedByDelay, bwe.GetStats()["limitedBy"])
+
+	require.NoError(t, bwe.WriteRTCP([]rtcp.Packet{&rtcp.ReceiverEstimatedMaximumBitrate{Bitrate: 600_000}}, nil))
+	assert.Equal(t, 600_000, bwe.GetTargetBitrate())
+	assert.Equal(t, limitedByREMB, bwe.GetStats()["limitedBy"])
+	assert.Equal(t, 600_000, bwe.GetStats()["rembBitrate"])
+
+	// TMMBR is received as raw packet
+	raw, err := tmmbr.Request{SenderSSRC: 10, Entries: []tmmbr.Entry{{SSRC: 1, Bitrate: 300_000}}}.Marshal()
+	require.NoError(t, err)
+	pkts, err := rtcp.Unmarshal(raw)
+	require.NoError(t, err)
+	require.NoError(t, bwe.WriteRTCP(pkts, nil))
+	assert.Equal(t, 300_000, bwe.GetTargetBitrate())
+	stats := bwe.GetStats()
+	assert.Equal(t, limitedByTMMBR, stats["limitedBy"])
+	assert.Equal(t, 300_000, stats["tmmbrBitrate"])
+
+	require.Len(t, writer.pkts, 1)
+	assert.Equal(t, &tmmbr.Notification{
+		SenderSSRC: 1, Entries: []tmmbr.Entry{{SSRC: 10, Bitrate: 300_000}},
+	}, writer.pkts[0])
+
+	// Raising the limits above the estimate restores it
+	raw, err = tmmbr.Request{SenderSSRC: 10, Entries: []tmmbr.Entry{{SSRC: 1, Bitrate: 10_000_000}}}.Marshal()
+	require.NoError(t, err)
+	require.NoError(t, bwe.WriteRTCP([]rtcp.Packet{(*rtcp.RawPacket)(&raw)}, nil))
+	require.NoError(t, bwe.WriteRTCP([]rtcp.Packet{&rtcp.ReceiverEstimatedMaximumBitrate{Bitrate: 10_000_000}}, nil))
+	assert.Equal(t, 1_000_000, bwe.GetTargetBitrate())
+	assert.Equal(t, limitedByDelay, bwe.GetStats()["limitedBy"])
+}
+
+// failingRTCPWriter fails to write RTCP packets.
+type failingRTCPWriter struct{}
+
+func (failingRTCPWriter) Write([]rtcp.Packet, interceptor.Attributes) (int, error) {
+	return 0, errors.New("write failed") //nolint:err113
+}
+
+func TestSendSideBWE_TMMBNWriteFailure(t *testing.T) {
+	bwe, err := NewSendSideBWE(SendSideBWEInitialBitrate(1_000_000), SendSideBWEPacer(NewNoOpPacer()))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, bwe.Close())
+	}()
+	bwe.BindRTCPWriter(failingRTCPWriter{})
+	bwe.AddStream(&interceptor.StreamInfo{SSRC: 1}, &mockRTPRecorder{})
+
+	// The failure to send the TMMBN does not stop the rest of the batch
+	raw, err := tmmbr.Request{SenderSSRC: 10, Entries: []tmmbr.Entry{{SSRC: 1, Bitrate: 300_000}}}.Marshal()
+	require.NoError(t, err)
+	require.NoError(t, bwe.WriteRTCP([]rtcp.Packet{
+		(*rtcp.RawPacket)(&raw),
+		&rtcp.ReceiverEstimatedMaximumBitrate{Bitrate: 200_000},
+	}, nil))
+	assert.Equal(t, 200_000, bwe.GetTargetBitrate())
+	assert.Equal(t, limitedByREMB, bwe.GetStats()["limitedBy"])
+}
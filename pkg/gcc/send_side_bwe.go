@@ -12,6 +12,7 @@ import (
 	"github.com/pion/interceptor"
 	"github.com/pion/interceptor/internal/cc"
 	"github.com/pion/interceptor/internal/ntp"
+	"github.com/pion/interceptor/internal/tmmbr"
 	"github.com/pion/logging"
 	"github.com/pion/rtcp"
 	"github.com/pion/rtp"
@@ -22,11 +23,43 @@ const (
 	latestBitrate  = 10_000
 	minBitrate     = 5_000
 	maxBitrate     = 50_000_000
+
+	// rttSampleTimeout is how long an RTT sample of a source is used after it was measured.
+	rttSampleTimeout = 5 * time.Second
+	// processInterval is how often the estimator updates the ALR budget and processes the
+	// probe clusters.
+	processInterval = 5 * time.Millisecond
+)
+
+// rttSource is a source of RTT samples.
+type rttSource int
+
+const (
+	// rttFromFeedback is measured from congestion control feedback. It includes the time
+	// the receiver holds packets until it sends feedback, so it overestimates the RTT.
+	rttFromFeedback rttSource = iota
+	// rttFromReports is measured from the LSR and DLSR of reception reports.
+	rttFromReports
+	numRTTSources
 )
 
+// rttSample is the latest RTT measured by a source.
+type rttSample struct {
+	rtt time.Duration
+	at  time.Time
+}
+
 // ErrSendSideBWEClosed is raised when SendSideBWE.WriteRTCP is called after SendSideBWE.Close.
 var ErrSendSideBWEClosed = errors.New("SendSideBwe closed")
 
+// ErrInvalidTrendlineParameters is returned by SendSideBWETrendlineEstimator for a
+// window smaller than two groups or a smoothing factor outside [0, 1).
+var ErrInvalidTrendlineParameters = errors.New("invalid trendline estimator parameters")
+
+// ErrPaddingUnsupported is returned by NewSendSideBWE for a padding rate with a pacer
+// that does not implement PaddingPacer.
+var ErrPaddingUnsupported = errors.New("pacer does not support padding")
+
 // Pacer is the interface implemented by packet pacers.
 type Pacer interface {
 	interceptor.RTPWriter
@@ -35,6 +68,15 @@ type Pacer interface {
 	Close() error
 }
 
+// PaddingPacer is the interface implemented by pacers that send padding up to a padding
+// rate, like the LeakyBucketPacer.
+type PaddingPacer interface {
+	// AddStreamInfo registers the RTX SSRC and payload type of a local stream, which carry
+	// the padding of the stream.
+	AddStreamInfo(info *interceptor.StreamInfo)
+	SetPaddingRate(int)
+}
+
 // Stats contains internal statistics of the bandwidth estimator.
 type Stats struct {
 	LossStats
@@ -44,9 +86,24 @@ type Stats struct {
 // SendSideBWE implements a combination of loss and delay based GCC.
 type SendSideBWE struct {
 	pacer           Pacer
-	lossController  *lossBasedBandwidthEstimator
+	paddingPacer    PaddingPacer
+	paddingRate     int
+	lossController  lossEstimator
 	delayController *delayController
 	feedbackAdapter *cc.FeedbackAdapter
+	prober          *prober
+	probing         bool
+	alrDetector     *alrDetector
+	lossBasedV2     bool
+
+	congestionWindow *congestionWindow
+	pushback         bool
+	pauseWhenFull    bool
+	latestRTT        time.Duration
+	rttSamples       [numRTTSources]rttSample
+
+	trendlineWindowSize int
+	trendlineSmoothing  float64
 
 	onTargetBitrateChange func(bitrate int)
 
@@ -56,10 +113,19 @@ type SendSideBWE struct {
 	minBitrate    int
 	maxBitrate    int
 
+	// estimatedBitrate is the target bitrate before the limits of the receivers apply.
+	estimatedBitrate int
+	receiverLimits   *receiverLimits
+	limitedBy        string
+	rtcpWriter       interceptor.RTCPWriter
+
 	close     chan struct{}
 	closeLock sync.RWMutex
+	// wg tracks the goroutine that runs the periodic updates.
+	wg sync.WaitGroup
 
 	loggerFactory logging.LoggerFactory
+	log           logging.LeveledLogger
 }
 
 // Option configures a bandwidth estimator.
@@ -101,6 +167,76 @@ func SendSideBWEPacer(p Pacer) Option {
 	}
 }
 
+// SendSideBWEProbing enables probing for the available bandwidth at startup and after
+// large estimate drops, instead of only ramping up the estimate slowly. Probe clusters
+// are sent through the pacer at multiples of the estimate, filled with padding on the
+// RTX SSRC of a stream, and their bitrate measured from transport wide feedback is
+// adopted immediately. Probing requires the transport wide congestion control header
+// extension, whose sequence numbers are then assigned by the estimator in send order.
+func SendSideBWEProbing() Option {
+	return func(e *SendSideBWE) error {
+		e.probing = true
+
+		return nil
+	}
+}
+
+// SendSideBWETrendlineEstimator estimates the delay gradient with a trendline filter,
+// as libwebrtc does, instead of the Kalman filter. The trendline is the slope of a linear
+// regression over the last windowSize arrival groups, fitted to the accumulated delay
+// variation smoothed with an exponential moving average of the given smoothing factor.
+// libwebrtc uses a window of 20 groups and a smoothing factor of 0.9.
+func SendSideBWETrendlineEstimator(windowSize int, smoothing float64) Option {
+	return func(e *SendSideBWE) error {
+		if windowSize < 2 || smoothing < 0 || smoothing >= 1 {
+			return ErrInvalidTrendlineParameters
+		}
+		e.trendlineWindowSize = windowSize
+		e.trendlineSmoothing = smoothing
+
+		return nil
+	}
+}
+
+// SendSideBWELossBasedV2 replaces the threshold based loss controller with an estimator
+// modeled on LossBasedBweV2 of libwebrtc. It fits a model of inherent and congestion loss
+// to the loss observed at the acknowledged send rates, so that random loss, as on
+// wireless links, does not decrease the estimate.
+func SendSideBWELossBasedV2() Option {
+	return func(e *SendSideBWE) error {
+		e.lossBasedV2 = true
+
+		return nil
+	}
+}
+
+// SendSideBWECongestionWindow limits the data in flight to a congestion window of the
+// estimate times the RTT, plus an accepted queuing delay. While the bytes sent but not yet
+// acknowledged by feedback fill the window, the target bitrate of the pacer is pushed back.
+// If pauseWhenFull is set, the pacer is paused with a zero target bitrate until feedback
+// frees the window, which requires a pacer that holds packets at a zero target bitrate,
+// like the LeakyBucketPacer.
+func SendSideBWECongestionWindow(pauseWhenFull bool) Option {
+	return func(e *SendSideBWE) error {
+		e.pushback = true
+		e.pauseWhenFull = pauseWhenFull
+
+		return nil
+	}
+}
+
+// SendSideBWEPaddingRate sets the rate up to which the pacer sends padding when the
+// streams send less, so that the delay based estimate does not stall while the encoder
+// undershoots. The padding is sent on the RTX SSRC of the streams and does not exceed the
+// target bitrate. The pacer has to implement PaddingPacer.
+func SendSideBWEPaddingRate(rate int) Option {
+	return func(e *SendSideBWE) error {
+		e.paddingRate = rate
+
+		return nil
+	}
+}
+
 // WithLoggerFactory sets the logger factory for the bandwidth estimator.
 func WithLoggerFactory(factory logging.LoggerFactory) Option {
 	return func(e *SendSideBWE) error {
@@ -123,6 +259,8 @@ func NewSendSideBWE(opts ...Option) (*SendSideBWE, error) {
 		latestBitrate:         latestBitrate,
 		minBitrate:            minBitrate,
 		maxBitrate:            maxBitrate,
+		receiverLimits:        newReceiverLimits(),
+		limitedBy:             limitedByDelay,
 		close:                 make(chan struct{}),
 	}
 	for _, opt := range opts {
@@ -133,22 +271,79 @@ func NewSendSideBWE(opts ...Option) (*SendSideBWE, error) {
 	if send.loggerFactory == nil {
 		send.loggerFactory = logging.NewDefaultLoggerFactory()
 	}
+	send.log = send.loggerFactory.NewLogger("gcc_send_side_bwe")
+	send.estimatedBitrate = send.latestBitrate
 	if send.pacer == nil {
 		send.pacer = newLeakyBucketPacer(send.latestBitrate, send.loggerFactory)
 	}
-	send.lossController = newLossBasedBWE(send.latestBitrate, send.loggerFactory)
+	if paddingPacer, ok := send.pacer.(PaddingPacer); ok {
+		send.paddingPacer = paddingPacer
+		send.paddingPacer.SetPaddingRate(send.paddingRate)
+	} else if send.paddingRate > 0 {
+		return nil, ErrPaddingUnsupported
+	}
+	if send.pushback {
+		send.congestionWindow = newCongestionWindow(
+			send.pacer, send.feedbackAdapter, send.latestBitrate, send.pauseWhenFull,
+		)
+		send.pacer = send.congestionWindow
+	}
+	if send.probing {
+		send.prober = newProber(
+			send.pacer, send.latestBitrate, send.minBitrate, send.maxBitrate, send.loggerFactory.NewLogger("gcc_prober"),
+		)
+	}
+	send.alrDetector = newALRDetector(send.latestBitrate)
+	if send.lossBasedV2 {
+		send.lossController = newLossBasedBWEV2(send.latestBitrate, send.minBitrate, send.maxBitrate, send.loggerFactory)
+	} else {
+		send.lossController = newLossBasedBWE(send.latestBitrate, send.loggerFactory)
+	}
 	send.delayController = newDelayController(delayControllerConfig{
 		nowFn:          time.Now,
 		initialBitrate: send.latestBitrate,
 		minBitrate:     send.minBitrate,
 		maxBitrate:     send.maxBitrate,
+
+		trendlineWindowSize: send.trendlineWindowSize,
+		trendlineSmoothing:  send.trendlineSmoothing,
+		applicationLimited:  send.alrDetector.applicationLimited,
 	}, send.loggerFactory)
 
 	send.delayController.onUpdate(send.onDelayUpdate)
 
+	send.wg.Add(1)
+	go func() {
+		defer send.wg.Done()
+		send.run()
+	}()
+
 	return send, nil
 }
 
+// run updates the ALR budget and processes the probe clusters periodically until the
+// estimator is closed. The ALR budget is refilled while nothing is sent, so that an idle
+// sender becomes application limited, and probing starts before the first feedback
+// arrives and clusters time out without feedback.
+// Reference: libwebrtc modules/pacing/pacing_controller.cc ProcessPackets (UpdateBudgetWithElapsedTime)
+func (e *SendSideBWE) run() {
+	ticker := time.NewTicker(processInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-e.close:
+			return
+		case <-ticker.C:
+			now := time.Now()
+			e.alrDetector.onBytesSent(0, now)
+			if e.prober != nil {
+				e.prober.process(now)
+			}
+		}
+	}
+}
+
 // AddStream adds a new stream to the bandwidth estimator.
 func (e *SendSideBWE) AddStream(info *interceptor.StreamInfo, writer interceptor.RTPWriter) interceptor.RTPWriter {
 	var hdrExtID uint8
@@ -160,21 +355,53 @@ func (e *SendSideBWE) AddStream(info *interceptor.StreamInfo, writer interceptor
 		}
 	}
 
-	e.pacer.AddStream(info.SSRC, interceptor.RTPWriterFunc(
+	if e.prober != nil && hdrExtID != 0 {
+		e.prober.addStream(info)
+	}
+	e.lock.Lock()
+	e.receiverLimits.addStream(info.SSRC)
+	e.lock.Unlock()
+
+	streamWriter := interceptor.RTPWriterFunc(
 		func(header *rtp.Header, payload []byte, attributes interceptor.Attributes) (int, error) {
+			size := len(payload) + int(header.PaddingSize)
 			if hdrExtID != 0 {
 				if attributes == nil {
 					attributes = make(interceptor.Attributes)
 				}
 				attributes.Set(cc.TwccExtensionAttributesKey, hdrExtID)
+
+				if e.prober != nil {
+					tcc, err := (&rtp.TransportCCExtension{
+						TransportSequence: e.prober.onPacketSent(header, payload, size, time.Now()),
+					}).Marshal()
+					if err != nil {
+						return 0, err
+					}
+					if err := header.SetExtension(hdrExtID, tcc); err != nil {
+						return 0, err
+					}
+				}
 			}
-			if err := e.feedbackAdapter.OnSent(time.Now(), header, len(payload), attributes); err != nil {
+			now := time.Now()
+			if err := e.feedbackAdapter.OnSent(now, header, size, attributes); err != nil {
 				return 0, err
 			}
+			e.alrDetector.onBytesSent(header.MarshalSize()+size, now)
+			if e.congestionWindow != nil {
+				e.congestionWindow.onPacketSent(now)
+			}
 
 			return writer.Write(header, payload, attributes)
 		},
-	))
+	)
+	e.pacer.AddStream(info.SSRC, streamWriter)
+	if info.SSRCRetransmission != 0 {
+		e.pacer.AddStream(info.SSRCRetransmission, streamWriter)
+	}
+	if e.paddingPacer != nil {
+		e.paddingPacer.AddStreamInfo(info)
+	}
 
 	return e.pacer
 }
@@ -214,6 +441,27 @@ func (e *SendSideBWE) WriteRTCP(pkts []rtcp.Packet, _ interceptor.Attributes) er
 		case *rtcp.CCFeedbackReport:
 			acks = e.feedbackAdapter.OnRFC8888Feedback(now, fb)
 			feedbackSentTime = ntp.ToTime(uint64(fb.ReportTimestamp) << 16)
+		case *rtcp.ReceiverReport:
+			e.onReceptionReports(now, fb.Reports)
+
+			continue
+		case *rtcp.SenderReport:
+			e.onReceptionReports(now, fb.Reports)
+
+			continue
+		case *rtcp.ReceiverEstimatedMaximumBitrate:
+			e.lock.Lock()
+			e.receiverLimits.onREMB(int(fb.Bitrate), now)
+			e.applyTargetBitrate()
+			e.lock.Unlock()
+
+			continue
+		case *rtcp.RawPacket:
+			if request, ok := tmmbr.FromRaw(*fb).(*tmmbr.Request); ok {
+				e.onTMMBR(request, now)
+			}
+
+			continue
 		default:
 			continue
 		}
@@ -228,16 +476,99 @@ func (e *SendSideBWE) WriteRTCP(pkts []rtcp.Packet, _ interceptor.Attributes) er
 			feedbackMinRTT = time.Duration(min(int(rtt), int(feedbackMinRTT)))
 		}
 		if feedbackMinRTT < math.MaxInt {
-			e.delayController.updateRTT(feedbackMinRTT)
+			e.updateRTT(rttFromFeedback, feedbackMinRTT, now)
+		}
+
+		if e.prober != nil {
+			if bitrate, ok := e.prober.onAcknowledgments(acks); ok {
+				e.lossController.onProbeResult(bitrate)
+				e.delayController.onProbeResult(bitrate)
+			}
 		}
 
 		e.lossController.updateLossEstimate(acks)
 		e.delayController.updateDelayEstimate(acks)
 	}
 
+	if e.congestionWindow != nil {
+		e.lock.Lock()
+		bitrate, rtt := e.latestBitrate, e.latestRTT
+		e.lock.Unlock()
+		if rtt > 0 {
+			e.congestionWindow.update(bitrate, rtt, now)
+		}
+	}
+
 	return nil
 }
 
+// onReceptionReports updates the RTT from the LSR and DLSR of reception reports, which
+// assumes that the NTP timestamps of the sender reports are taken from the local clock.
+// See https://datatracker.ietf.org/doc/html/rfc3550#section-6.4.1
+func (e *SendSideBWE) onReceptionReports(now time.Time, reports []rtcp.ReceptionReport) {
+	arrival := ntp.ToNTP32(now)
+	for _, report := range reports {
+		if report.LastSenderReport == 0 {
+			continue
+		}
+		rtt := arrival - report.LastSenderReport - report.Delay
+		// A negative RTT wraps around, and is caused by clock differences.
+		if rtt >= 1<<31 {
+			continue
+		}
+		e.updateRTT(rttFromReports, time.Duration(float64(rtt)/65536.0*float64(time.Second)), now)
+	}
+}
+
+// onTMMBR limits the target bitrate to the request, and notifies the receivers of the
+// new bounding sets with TMMBN. A failure to send the TMMBN is logged, since it must not
+// keep the remaining feedback from being processed.
+func (e *SendSideBWE) onTMMBR(request *tmmbr.Request, now time.Time) {
+	e.lock.Lock()
+	notifications := e.receiverLimits.onTMMBR(request, now)
+	e.applyTargetBitrate()
+	writer := e.rtcpWriter
+	e.lock.Unlock()
+
+	if len(notifications) == 0 || writer == nil {
+		return
+	}
+	pkts := make([]rtcp.Packet, 0, len(notifications))
+	for _, notification := range notifications {
+		pkts = append(pkts, notification)
+	}
+	if _, err := writer.Write(pkts, nil); err != nil {
+		e.log.Warnf("failed to send TMMBN: %v", err)
+	}
+}
+
+// BindRTCPWriter sets the writer used to send TMMBN in response to TMMBR.
+func (e *SendSideBWE) BindRTCPWriter(writer interceptor.RTCPWriter) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.rtcpWriter = writer
+}
+
+// updateRTT updates the RTT used by the rate controller and the congestion window with a
+// sample of a source. The RTT is the minimum of the recent samples of all sources, so that
+// reception reports correct the RTT from feedback, which is inflated by the feedback
+// interval, while feedback keeps the RTT up to date between reports.
+func (e *SendSideBWE) updateRTT(source rttSource, rtt time.Duration, now time.Time) {
+	e.lock.Lock()
+	e.rttSamples[source] = rttSample{rtt: rtt, at: now}
+	latest := rtt
+	for _, sample := range e.rttSamples {
+		if !sample.at.IsZero() && now.Sub(sample.at) < rttSampleTimeout {
+			latest = min(latest, sample.rtt)
+		}
+	}
+	e.latestRTT = latest
+	e.lock.Unlock()
+
+	e.delayController.updateRTT(latest)
+}
+
 // GetTargetBitrate returns the current target bitrate in bits per second.
 func (e *SendSideBWE) GetTargetBitrate() int {
 	e.lock.Lock()
@@ -251,7 +582,8 @@ func (e *SendSideBWE) GetStats() map[string]any {
 	e.lock.Lock()
 	defer e.lock.Unlock()
 
-	return map[string]any{
+	now := time.Now()
+	stats := map[string]any{
 		"lossTargetBitrate":  e.latestStats.LossStats.TargetBitrate,
 		"averageLoss":        e.latestStats.AverageLoss,
 		"delayTargetBitrate": e.latestStats.DelayStats.TargetBitrate,
@@ -260,7 +592,25 @@ func (e *SendSideBWE) GetStats() map[string]any {
 		"delayThreshold":     float64(e.latestStats.Threshold.Microseconds()) / 1000.0,
 		"usage":              e.latestStats.Usage.String(),
 		"state":              e.latestStats.State.String(),
+		"rtt":                float64(e.latestRTT.Microseconds()) / 1000.0,
+		"applicationLimited": e.alrDetector.applicationLimited(),
+		"limitedBy":          e.limitedBy,
+		"rembBitrate":        e.receiverLimits.rembBitrate(now),
+	}
+	if bitrate, ok := e.receiverLimits.tmmbr(now); ok {
+		stats["tmmbrBitrate"] = bitrate
+	}
+	if e.prober != nil {
+		stats["probeBitrate"] = e.prober.lastProbeResult()
+	}
+	if e.congestionWindow != nil {
+		window, outstanding, ratio := e.congestionWindow.stats(now)
+		stats["congestionWindow"] = window
+		stats["outstandingBytes"] = outstanding
+		stats["pushbackRatio"] = ratio
 	}
+
+	return stats
 }
 
 // OnTargetBitrateChange sets the callback that is called when the target
@@ -288,6 +638,7 @@ func (e *SendSideBWE) Close() error {
 		return err
 	}
 	close(e.close)
+	e.wg.Wait()
 
 	return e.pacer.Close()
 }
@@ -297,20 +648,39 @@ func (e *SendSideBWE) onDelayUpdate(delayStats DelayStats) {
 	defer e.lock.Unlock()
 
 	lossStats := e.lossController.getEstimate(delayStats.TargetBitrate)
-	bitrateChanged := false
-	bitrate := min(delayStats.TargetBitrate, lossStats.TargetBitrate)
-	if bitrate != e.latestBitrate {
-		bitrateChanged = true
-		e.latestBitrate = bitrate
-		e.pacer.SetTargetBitrate(e.latestBitrate)
+	e.estimatedBitrate = min(delayStats.TargetBitrate, lossStats.TargetBitrate)
+	e.latestStats = Stats{
+		LossStats:  lossStats,
+		DelayStats: delayStats,
 	}
+	e.applyTargetBitrate()
+}
 
-	if bitrateChanged && e.onTargetBitrateChange != nil {
-		go e.onTargetBitrateChange(bitrate)
+// applyTargetBitrate sets the target bitrate to the estimate, limited by the maximum
+// bitrates requested by the receivers.
+// This method should be called with lock held.
+func (e *SendSideBWE) applyTargetBitrate() {
+	bitrate := e.estimatedBitrate
+	e.limitedBy = limitedByDelay
+	if e.latestStats.LossStats.TargetBitrate < e.latestStats.DelayStats.TargetBitrate {
+		e.limitedBy = limitedByLoss
+	}
+	if limit, limitedBy, ok := e.receiverLimits.limit(time.Now()); ok && limit < bitrate {
+		bitrate = max(limit, e.minBitrate)
+		e.limitedBy = limitedBy
+	}
+	if bitrate == e.latestBitrate {
+		return
 	}
 
-	e.latestStats = Stats{
-		LossStats:  lossStats,
-		DelayStats: delayStats,
+	e.latestBitrate = bitrate
+	e.alrDetector.setEstimatedBitrate(e.latestBitrate)
+	if e.prober != nil {
+		e.prober.onEstimate(e.latestBitrate, time.Now())
+	} else {
+		e.pacer.SetTargetBitrate(e.latestBitrate)
+	}
+	if e.onTargetBitrateChange != nil {
+		go e.onTargetBitrateChange(bitrate)
 	}
 }
@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/pion/interceptor"
+	"github.com/pion/interceptor/internal/ntp"
 	"github.com/pion/interceptor/pkg/twcc"
 	"github.com/pion/logging"
 	"github.com/pion/rtcp"
@@ -112,6 +114,195 @@ func TestSendSideBWE_ErrorOnWriteRTCPAtClosedState(t *testing.T) {
 	require.Equal(t, bwe.isClosed(), true)
 }
 
+// mockRTPRecorder records the headers of the RTP packets written to it.
+type mockRTPRecorder struct {
+	headers []rtp.Header
+}
+
+func (m *mockRTPRecorder) Write(header *rtp.Header, payload []byte, _ interceptor.Attributes) (int, error) {
+	m.headers = append(m.headers, header.Clone())
+
+	return header.MarshalSize() + len(payload), nil
+}
+
+func TestSendSideBWE_Probing(t *testing.T) {
+	bwe, err := NewSendSideBWE(
+		SendSideBWEPacer(NewNoOpPacer()),
+		SendSideBWEInitialBitrate(1_000_000),
+		SendSideBWEProbing(),
+	)
+	require.NoError(t, err)
+
+	packets := make(mockRTPChan, 1000)
+	writer := bwe.AddStream(&interceptor.StreamInfo{
+		SSRC:                      1,
+		SSRCRetransmission:        2,
+		PayloadTypeRetransmission: 97,
+		RTPHeaderExtensions:       []interceptor.RTPHeaderExtension{{URI: transportCCURI, ID: 1}},
+	}, packets)
+
+	// The startup clusters are sent without feedback
+	var padding []*rtp.Packet
+	for done := false; !done; {
+		select {
+		case pkt := <-packets:
+			padding = append(padding, pkt)
+		case <-time.After(100 * time.Millisecond):
+			done = true
+		}
+	}
+	require.NotEmpty(t, padding)
+
+	recorder := twcc.NewRecorder(5000)
+	arrivalTime := int64(0)
+	for i, pkt := range padding {
+		assert.Equal(t, uint32(2), pkt.SSRC)
+		assert.Equal(t, uint8(97), pkt.PayloadType)
+		assert.Equal(t, uint8(255), pkt.Header.PaddingSize)
+		assert.Equal(t, padding[0].SequenceNumber+uint16(i), pkt.SequenceNumber) //nolint:gosec // G115
+
+		var tcc rtp.TransportCCExtension
+		require.NoError(t, tcc.Unmarshal(pkt.GetExtension(1)))
+		arrivalTime += 1000
+		recorder.Record(1, tcc.TransportSequence, arrivalTime)
+	}
+
+	// Retransmissions continue the RTX sequence numbers of the probe padding
+	_, err = writer.Write(&rtp.Header{SSRC: 2, PayloadType: 97, SequenceNumber: 1000}, make([]byte, 100), nil)
+	require.NoError(t, err)
+	retransmission := <-packets
+	assert.Equal(t, padding[len(padding)-1].SequenceNumber+1, retransmission.SequenceNumber)
+
+	require.NoError(t, bwe.WriteRTCP(recorder.BuildFeedbackPacket(), nil))
+
+	// Padding of 275 bytes received every millisecond
+	probeBitrate, ok := bwe.GetStats()["probeBitrate"].(int)
+	require.True(t, ok)
+	assert.InDelta(t, 2_090_000, probeBitrate, 10_000)
+	assert.Equal(t, probeBitrate, bwe.GetTargetBitrate())
+	require.NoError(t, bwe.Close())
+}
+
+func TestSendSideBWE_IdleSenderApplicationLimited(t *testing.T) {
+	bwe, err := NewSendSideBWE(SendSideBWEPacer(NewNoOpPacer()))
+	require.NoError(t, err)
+
+	assert.Eventually(t, bwe.alrDetector.applicationLimited, 2*time.Second, 10*time.Millisecond)
+	require.NoError(t, bwe.Close())
+}
+
+// mockRTPChan is a RTPWriter that passes the written packets to a channel.
+type mockRTPChan chan *rtp.Packet
+
+func (m mockRTPChan) Write(header *rtp.Header, payload []byte, _ interceptor.Attributes) (int, error) {
+	m <- &rtp.Packet{Header: header.Clone(), Payload: append([]byte(nil), payload...)}
+
+	return header.MarshalSize() + len(payload), nil
+}
+
+func TestSendSideBWE_Padding(t *testing.T) {
+	_, err := NewSendSideBWE(SendSideBWEPacer(NewNoOpPacer()), SendSideBWEPaddingRate(100_000))
+	assert.ErrorIs(t, err, ErrPaddingUnsupported)
+
+	bwe, err := NewSendSideBWE(SendSideBWEInitialBitrate(1_000_000), SendSideBWEPaddingRate(400_000))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, bwe.Close())
+	}()
+
+	written := make(mockRTPChan, 1000)
+	writer := bwe.AddStream(&interceptor.StreamInfo{
+		SSRC:                      1,
+		SSRCRetransmission:        2,
+		PayloadType:               96,
+		PayloadTypeRetransmission: 97,
+	}, written)
+	_, err = writer.Write(&rtp.Header{SSRC: 1, PayloadType: 96, SequenceNumber: 7}, make([]byte, 500), nil)
+	require.NoError(t, err)
+
+	media := <-written
+	assert.Equal(t, uint32(1), media.SSRC)
+
+	// The media packet is retransmitted as payload padding at about the padding rate
+	start := time.Now()
+	bytes := 0
+	var last *rtp.Packet
+	for time.Since(start) < 200*time.Millisecond {
+		select {
+		case p := <-written:
+			assert.Equal(t, uint32(2), p.SSRC)
+			assert.Equal(t, uint8(97), p.PayloadType)
+			assert.Equal(t, []byte{0, 7}, p.Payload[:2])
+			if last != nil {
+				assert.Equal(t, last.SequenceNumber+1, p.SequenceNumber)
+			}
+			last = p
+			bytes += p.MarshalSize()
+		case <-time.After(100 * time.Millisecond):
+			assert.FailNow(t, "no padding written")
+		}
+	}
+	assert.Greater(t, bytes, 0)
+	assert.Less(t, bytes, 400_000/8*3/10)
+}
+
+func TestSendSideBWE_ReceiverReportRTT(t *testing.T) {
+	bwe, err := NewSendSideBWE(SendSideBWEPacer(NewNoOpPacer()))
+	require.NoError(t, err)
+
+	now := time.Now()
+	require.NoError(t, bwe.WriteRTCP([]rtcp.Packet{
+		&rtcp.ReceiverReport{Reports: []rtcp.ReceptionReport{{
+			SSRC:             1,
+			LastSenderReport: ntp.ToNTP32(now.Add(-150 * time.Millisecond)),
+			Delay:            65536 / 10, // 100ms
+		}}},
+		// Reports without a sender report and reports from unsynchronized clocks are ignored
+		&rtcp.ReceiverReport{Reports: []rtcp.ReceptionReport{{SSRC: 1}}},
+		&rtcp.SenderReport{Reports: []rtcp.ReceptionReport{{
+			SSRC:             1,
+			LastSenderReport: ntp.ToNTP32(now.Add(time.Second)),
+		}}},
+	}, nil))
+
+	rtt, ok := bwe.GetStats()["rtt"].(float64)
+	require.True(t, ok)
+	assert.InDelta(t, 50, rtt, 5)
+	bwe.delayController.rateController.lock.Lock()
+	assert.InDelta(t, 50*time.Millisecond, bwe.delayController.rateController.latestRTT, float64(5*time.Millisecond))
+	bwe.delayController.rateController.lock.Unlock()
+	require.NoError(t, bwe.Close())
+}
+
+func TestSendSideBWE_CombinedRTT(t *testing.T) {
+	bwe, err := NewSendSideBWE(SendSideBWEPacer(NewNoOpPacer()))
+	require.NoError(t, err)
+
+	rtt := func() float64 {
+		value, ok := bwe.GetStats()["rtt"].(float64)
+		require.True(t, ok)
+
+		return value
+	}
+	now := time.Now()
+
+	// The RTT from feedback is used until reception reports measure a lower RTT
+	bwe.updateRTT(rttFromFeedback, 80*time.Millisecond, now)
+	assert.Equal(t, 80.0, rtt())
+	bwe.updateRTT(rttFromReports, 50*time.Millisecond, now)
+	assert.Equal(t, 50.0, rtt())
+	bwe.updateRTT(rttFromFeedback, 90*time.Millisecond, now.Add(time.Second))
+	assert.Equal(t, 50.0, rtt())
+
+	// Samples of a source time out
+	bwe.updateRTT(rttFromFeedback, 90*time.Millisecond, now.Add(rttSampleTimeout))
+	assert.Equal(t, 90.0, rtt())
+	bwe.delayController.rateController.lock.Lock()
+	assert.Equal(t, 90*time.Millisecond, bwe.delayController.rateController.latestRTT)
+	bwe.delayController.rateController.lock.Unlock()
+	require.NoError(t, bwe.Close())
+}
+
 func BenchmarkSendSideBWE_WriteRTCP(b *testing.B) {
 	numSequencesPerTwccReport := []int{10, 100, 500, 1000}
 
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package gcc
+
+import (
+	"time"
+)
+
+// trendlineThresholdGain scales the trend so that it can be compared to the
+// delay threshold of the overuse detector.
+// Reference: libwebrtc modules/congestion_controller/goog_cc/trendline_estimator.cc
+const trendlineThresholdGain = 4.0
+
+type trendlinePoint struct {
+	arrival float64 // ms since the first arrival group
+	delay   float64 // smoothed accumulated delay in ms
+}
+
+// trendlineEstimator estimates the delay gradient as the slope of a linear regression
+// over a window of the smoothed accumulated inter-group delay variation. It is an
+// alternative to the slopeEstimator, whose Kalman filter is no longer used by libwebrtc.
+// Reference: libwebrtc modules/congestion_controller/goog_cc/trendline_estimator.cc
+type trendlineEstimator struct {
+	windowSize int
+	smoothing  float64
+
+	init         bool
+	group        arrivalGroup
+	firstArrival time.Time
+
+	accumulatedDelay float64
+	smoothedDelay    float64
+	history          []trendlinePoint
+	trend            float64
+
+	delayStatsWriter func(DelayStats)
+}
+
+func newTrendlineEstimator(windowSize int, smoothing float64, dsw func(DelayStats)) *trendlineEstimator {
+	return &trendlineEstimator{
+		windowSize:       windowSize,
+		smoothing:        smoothing,
+		history:          make([]trendlinePoint, 0, windowSize),
+		delayStatsWriter: dsw,
+	}
+}
+
+func (e *trendlineEstimator) onArrivalGroup(ag arrivalGroup) {
+	if !e.init {
+		e.group = ag
+		e.firstArrival = ag.arrival
+		e.init = true
+
+		return
+	}
+	measurement := interGroupDelayVariation(e.group, ag)
+	delta := ag.arrival.Sub(e.group.arrival)
+	e.group = ag
+
+	e.accumulatedDelay += float64(measurement.Microseconds()) / 1000.0
+	e.smoothedDelay = e.smoothing*e.smoothedDelay + (1-e.smoothing)*e.accumulatedDelay
+
+	if len(e.history) == e.windowSize {
+		copy(e.history, e.history[1:])
+		e.history = e.history[:len(e.history)-1]
+	}
+	e.history = append(e.history, trendlinePoint{
+		arrival: float64(ag.arrival.Sub(e.firstArrival).Microseconds()) / 1000.0,
+		delay:   e.smoothedDelay,
+	})
+	// Keep the previous trend until the window is filled, or if the slope is undefined
+	if len(e.history) == e.windowSize {
+		if trend, ok := linearFitSlope(e.history); ok {
+			e.trend = trend
+		}
+	}
+
+	e.delayStatsWriter(DelayStats{
+		Measurement:      measurement,
+		Estimate:         time.Duration(e.trend * trendlineThresholdGain * float64(time.Millisecond)),
+		Threshold:        0,
+		LastReceiveDelta: delta,
+		Usage:            0,
+		State:            0,
+		TargetBitrate:    0,
+	})
+}
+
+// linearFitSlope returns the slope of the least squares fit of the delay over the arrival time.
+func linearFitSlope(points []trendlinePoint) (float64, bool) {
+	var sumX, sumY float64
+	for _, p := range points {
+		sumX += p.arrival
+		sumY += p.delay
+	}
+	avgX := sumX / float64(len(points))
+	avgY := sumY / float64(len(points))
+
+	var numerator, denominator float64
+	for _, p := range points {
+		numerator += (p.arrival - avgX) * (p.delay - avgY)
+		denominator += (p.arrival - avgX) * (p.arrival - avgX)
+	}
+	if denominator == 0 {
+		return 0, false
+	}
+
+	return numerator / denominator, true
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package gcc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrendlineEstimator(t *testing.T) {
+	// Groups sent every 10ms arrive every 12ms, the delay grows by 2ms per group.
+	groups := func(count int) []arrivalGroup {
+		ags := make([]arrivalGroup, count)
+		for i := range ags {
+			ags[i] = arrivalGroup{
+				departure: time.Time{}.Add(time.Duration(i) * 10 * time.Millisecond),
+				arrival:   time.Time{}.Add(time.Duration(i) * 12 * time.Millisecond),
+			}
+		}
+
+		return ags
+	}
+
+	t.Run("increasing delay", func(t *testing.T) {
+		var stats []DelayStats
+		estimator := newTrendlineEstimator(3, 0, func(ds DelayStats) {
+			stats = append(stats, ds)
+		})
+		for _, ag := range groups(6) {
+			estimator.onArrivalGroup(ag)
+		}
+
+		require.Len(t, stats, 5)
+		for i, ds := range stats {
+			assert.Equal(t, 2*time.Millisecond, ds.Measurement)
+			assert.Equal(t, 12*time.Millisecond, ds.LastReceiveDelta)
+			if i < 2 {
+				// The window is not filled yet
+				assert.Equal(t, time.Duration(0), ds.Estimate)
+			} else {
+				// A slope of 2/12 scaled by the threshold gain
+				assert.InDelta(t, float64(2*time.Millisecond)/12*trendlineThresholdGain, float64(ds.Estimate), 1000)
+			}
+		}
+	})
+
+	t.Run("smoothing delays the trend", func(t *testing.T) {
+		var estimates []time.Duration
+		estimator := newTrendlineEstimator(3, 0.9, func(ds DelayStats) {
+			estimates = append(estimates, ds.Estimate)
+		})
+		for _, ag := range groups(6) {
+			estimator.onArrivalGroup(ag)
+		}
+
+		unsmoothed := float64(2*time.Millisecond) / 12 * trendlineThresholdGain
+		require.Len(t, estimates, 5)
+		assert.Greater(t, estimates[2], time.Duration(0))
+		assert.Less(t, float64(estimates[2]), unsmoothed)
+		assert.Greater(t, estimates[4], estimates[2])
+	})
+
+	t.Run("constant delay", func(t *testing.T) {
+		var estimates []time.Duration
+		estimator := newTrendlineEstimator(20, 0.9, func(ds DelayStats) {
+			estimates = append(estimates, ds.Estimate)
+		})
+		for i := 0; i < 30; i++ {
+			estimator.onArrivalGroup(arrivalGroup{
+				departure: time.Time{}.Add(time.Duration(i) * 10 * time.Millisecond),
+				arrival:   time.Time{}.Add(time.Duration(i)*10*time.Millisecond + 40*time.Millisecond),
+			})
+		}
+
+		for _, estimate := range estimates {
+			assert.Equal(t, time.Duration(0), estimate)
+		}
+	})
+}
+
+func TestSendSideBWETrendlineEstimatorOption(t *testing.T) {
+	_, err := NewSendSideBWE(SendSideBWETrendlineEstimator(1, 0.9))
+	assert.ErrorIs(t, err, ErrInvalidTrendlineParameters)
+	_, err = NewSendSideBWE(SendSideBWETrendlineEstimator(20, 1))
+	assert.ErrorIs(t, err, ErrInvalidTrendlineParameters)
+
+	bwe, err := NewSendSideBWE(SendSideBWETrendlineEstimator(20, 0.9))
+	require.NoError(t, err)
+	assert.NoError(t, bwe.Close())
+}
@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package jitterbuffer
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	// delayHistoryWindow is how long packet delays are remembered. The target delay
+	// decreases again once delay spikes are older than the window.
+	delayHistoryWindow = 2 * time.Second
+	// maxDelaySamples bounds the number of remembered packet delays.
+	maxDelaySamples = 500
+	// delayPercentile is the fraction of packets that should arrive before their playout time.
+	delayPercentile = 0.95
+	// jitterMultiplier scales the RFC 3550 jitter to a minimum target delay, which reacts
+	// faster than the percentile to a sudden increase of jitter.
+	jitterMultiplier = 2
+)
+
+// delaySample is the arrival delay of a packet relative to the first packet.
+type delaySample struct {
+	arrival time.Time
+	delay   time.Duration
+}
+
+// delayEstimator estimates the playout delay needed to absorb network jitter.
+// The relative delay of a packet is its arrival time minus its RTP timestamp, compared to
+// the fastest packet of the last two seconds. The target delay is the 95th percentile of
+// the relative delays, and at least twice the RFC 3550 interarrival jitter.
+type delayEstimator struct {
+	clockRate uint32
+	minDelay  time.Duration
+	maxDelay  time.Duration
+
+	started     bool
+	baseArrival time.Time
+	// lastTS is the RTP timestamp of the last packet, lastUnwrapped the same timestamp
+	// relative to the first packet.
+	lastTS        uint32
+	lastUnwrapped int64
+	newest        int64
+
+	// jitter is the RFC 3550 Section 6.4.1 interarrival jitter.
+	jitter      time.Duration
+	lastTransit time.Duration
+	samples     []delaySample
+
+	minRelative time.Duration
+	target      time.Duration
+	targetStale bool
+}
+
+func newDelayEstimator(clockRate uint32, minDelay, maxDelay time.Duration) *delayEstimator {
+	return &delayEstimator{
+		clockRate: clockRate,
+		minDelay:  minDelay,
+		maxDelay:  maxDelay,
+		target:    minDelay,
+	}
+}
+
+// unwrap returns the RTP timestamp relative to the first packet.
+func (e *delayEstimator) unwrap(ts uint32) int64 {
+	return e.lastUnwrapped + int64(int32(ts-e.lastTS)) //nolint:gosec // G115
+}
+
+// ticksToDuration converts an RTP timestamp difference to a duration.
+func (e *delayEstimator) ticksToDuration(ticks int64) time.Duration {
+	return time.Duration(ticks * int64(time.Second) / int64(e.clockRate))
+}
+
+// update adds a packet with the RTP timestamp ts arriving at arrival.
+func (e *delayEstimator) update(ts uint32, arrival time.Time) {
+	if !e.started {
+		e.started = true
+		e.baseArrival = arrival
+		e.lastTS = ts
+	}
+
+	unwrapped := e.unwrap(ts)
+	e.lastTS = ts
+	e.lastUnwrapped = unwrapped
+	e.newest = max(e.newest, unwrapped)
+
+	// J(i) = J(i-1) + (|D(i-1,i)| - J(i-1))/16, RFC 3550 Section 6.4.1
+	transit := arrival.Sub(e.baseArrival) - e.ticksToDuration(unwrapped)
+	if len(e.samples) > 0 {
+		d := transit - e.lastTransit
+		if d < 0 {
+			d = -d
+		}
+		e.jitter += (d - e.jitter) / 16
+	}
+	e.lastTransit = transit
+
+	e.samples = append(e.samples, delaySample{arrival: arrival, delay: transit})
+	for len(e.samples) > maxDelaySamples || arrival.Sub(e.samples[0].arrival) > delayHistoryWindow {
+		e.samples = e.samples[1:]
+	}
+	e.targetStale = true
+}
+
+// refresh recomputes the minimum relative delay and the target delay.
+func (e *delayEstimator) refresh() {
+	if !e.targetStale {
+		return
+	}
+	e.targetStale = false
+
+	delays := make([]time.Duration, len(e.samples))
+	for i, sample := range e.samples {
+		delays[i] = sample.delay
+	}
+	sort.Slice(delays, func(i, j int) bool { return delays[i] < delays[j] })
+
+	e.minRelative = delays[0]
+	percentile := delays[int(float64(len(delays)-1)*delayPercentile)] - e.minRelative
+	target := max(percentile, jitterMultiplier*e.jitter)
+	e.target = min(max(target, e.minDelay), e.maxDelay)
+}
+
+// targetDelay returns the target playout delay within the delay bounds.
+func (e *delayEstimator) targetDelay() time.Duration {
+	if !e.started {
+		return e.minDelay
+	}
+	e.refresh()
+
+	return e.target
+}
+
+// playoutTime returns the local time at which the packet with RTP timestamp ts should
+// be played out: the arrival time of a packet with the minimum delay plus the target delay.
+func (e *delayEstimator) playoutTime(ts uint32) time.Time {
+	e.refresh()
+
+	return e.baseArrival.Add(e.ticksToDuration(e.unwrap(ts)) + e.minRelative + e.target)
+}
+
+// span returns the media duration from the packet with RTP timestamp ts to the newest packet.
+func (e *delayEstimator) span(ts uint32) time.Duration {
+	return e.ticksToDuration(e.newest - e.unwrap(ts))
+}
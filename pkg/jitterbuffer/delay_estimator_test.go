@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package jitterbuffer
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelayEstimator(t *testing.T) {
+	t.Run("Unwraps timestamps", func(t *testing.T) {
+		estimator := newDelayEstimator(8000, 0, time.Second)
+		start := time.Unix(0, 0)
+
+		estimator.update(math.MaxUint32-159, start)
+		estimator.update(0, start.Add(20*time.Millisecond))
+		estimator.update(160, start.Add(40*time.Millisecond))
+
+		assert.Equal(t, int64(320), estimator.newest)
+		assert.Equal(t, 40*time.Millisecond, estimator.span(math.MaxUint32-159))
+		assert.Equal(t, start.Add(40*time.Millisecond), estimator.playoutTime(160))
+	})
+
+	t.Run("Measures interarrival jitter", func(t *testing.T) {
+		estimator := newDelayEstimator(8000, 0, time.Second)
+		start := time.Unix(0, 0)
+
+		estimator.update(0, start)
+		estimator.update(160, start.Add(36*time.Millisecond))
+
+		// D = 16ms, J = 16ms / 16
+		assert.Equal(t, time.Millisecond, estimator.jitter)
+		assert.Equal(t, 2*time.Millisecond, estimator.targetDelay())
+		assert.Equal(t, start.Add(22*time.Millisecond), estimator.playoutTime(160))
+	})
+}
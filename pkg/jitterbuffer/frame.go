@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package jitterbuffer
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/pion/rtp"
+)
+
+// ErrFrameIncomplete is returned by PopFrame if the frame at the playout head is missing packets.
+var ErrFrameIncomplete = errors.New("frame at the playout head is incomplete")
+
+// Frame is the group of buffered packets sharing an RTP timestamp.
+type Frame struct {
+	Timestamp uint32
+	// Packets are in sequence number order.
+	Packets []*rtp.Packet
+	// Complete reports whether the frame has all packets from its first packet through
+	// the packet with the marker bit set, without gaps.
+	Complete bool
+}
+
+// frameState tracks the frame boundaries of the packets popped by frame.
+type frameState struct {
+	// deadline is how long an incomplete frame is waited for before it is skipped,
+	// negative if incomplete frames are never skipped.
+	deadline time.Duration
+	// since is when the frame with timestamp sinceTS became the frame at the playout head.
+	since   time.Time
+	sinceTS uint32
+
+	popped     bool
+	lastSeq    uint16
+	lastTS     uint32
+	lastMarker bool
+}
+
+// WithFrameDeadline will skip an incomplete frame at the playout head once it was
+// waited for the given duration, so that PopFrame continues with the next frame.
+func WithFrameDeadline(deadline time.Duration) Option {
+	return func(jb *JitterBuffer) {
+		jb.frames.deadline = max(deadline, 0)
+	}
+}
+
+// PeekFrame returns the frame at the playout head without removing it from the buffer.
+func (jb *JitterBuffer) PeekFrame() (*Frame, error) {
+	jb.mutex.Lock()
+	defer jb.unlock()
+
+	return jb.frameAtHead()
+}
+
+// PopFrame removes and returns the frame at the playout head once it is complete.
+// Packets of a frame are grouped by RTP timestamp, and a frame ends with the marker bit.
+// If the frame is incomplete, ErrFrameIncomplete is returned until the frame deadline
+// passes, then the frame is skipped and the next frame is returned if it is complete.
+// With adaptive delay, frames are returned once their playout time is reached.
+// PopFrame should not be mixed with the packet level Pop methods.
+func (jb *JitterBuffer) PopFrame() (*Frame, error) {
+	jb.mutex.Lock()
+	defer jb.unlock()
+
+	if jb.state != Emitting && jb.delay == nil {
+		return nil, ErrPopWhileBuffering
+	}
+
+	for {
+		frame, err := jb.frameAtHead()
+		if err != nil {
+			return nil, err
+		}
+		if jb.delay != nil && jb.delay.playoutTime(frame.Timestamp).After(jb.now()) {
+			return nil, ErrPopWhileBuffering
+		}
+
+		if frame.Complete {
+			jb.beginPlayback()
+			jb.removeFrame(frame)
+
+			return frame, nil
+		}
+
+		now := jb.now()
+		if jb.frames.since.IsZero() || jb.frames.sinceTS != frame.Timestamp {
+			jb.frames.since = now
+			jb.frames.sinceTS = frame.Timestamp
+		}
+		if jb.frames.deadline < 0 || now.Sub(jb.frames.since) < jb.frames.deadline {
+			return nil, ErrFrameIncomplete
+		}
+
+		jb.beginPlayback()
+		jb.removeFrame(frame)
+		jb.stats.SkippedFrameCount++
+		jb.emit(FrameSkipped)
+	}
+}
+
+// frameAtHead collects the buffered packets of the frame at the playout head.
+// This method should be called with mutex held.
+func (jb *JitterBuffer) frameAtHead() (*Frame, error) {
+	first, err := jb.packets.findNext(jb.playoutHead)
+	if err != nil {
+		return nil, ErrBufferUnderrun
+	}
+
+	frame := &Frame{Timestamp: first.Timestamp}
+	for node := jb.packets.next; node != nil; node = node.next {
+		if node.val.Timestamp == frame.Timestamp && node.priority-jb.playoutHead < 1<<15 {
+			frame.Packets = append(frame.Packets, node.val)
+		}
+	}
+	sort.Slice(frame.Packets, func(i, j int) bool {
+		return frame.Packets[i].SequenceNumber-jb.playoutHead < frame.Packets[j].SequenceNumber-jb.playoutHead
+	})
+
+	frame.Complete = first.SequenceNumber == jb.playoutHead && jb.startsFrame(first)
+	for i, packet := range frame.Packets {
+		if packet.SequenceNumber != first.SequenceNumber+uint16(i) { //nolint:gosec // G115
+			frame.Complete = false
+		}
+	}
+	frame.Complete = frame.Complete && frame.Packets[len(frame.Packets)-1].Marker
+
+	return frame, nil
+}
+
+// startsFrame reports whether the packet following the last popped frame starts a new frame.
+// At the start of the stream, the first packet is assumed to start a frame.
+// This method should be called with mutex held.
+func (jb *JitterBuffer) startsFrame(packet *rtp.Packet) bool {
+	if !jb.frames.popped {
+		return true
+	}
+
+	return packet.SequenceNumber == jb.frames.lastSeq+1 &&
+		(jb.frames.lastMarker || packet.Timestamp != jb.frames.lastTS)
+}
+
+// removeFrame removes the packets of a frame and moves the playout head past it.
+// This method should be called with mutex held.
+func (jb *JitterBuffer) removeFrame(frame *Frame) {
+	for _, packet := range frame.Packets {
+		_, _ = jb.packets.PopAt(packet.SequenceNumber)
+	}
+
+	last := frame.Packets[len(frame.Packets)-1]
+	jb.playoutHead = last.SequenceNumber + 1
+	jb.frames.popped = true
+	jb.frames.lastSeq = last.SequenceNumber
+	jb.frames.lastTS = last.Timestamp
+	jb.frames.lastMarker = last.Marker
+	jb.frames.since = time.Time{}
+}
@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package jitterbuffer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJitterBufferFrames(t *testing.T) {
+	// push pushes a packet of the frame with the given timestamp.
+	push := func(jb *JitterBuffer, seq uint16, ts uint32, marker bool) {
+		jb.Push(&rtp.Packet{Header: rtp.Header{SequenceNumber: seq, Timestamp: ts, Marker: marker}})
+	}
+	sequenceNumbers := func(frame *Frame) []uint16 {
+		var seqs []uint16
+		for _, packet := range frame.Packets {
+			seqs = append(seqs, packet.SequenceNumber)
+		}
+
+		return seqs
+	}
+
+	t.Run("Pops complete frames in order", func(t *testing.T) {
+		jb := New(WithMinimumPacketCount(1))
+
+		push(jb, 65534, 3000, false)
+		push(jb, 0, 3000, true)
+		push(jb, 1, 6000, true)
+		push(jb, 65535, 3000, false)
+
+		frame, err := jb.PeekFrame()
+		require.NoError(t, err)
+		assert.True(t, frame.Complete)
+		assert.Equal(t, uint16(4), jb.packets.Length())
+
+		frame, err = jb.PopFrame()
+		require.NoError(t, err)
+		assert.Equal(t, uint32(3000), frame.Timestamp)
+		assert.Equal(t, []uint16{65534, 65535, 0}, sequenceNumbers(frame))
+
+		frame, err = jb.PopFrame()
+		require.NoError(t, err)
+		assert.Equal(t, []uint16{1}, sequenceNumbers(frame))
+
+		_, err = jb.PopFrame()
+		assert.ErrorIs(t, err, ErrBufferUnderrun)
+	})
+
+	t.Run("Reports incomplete frames", func(t *testing.T) {
+		jb := New(WithMinimumPacketCount(1))
+
+		push(jb, 10, 3000, true)
+		_, err := jb.PopFrame()
+		require.NoError(t, err)
+
+		// Missing marker
+		push(jb, 11, 6000, false)
+		frame, err := jb.PeekFrame()
+		require.NoError(t, err)
+		assert.False(t, frame.Complete)
+		_, err = jb.PopFrame()
+		assert.ErrorIs(t, err, ErrFrameIncomplete)
+
+		// Gap within the frame
+		push(jb, 13, 6000, true)
+		frame, err = jb.PeekFrame()
+		require.NoError(t, err)
+		assert.False(t, frame.Complete)
+
+		push(jb, 12, 6000, false)
+		frame, err = jb.PopFrame()
+		require.NoError(t, err)
+		assert.Equal(t, []uint16{11, 12, 13}, sequenceNumbers(frame))
+	})
+
+	t.Run("Skips incomplete frames after the deadline", func(t *testing.T) {
+		now := time.Unix(0, 0)
+		jb := New(WithMinimumPacketCount(1), WithFrameDeadline(100*time.Millisecond))
+		jb.now = func() time.Time { return now }
+		var skipped int
+		jb.Listen(FrameSkipped, func(Event, *JitterBuffer) {
+			skipped++
+		})
+
+		push(jb, 0, 3000, true)
+		_, err := jb.PopFrame()
+		require.NoError(t, err)
+
+		// The first packet of the second frame is lost
+		push(jb, 2, 6000, true)
+		push(jb, 3, 9000, true)
+		_, err = jb.PopFrame()
+		assert.ErrorIs(t, err, ErrFrameIncomplete)
+
+		now = now.Add(100 * time.Millisecond)
+		frame, err := jb.PopFrame()
+		require.NoError(t, err)
+		assert.Equal(t, uint32(9000), frame.Timestamp)
+		assert.Equal(t, 1, skipped)
+		assert.Equal(t, uint32(1), jb.Stats().SkippedFrameCount)
+	})
+
+	t.Run("Waits for the playout time with adaptive delay", func(t *testing.T) {
+		now := time.Unix(0, 0)
+		jb := New(WithClockRate(90000), WithAdaptiveDelay(50*time.Millisecond, time.Second))
+		jb.now = func() time.Time { return now }
+
+		push(jb, 0, 0, false)
+		push(jb, 1, 0, true)
+		_, err := jb.PopFrame()
+		assert.ErrorIs(t, err, ErrPopWhileBuffering)
+
+		now = now.Add(50 * time.Millisecond)
+		frame, err := jb.PopFrame()
+		require.NoError(t, err)
+		assert.Len(t, frame.Packets, 2)
+		assert.Equal(t, Emitting, jb.state)
+	})
+
+	t.Run("Waits for buffering", func(t *testing.T) {
+		jb := New()
+
+		push(jb, 0, 0, true)
+		_, err := jb.PopFrame()
+		assert.ErrorIs(t, err, ErrPopWhileBuffering)
+	})
+}
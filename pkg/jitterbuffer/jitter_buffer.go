@@ -8,6 +8,7 @@ package jitterbuffer
 import (
 	"errors"
 	"sync"
+	"time"
 
 	"github.com/pion/rtp"
 )
@@ -42,6 +43,17 @@ const (
 	BufferUnderflow = "underflow"
 	// BufferOverflow is emitted when the buffer has exceeded its limit.
 	BufferOverflow = "overflow"
+	// LatePacket is emitted when a packet arrives after its sequence number was played out or skipped.
+	LatePacket = "late"
+	// DuplicatePacket is emitted when a packet arrives that is already buffered.
+	DuplicatePacket = "duplicate"
+	// DiscardedPacket is emitted when a packet is dropped without being played out,
+	// either because it is late or a duplicate.
+	DiscardedPacket = "discarded"
+	// BufferReset is emitted when the buffer is cleared and its state is reset.
+	BufferReset = "reset"
+	// FrameSkipped is emitted when PopFrame skips an incomplete frame after the frame deadline.
+	FrameSkipped = "frameSkipped"
 )
 
 func (jbs State) String() string {
@@ -76,36 +88,80 @@ type JitterBuffer struct {
 	stats         Stats
 	listeners     map[Event][]EventListener
 	mutex         sync.Mutex
+	// pending are the events emitted while mutex is held.
+	pending []pendingEvent
+
+	// clockRate is the RTP clock rate of the stream, required for adaptive delay.
+	clockRate uint32
+	// adaptive releases packets at their playout time, with a delay between minDelay and maxDelay.
+	adaptive bool
+	minDelay time.Duration
+	maxDelay time.Duration
+	delay    *delayEstimator
+	now      func() time.Time
+
+	// gapWait is how long a missing packet is waited for before playout skips it,
+	// negative if missing packets are never skipped without adaptive delay.
+	gapWait  time.Duration
+	gapSince time.Time
+
+	frames frameState
 }
 
-// Stats Track interesting statistics for the life of this JitterBuffer
-// outOfOrderCount will provide the number of times a packet was Pushed
-//
-//	without its predecessor being present
-//
-// underflowCount will provide the count of attempts to Pop an empty buffer
-// overflowCount will track the number of times the jitter buffer exceeds its limit.
+// Stats Track interesting statistics for the life of this JitterBuffer.
+// The counters are reset when the JitterBuffer is cleared with resetState.
 type Stats struct {
-	outOfOrderCount uint32
-	underflowCount  uint32
-	overflowCount   uint32
+	// OutOfOrderCount is the number of times a packet was Pushed without its
+	// predecessor being present.
+	OutOfOrderCount uint32
+	// UnderflowCount is the count of attempts to Pop an empty buffer.
+	UnderflowCount uint32
+	// OverflowCount is the number of times the jitter buffer exceeded its limit.
+	OverflowCount uint32
+	// LateCount is the number of packets that arrived after their sequence number was
+	// played out or skipped.
+	LateCount uint32
+	// DuplicateCount is the number of packets that were already buffered.
+	DuplicateCount uint32
+	// DiscardedCount is the number of packets dropped without being played out,
+	// including late and duplicate packets.
+	DiscardedCount uint32
+	// SkippedFrameCount is the number of incomplete frames skipped by PopFrame.
+	SkippedFrameCount uint32
+
+	// Depth is the number of buffered packets.
+	Depth uint16
+	// DepthDuration is the media duration between the oldest and the newest buffered
+	// packet. It is zero if the clock rate of the stream is unknown.
+	DepthDuration time.Duration
+	// TargetDelay is the current playout delay with adaptive delay, or else zero.
+	TargetDelay time.Duration
+	// State is the current state of the JitterBuffer.
+	State State
 }
 
 // New will initialize a jitter buffer and its associated statistics.
 func New(opts ...Option) *JitterBuffer {
 	jb := &JitterBuffer{
 		state:         Buffering,
-		stats:         Stats{0, 0, 0},
+		stats:         Stats{},
 		minStartCount: 50,
 		overflowLen:   100,
 		packets:       NewQueue(),
 		listeners:     make(map[Event][]EventListener),
+		now:           time.Now,
+		gapWait:       -1,
+		frames:        frameState{deadline: -1},
 	}
 
 	for _, o := range opts {
 		o(jb)
 	}
 
+	if jb.adaptive && jb.clockRate > 0 {
+		jb.delay = newDelayEstimator(jb.clockRate, jb.minDelay, jb.maxDelay)
+	}
+
 	return jb
 }
 
@@ -117,17 +173,50 @@ func WithMinimumPacketCount(count uint16) Option {
 	}
 }
 
+// WithAdaptiveDelay will release packets at their playout time instead of after a minimum
+// packet count. The playout delay follows the network jitter measured from packet arrival
+// times and RTP timestamps, within minDelay and maxDelay. It requires the clock rate of the
+// stream, set with WithClockRate; without it the minimum packet count is used.
+func WithAdaptiveDelay(minDelay, maxDelay time.Duration) Option {
+	return func(jb *JitterBuffer) {
+		jb.adaptive = true
+		jb.minDelay = max(minDelay, 0)
+		jb.maxDelay = max(maxDelay, jb.minDelay)
+	}
+}
+
+// WithClockRate will set the RTP clock rate of the buffered stream.
+func WithClockRate(clockRate uint32) Option {
+	return func(jb *JitterBuffer) {
+		jb.clockRate = clockRate
+	}
+}
+
+// WithMaxGapWait will skip a missing packet at the playout head after waiting for it
+// for the given duration, and continue playout with the next buffered packet. Packets
+// arriving after their sequence number was skipped are discarded as late.
+// With adaptive delay, the wait starts at the playout time of the next buffered packet,
+// and missing packets are skipped without waiting by default.
+func WithMaxGapWait(wait time.Duration) Option {
+	return func(jb *JitterBuffer) {
+		jb.gapWait = max(wait, 0)
+	}
+}
+
 // Listen will register an event listener
 // The jitter buffer may emit events correspnding, interested listerns should
 // look at Event for available events.
 func (jb *JitterBuffer) Listen(event Event, cb EventListener) {
+	jb.mutex.Lock()
+	defer jb.unlock()
+
 	jb.listeners[event] = append(jb.listeners[event], cb)
 }
 
 // PlayoutHead returns the SequenceNumber that will be attempted to Pop next.
 func (jb *JitterBuffer) PlayoutHead() uint16 {
 	jb.mutex.Lock()
-	defer jb.mutex.Unlock()
+	defer jb.unlock()
 
 	return jb.playoutHead
 }
@@ -136,7 +225,7 @@ func (jb *JitterBuffer) PlayoutHead() uint16 {
 // If you have encountered a packet that hasn't resolved you can skip it.
 func (jb *JitterBuffer) SetPlayoutHead(playoutHead uint16) {
 	jb.mutex.Lock()
-	defer jb.mutex.Unlock()
+	defer jb.unlock()
 
 	jb.playoutHead = playoutHead
 }
@@ -145,7 +234,7 @@ func (jb *JitterBuffer) updateStats(lastPktSeqNo uint16) {
 	// If we have at least one packet, and the next packet being pushed in is not
 	// at the expected sequence number increment the out of order count
 	if jb.packets.Length() > 0 && lastPktSeqNo != (jb.lastSequence+1) {
-		jb.stats.outOfOrderCount++
+		jb.stats.OutOfOrderCount++
 	}
 	jb.lastSequence = lastPktSeqNo
 }
@@ -154,34 +243,124 @@ func (jb *JitterBuffer) updateStats(lastPktSeqNo uint16) {
 // the data so if the memory is expected to be reused, the caller should
 // take this in to account and pass a copy of the packet they wish to buffer.
 func (jb *JitterBuffer) Push(packet *rtp.Packet) {
+	jb.push(packet)
+}
+
+// push pushes an RTP packet into the jitter buffer, and returns false if the packet was
+// discarded.
+func (jb *JitterBuffer) push(packet *rtp.Packet) bool {
 	jb.mutex.Lock()
-	defer jb.mutex.Unlock()
+	defer jb.unlock()
 
 	if jb.packets.Length() == 0 {
 		jb.emit(StartBuffering)
 	}
 
-	if jb.packets.Length() > jb.overflowLen {
-		jb.stats.overflowCount++
+	if jb.discard(packet) {
+		return false
+	}
+
+	if jb.delay != nil {
+		jb.delay.update(packet.Timestamp, jb.now())
+	}
+
+	if jb.overflowing() {
+		jb.stats.OverflowCount++
 		jb.emit(BufferOverflow)
 	}
 
 	if !jb.playoutReady && jb.packets.Length() == 0 {
 		jb.playoutHead = packet.SequenceNumber
 	}
+	// Packets are played out by time, so reordered packets before the first packet are played out too
+	if jb.delay != nil && !jb.playoutReady && int16(packet.SequenceNumber-jb.playoutHead) < 0 {
+		jb.playoutHead = packet.SequenceNumber
+	}
 
 	jb.updateStats(packet.SequenceNumber)
 	jb.packets.Push(packet, packet.SequenceNumber)
 	jb.updateState()
+
+	return true
 }
 
+// discard drops duplicate packets, and packets arriving after playout moved past them
+// when missing packets are skipped.
+// This method should be called with mutex held.
+func (jb *JitterBuffer) discard(packet *rtp.Packet) bool {
+	late := jb.skipsGaps() && jb.playoutReady && int16(packet.SequenceNumber-jb.playoutHead) < 0
+	if !late {
+		if _, err := jb.packets.Find(packet.SequenceNumber); err != nil {
+			return false
+		}
+	}
+
+	if late {
+		jb.stats.LateCount++
+		jb.emit(LatePacket)
+	} else {
+		jb.stats.DuplicateCount++
+		jb.emit(DuplicatePacket)
+	}
+	jb.stats.DiscardedCount++
+	jb.emit(DiscardedPacket)
+
+	return true
+}
+
+// skipsGaps reports whether playout continues past missing packets.
+func (jb *JitterBuffer) skipsGaps() bool {
+	return jb.delay != nil || jb.gapWait >= 0
+}
+
+// pendingEvent is an event with the listeners registered when it was emitted.
+type pendingEvent struct {
+	event     Event
+	listeners []EventListener
+}
+
+// emit queues the listeners of an event, they are called once mutex is released.
+// This method should be called with mutex held.
 func (jb *JitterBuffer) emit(event Event) {
-	for _, l := range jb.listeners[event] {
-		l(event, jb)
+	if listeners := jb.listeners[event]; len(listeners) > 0 {
+		jb.pending = append(jb.pending, pendingEvent{event: event, listeners: listeners})
+	}
+}
+
+// unlock releases mutex and calls the listeners of the events emitted while it was held,
+// so that listeners can call the JitterBuffer.
+func (jb *JitterBuffer) unlock() {
+	pending := jb.pending
+	jb.pending = nil
+	jb.mutex.Unlock()
+
+	for _, p := range pending {
+		for _, l := range p.listeners {
+			l(p.event, jb)
+		}
 	}
 }
 
+// overflowing reports whether the buffer has exceeded its limit: the maximum delay
+// with adaptive delay, or else the overflow length.
+// This method should be called with mutex held.
+func (jb *JitterBuffer) overflowing() bool {
+	if jb.delay == nil {
+		return jb.packets.Length() > jb.overflowLen
+	}
+	head, err := jb.packets.findNext(jb.playoutHead)
+	if err != nil {
+		return false
+	}
+
+	return jb.delay.span(head.Timestamp) > jb.maxDelay
+}
+
 func (jb *JitterBuffer) updateState() {
+	// With adaptive delay, playback begins when the first packet is due
+	if jb.delay != nil {
+		return
+	}
 	// For now, we only look at the number of packets captured in the play buffer
 	if jb.packets.Length() >= jb.minStartCount && jb.state == Buffering {
 		jb.state = Emitting
@@ -199,7 +378,7 @@ func (jb *JitterBuffer) updateState() {
 //	At the last sequence received
 func (jb *JitterBuffer) Peek(playoutHead bool) (*rtp.Packet, error) {
 	jb.mutex.Lock()
-	defer jb.mutex.Unlock()
+	defer jb.unlock()
 	if jb.packets.Length() < 1 {
 		return nil, ErrBufferUnderrun
 	}
@@ -213,13 +392,38 @@ func (jb *JitterBuffer) Peek(playoutHead bool) (*rtp.Packet, error) {
 // Pop an RTP packet from the jitter buffer at the current playout head.
 func (jb *JitterBuffer) Pop() (*rtp.Packet, error) {
 	jb.mutex.Lock()
-	defer jb.mutex.Unlock()
+	defer jb.unlock()
+
+	return jb.pop()
+}
+
+// popBuffered pops like Pop, but does not count an underflow when the buffer is empty.
+func (jb *JitterBuffer) popBuffered() (*rtp.Packet, error) {
+	jb.mutex.Lock()
+	defer jb.unlock()
+	if jb.packets.Length() == 0 {
+		return nil, ErrBufferUnderrun
+	}
+
+	return jb.pop()
+}
+
+// This method should be called with mutex held.
+func (jb *JitterBuffer) pop() (*rtp.Packet, error) {
+	if jb.delay != nil {
+		return jb.popDue()
+	}
 	if jb.state != Emitting {
 		return nil, ErrPopWhileBuffering
 	}
+	if jb.gapWait >= 0 && jb.packets.Length() > 0 {
+		if err := jb.skipGap(jb.now()); err != nil {
+			return nil, err
+		}
+	}
 	packet, err := jb.packets.PopAt(jb.playoutHead)
 	if err != nil {
-		jb.stats.underflowCount++
+		jb.stats.UnderflowCount++
 		jb.emit(BufferUnderflow)
 
 		return nil, err
@@ -230,16 +434,128 @@ func (jb *JitterBuffer) Pop() (*rtp.Packet, error) {
 	return packet, nil
 }
 
+// skipGap moves the playout head past a missing packet once it was waited for long enough,
+// and returns ErrPopWhileBuffering while waiting.
+// This method should be called with mutex held.
+func (jb *JitterBuffer) skipGap(now time.Time) error {
+	if _, err := jb.packets.Find(jb.playoutHead); err == nil {
+		jb.gapSince = time.Time{}
+
+		return nil
+	}
+	next, err := jb.packets.findNext(jb.playoutHead)
+	if err != nil {
+		return err
+	}
+	if jb.gapSince.IsZero() {
+		jb.gapSince = now
+	}
+	if now.Sub(jb.gapSince) < jb.gapWait {
+		return ErrPopWhileBuffering
+	}
+	jb.gapSince = time.Time{}
+	jb.playoutHead = next.SequenceNumber
+
+	return nil
+}
+
+// popDue pops the packet at the playout head once its playout time is reached.
+// If the packet at the playout head is missing, playout skips ahead to the next
+// buffered packet once that one is due, and the maximum gap wait has passed.
+// This method should be called with mutex held.
+func (jb *JitterBuffer) popDue() (*rtp.Packet, error) {
+	packet, err := jb.packets.findNext(jb.playoutHead)
+	if err != nil {
+		if jb.state != Emitting {
+			return nil, ErrPopWhileBuffering
+		}
+		jb.stats.UnderflowCount++
+		jb.emit(BufferUnderflow)
+
+		return nil, ErrBufferUnderrun
+	}
+	due := jb.delay.playoutTime(packet.Timestamp)
+	if packet.SequenceNumber != jb.playoutHead && jb.playoutReady {
+		due = due.Add(max(jb.gapWait, 0))
+	}
+	if due.After(jb.now()) {
+		return nil, ErrPopWhileBuffering
+	}
+
+	jb.beginPlayback()
+	if _, err := jb.packets.PopAt(packet.SequenceNumber); err != nil {
+		return nil, err
+	}
+	jb.playoutHead = packet.SequenceNumber + 1
+
+	return packet, nil
+}
+
+// beginPlayback switches to Emitting when the first packet is due with adaptive delay.
+// This method should be called with mutex held.
+func (jb *JitterBuffer) beginPlayback() {
+	if jb.state == Buffering {
+		jb.state = Emitting
+		jb.playoutReady = true
+		jb.emit(BeginPlayback)
+	}
+}
+
+// Stats returns a snapshot of the statistics of the JitterBuffer.
+func (jb *JitterBuffer) Stats() Stats {
+	jb.mutex.Lock()
+	defer jb.unlock()
+
+	stats := jb.stats
+	stats.Depth = jb.packets.Length()
+	stats.DepthDuration = jb.depthDuration()
+	stats.State = jb.state
+	if jb.delay != nil {
+		stats.TargetDelay = jb.delay.targetDelay()
+	}
+
+	return stats
+}
+
+// depthDuration returns the media duration between the oldest and the newest buffered packet.
+// This method should be called with mutex held.
+func (jb *JitterBuffer) depthDuration() time.Duration {
+	if jb.clockRate == 0 || jb.packets.next == nil {
+		return 0
+	}
+
+	first := jb.packets.next.val.Timestamp
+	var oldest, newest int32
+	for node := jb.packets.next; node != nil; node = node.next {
+		diff := int32(node.val.Timestamp - first) //nolint:gosec // G115
+		oldest = min(oldest, diff)
+		newest = max(newest, diff)
+	}
+
+	return time.Duration(int64(newest-oldest) * int64(time.Second) / int64(jb.clockRate))
+}
+
+// TargetDelay returns the current playout delay with adaptive delay, or else zero.
+func (jb *JitterBuffer) TargetDelay() time.Duration {
+	jb.mutex.Lock()
+	defer jb.unlock()
+	if jb.delay == nil {
+		return 0
+	}
+
+	return jb.delay.targetDelay()
+}
+
 // PopAtSequence will pop an RTP packet from the jitter buffer at the specified Sequence.
 func (jb *JitterBuffer) PopAtSequence(sq uint16) (*rtp.Packet, error) {
 	jb.mutex.Lock()
-	defer jb.mutex.Unlock()
+	defer jb.unlock()
 	if jb.state != Emitting {
 		return nil, ErrPopWhileBuffering
 	}
 	packet, err := jb.packets.PopAt(sq)
 	if err != nil {
-		jb.stats.underflowCount++
+		jb.stats.UnderflowCount++
 		jb.emit(BufferUnderflow)
 
 		return nil, err
@@ -254,7 +570,7 @@ func (jb *JitterBuffer) PopAtSequence(sq uint16) (*rtp.Packet, error) {
 // without removing it from the buffer.
 func (jb *JitterBuffer) PeekAtSequence(sq uint16) (*rtp.Packet, error) {
 	jb.mutex.Lock()
-	defer jb.mutex.Unlock()
+	defer jb.unlock()
 	packet, err := jb.packets.Find(sq)
 	if err != nil {
 		return nil, err
@@ -267,13 +583,13 @@ func (jb *JitterBuffer) PeekAtSequence(sq uint16) (*rtp.Packet, error) {
 // Call this method repeatedly to drain the buffer at the timestamp.
 func (jb *JitterBuffer) PopAtTimestamp(ts uint32) (*rtp.Packet, error) {
 	jb.mutex.Lock()
-	defer jb.mutex.Unlock()
+	defer jb.unlock()
 	if jb.state != Emitting {
 		return nil, ErrPopWhileBuffering
 	}
 	packet, err := jb.packets.PopAtTimestamp(ts)
 	if err != nil {
-		jb.stats.underflowCount++
+		jb.stats.UnderflowCount++
 		jb.emit(BufferUnderflow)
 
 		return nil, err
@@ -286,13 +602,19 @@ func (jb *JitterBuffer) PopAtTimestamp(ts uint32) (*rtp.Packet, error) {
 // Clear will empty the buffer and optionally reset the state.
 func (jb *JitterBuffer) Clear(resetState bool) {
 	jb.mutex.Lock()
-	defer jb.mutex.Unlock()
+	defer jb.unlock()
 	jb.packets.Clear()
 
 	if resetState {
 		jb.lastSequence = 0
 		jb.state = Buffering
-		jb.stats = Stats{0, 0, 0}
+		jb.playoutReady = false
+		jb.stats = Stats{}
+		jb.gapSince = time.Time{}
 		jb.minStartCount = 50
+		if jb.delay != nil {
+			jb.delay = newDelayEstimator(jb.clockRate, jb.minDelay, jb.maxDelay)
+		}
+		jb.emit(BufferReset)
 	}
 }
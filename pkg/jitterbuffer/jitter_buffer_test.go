@@ -6,9 +6,11 @@ package jitterbuffer
 import (
 	"math"
 	"testing"
+	"time"
 
 	"github.com/pion/rtp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 //nolint:cyclop,maintidx
@@ -26,7 +28,7 @@ func TestJitterBuffer(t *testing.T) {
 
 		jb.Push(&rtp.Packet{Header: rtp.Header{SequenceNumber: 5012, Timestamp: 512}, Payload: []byte{0x02}})
 
-		assert.Equal(jb.stats.outOfOrderCount, uint32(1))
+		assert.Equal(jb.stats.OutOfOrderCount, uint32(1))
 		assert.Equal(jb.packets.Length(), uint16(4))
 		assert.Equal(jb.lastSequence, uint16(5012))
 	})
@@ -275,7 +277,235 @@ func TestJitterBuffer(t *testing.T) {
 		assert.Equal(jb.lastSequence, uint16(5002))
 		jb.Clear(true)
 		assert.Equal(jb.lastSequence, uint16(0))
-		assert.Equal(jb.stats.outOfOrderCount, uint32(0))
+		assert.Equal(jb.stats.OutOfOrderCount, uint32(0))
 		assert.Equal(jb.packets.Length(), uint16(0))
 	})
 }
+
+func TestJitterBufferAdaptiveDelay(t *testing.T) {
+	// newTestBuffer returns a 8kHz buffer with a manual clock.
+	newTestBuffer := func(minDelay, maxDelay time.Duration) (*JitterBuffer, *time.Time) {
+		now := time.Unix(0, 0)
+		jb := New(WithClockRate(8000), WithAdaptiveDelay(minDelay, maxDelay))
+		jb.now = func() time.Time { return now }
+
+		return jb, &now
+	}
+	// push pushes the 20ms packet with the given sequence number.
+	push := func(jb *JitterBuffer, seq uint16) {
+		jb.Push(&rtp.Packet{Header: rtp.Header{SequenceNumber: seq, Timestamp: uint32(seq) * 160}})
+	}
+
+	t.Run("Releases packets at their playout time", func(t *testing.T) {
+		jb, now := newTestBuffer(40*time.Millisecond, time.Second)
+		var events []Event
+		jb.Listen(BeginPlayback, func(event Event, _ *JitterBuffer) {
+			events = append(events, event)
+		})
+
+		push(jb, 0)
+		_, err := jb.Pop()
+		assert.ErrorIs(t, err, ErrPopWhileBuffering)
+
+		*now = now.Add(40 * time.Millisecond)
+		push(jb, 2)
+		packet, err := jb.Pop()
+		require.NoError(t, err)
+		assert.Equal(t, uint16(0), packet.SequenceNumber)
+		assert.Equal(t, Emitting, jb.state)
+		assert.Equal(t, []Event{BeginPlayback}, events)
+
+		// Packet 1 is missing, packet 2 is played out in its place when due
+		_, err = jb.Pop()
+		assert.ErrorIs(t, err, ErrPopWhileBuffering)
+		*now = now.Add(40 * time.Millisecond)
+		packet, err = jb.Pop()
+		require.NoError(t, err)
+		assert.Equal(t, uint16(2), packet.SequenceNumber)
+		assert.Equal(t, uint16(3), jb.PlayoutHead())
+
+		_, err = jb.Pop()
+		assert.ErrorIs(t, err, ErrBufferUnderrun)
+	})
+
+	t.Run("Adapts to jitter", func(t *testing.T) {
+		jb, now := newTestBuffer(0, time.Second)
+		assert.Equal(t, time.Duration(0), jb.TargetDelay())
+
+		// Every fifth packet is 100ms late
+		for seq := uint16(0); seq < 100; seq++ {
+			arrival := time.Unix(0, 0).Add(time.Duration(seq) * 20 * time.Millisecond)
+			if seq%5 == 0 {
+				arrival = arrival.Add(100 * time.Millisecond)
+			}
+			*now = arrival
+			push(jb, seq)
+		}
+		assert.Equal(t, 100*time.Millisecond, jb.TargetDelay())
+
+		// The delay decreases once the network is stable again
+		for seq := uint16(100); seq < 250; seq++ {
+			*now = time.Unix(0, 0).Add(time.Duration(seq) * 20 * time.Millisecond)
+			push(jb, seq)
+		}
+		assert.Less(t, jb.TargetDelay(), 10*time.Millisecond)
+	})
+
+	t.Run("Stays within the delay bounds", func(t *testing.T) {
+		jb, now := newTestBuffer(20*time.Millisecond, 60*time.Millisecond)
+
+		for seq := uint16(0); seq < 50; seq++ {
+			*now = time.Unix(0, 0).Add(time.Duration(seq) * 20 * time.Millisecond)
+			if seq%2 == 0 {
+				*now = now.Add(200 * time.Millisecond)
+			}
+			push(jb, seq)
+		}
+		assert.Equal(t, 60*time.Millisecond, jb.TargetDelay())
+	})
+
+	t.Run("Overflows beyond the maximum delay", func(t *testing.T) {
+		jb, _ := newTestBuffer(0, 100*time.Millisecond)
+		var overflows int
+		jb.Listen(BufferOverflow, func(Event, *JitterBuffer) {
+			overflows++
+		})
+
+		for seq := uint16(0); seq < 7; seq++ {
+			push(jb, seq)
+		}
+		assert.Equal(t, 1, overflows)
+	})
+
+	t.Run("Plays out packets reordered before playback", func(t *testing.T) {
+		jb, now := newTestBuffer(0, time.Second)
+
+		push(jb, 11)
+		push(jb, 10)
+		*now = now.Add(time.Second)
+		packet, err := jb.Pop()
+		require.NoError(t, err)
+		assert.Equal(t, uint16(10), packet.SequenceNumber)
+	})
+
+	t.Run("Requires the clock rate", func(t *testing.T) {
+		jb := New(WithAdaptiveDelay(0, time.Second), WithMinimumPacketCount(1))
+		push(jb, 0)
+		_, err := jb.Pop()
+		assert.NoError(t, err)
+	})
+}
+
+func TestJitterBufferGaps(t *testing.T) {
+	push := func(jb *JitterBuffer, seq uint16) {
+		jb.Push(&rtp.Packet{Header: rtp.Header{SequenceNumber: seq, Timestamp: uint32(seq) * 160}})
+	}
+
+	t.Run("Skips missing packets after the gap wait", func(t *testing.T) {
+		now := time.Unix(0, 0)
+		jb := New(WithMinimumPacketCount(1), WithMaxGapWait(50*time.Millisecond))
+		jb.now = func() time.Time { return now }
+
+		push(jb, 0)
+		push(jb, 2)
+		packet, err := jb.Pop()
+		require.NoError(t, err)
+		assert.Equal(t, uint16(0), packet.SequenceNumber)
+
+		_, err = jb.Pop()
+		assert.ErrorIs(t, err, ErrPopWhileBuffering)
+		now = now.Add(50 * time.Millisecond)
+		packet, err = jb.Pop()
+		require.NoError(t, err)
+		assert.Equal(t, uint16(2), packet.SequenceNumber)
+		assert.Zero(t, jb.stats.UnderflowCount)
+	})
+
+	t.Run("Waits for missing packets with adaptive delay", func(t *testing.T) {
+		now := time.Unix(0, 0)
+		jb := New(WithClockRate(8000), WithAdaptiveDelay(0, time.Second), WithMaxGapWait(30*time.Millisecond))
+		jb.now = func() time.Time { return now }
+
+		push(jb, 0)
+		_, err := jb.Pop()
+		require.NoError(t, err)
+
+		now = now.Add(40 * time.Millisecond)
+		push(jb, 2)
+		_, err = jb.Pop()
+		assert.ErrorIs(t, err, ErrPopWhileBuffering)
+
+		now = now.Add(30 * time.Millisecond)
+		packet, err := jb.Pop()
+		require.NoError(t, err)
+		assert.Equal(t, uint16(2), packet.SequenceNumber)
+	})
+
+	t.Run("Discards late and duplicate packets", func(t *testing.T) {
+		jb := New(WithMinimumPacketCount(1), WithMaxGapWait(0))
+		var events []Event
+		listener := func(event Event, _ *JitterBuffer) {
+			events = append(events, event)
+		}
+		jb.Listen(LatePacket, listener)
+		jb.Listen(DiscardedPacket, listener)
+
+		push(jb, 0)
+		push(jb, 2)
+		push(jb, 2)
+		assert.Equal(t, []Event{DiscardedPacket}, events)
+
+		for i := 0; i < 2; i++ {
+			_, err := jb.Pop()
+			require.NoError(t, err)
+		}
+		push(jb, 1)
+		assert.Equal(t, []Event{DiscardedPacket, LatePacket, DiscardedPacket}, events)
+		assert.Equal(t, uint32(1), jb.stats.LateCount)
+		assert.Equal(t, uint32(2), jb.stats.DiscardedCount)
+		assert.Zero(t, jb.packets.Length())
+	})
+}
+
+func TestJitterBufferStats(t *testing.T) {
+	jb := New(WithClockRate(8000), WithMinimumPacketCount(3))
+
+	var dropped []Stats
+	jb.Listen(DiscardedPacket, func(_ Event, jb *JitterBuffer) {
+		// Listeners are called without the buffer locked
+		dropped = append(dropped, jb.Stats())
+	})
+	var resets int
+	jb.Listen(BufferReset, func(Event, *JitterBuffer) {
+		resets++
+	})
+
+	for _, seq := range []uint16{10, 12, 11, 12} {
+		jb.Push(&rtp.Packet{Header: rtp.Header{SequenceNumber: seq, Timestamp: uint32(seq) * 160}})
+	}
+
+	require.Len(t, dropped, 1)
+	assert.Equal(t, uint32(1), dropped[0].DuplicateCount)
+	assert.Equal(t, uint32(1), dropped[0].DiscardedCount)
+
+	stats := jb.Stats()
+	assert.Equal(t, Stats{
+		OutOfOrderCount: 2,
+		DuplicateCount:  1,
+		DiscardedCount:  1,
+		Depth:           3,
+		DepthDuration:   40 * time.Millisecond,
+		State:           Emitting,
+	}, stats)
+
+	_, err := jb.PopAtSequence(20)
+	assert.Error(t, err)
+	assert.Equal(t, uint32(1), jb.Stats().UnderflowCount)
+
+	jb.Clear(true)
+	assert.Equal(t, 1, resets)
+	assert.Equal(t, Stats{}, jb.Stats())
+
+	adaptive := New(WithClockRate(8000), WithAdaptiveDelay(30*time.Millisecond, time.Second))
+	assert.Equal(t, 30*time.Millisecond, adaptive.Stats().TargetDelay)
+}
@@ -27,3 +27,25 @@ func WithLoggerFactory(loggerFactory logging.LoggerFactory) ReceiverInterceptorO
 		return nil
 	}
 }
+
+// WithJitterBufferOptions sets the options of the JitterBuffer created for each remote stream.
+func WithJitterBufferOptions(opts ...Option) ReceiverInterceptorOption {
+	return func(d *ReceiverInterceptor) error {
+		d.bufferOpts = opts
+
+		return nil
+	}
+}
+
+// WithScheduledRelease reads the packets of each remote stream in the background and
+// releases them from the JitterBuffer when they are due, instead of returning
+// ErrPopWhileBuffering from Read while buffering. Read blocks until the next packet is
+// released. It is meant to be combined with the WithAdaptiveDelay and WithMaxGapWait
+// JitterBuffer options.
+func WithScheduledRelease() ReceiverInterceptorOption {
+	return func(d *ReceiverInterceptor) error {
+		d.scheduled = true
+
+		return nil
+	}
+}
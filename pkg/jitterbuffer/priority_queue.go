@@ -62,6 +62,23 @@ func (q *PriorityQueue) Find(sqNum uint16) (*rtp.Packet, error) {
 	return nil, ErrNotFound
 }
 
+// findNext returns the packet with the provided sequence number, or else the packet
+// following it most closely in sequence number order, wrapping at MaxUint16.
+func (q *PriorityQueue) findNext(sqNum uint16) (*rtp.Packet, error) {
+	var found *rtp.Packet
+	for next := q.next; next != nil; next = next.next {
+		distance := next.priority - sqNum
+		if distance < 1<<15 && (found == nil || distance < found.SequenceNumber-sqNum) {
+			found = next.val
+		}
+	}
+	if found == nil {
+		return nil, ErrNotFound
+	}
+
+	return found, nil
+}
+
 // Push will insert a packet in to the queue in order of sequence number.
 func (q *PriorityQueue) Push(val *rtp.Packet, priority uint16) {
 	newPq := newNode(val, priority)
@@ -195,6 +212,7 @@ func (q *PriorityQueue) PopAtTimestamp(timestamp uint32) (*rtp.Packet, error) {
 // Clear will empty a PriorityQueue.
 func (q *PriorityQueue) Clear() {
 	next := q.next
+	q.next = nil
 	q.length = 0
 	for next != nil {
 		next.prev = nil
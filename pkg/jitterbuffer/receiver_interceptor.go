@@ -4,6 +4,7 @@
 package jitterbuffer
 
 import (
+	"errors"
 	"sync"
 
 	"github.com/pion/interceptor"
@@ -11,16 +12,24 @@ import (
 	"github.com/pion/rtp"
 )
 
+// NewJitterBufferCallback is called with the JitterBuffer created for a remote stream of
+// the interceptor with the given ID.
+type NewJitterBufferCallback func(id string, ssrc uint32, jb *JitterBuffer)
+
 // InterceptorFactory is a interceptor.Factory for a GeneratorInterceptor.
 type InterceptorFactory struct {
-	opts []ReceiverInterceptorOption
+	opts     []ReceiverInterceptorOption
+	registry *bufferRegistry
 }
 
 // NewInterceptor constructs a new ReceiverInterceptor.
-func (g *InterceptorFactory) NewInterceptor(_ string) (interceptor.Interceptor, error) {
+func (g *InterceptorFactory) NewInterceptor(id string) (interceptor.Interceptor, error) {
 	receiverInterceptor := &ReceiverInterceptor{
-		close:  make(chan struct{}),
-		buffer: New(),
+		id:       id,
+		close:    make(chan struct{}),
+		buffers:  make(map[uint32]*JitterBuffer),
+		loops:    make(map[uint32]*releaseLoop),
+		registry: g.registry,
 	}
 
 	for _, opt := range g.opts {
@@ -39,8 +48,60 @@ func (g *InterceptorFactory) NewInterceptor(_ string) (interceptor.Interceptor,
 	return receiverInterceptor, nil
 }
 
-// ReceiverInterceptor places a JitterBuffer in the chain to smooth packet arrival
-// and allow for network jitter
+// OnNewJitterBuffer sets a callback that is called when a JitterBuffer is created for a
+// remote stream, before any packet is pushed. It can be used to register event listeners.
+func (g *InterceptorFactory) OnNewJitterBuffer(cb NewJitterBufferCallback) {
+	g.registry.mu.Lock()
+	defer g.registry.mu.Unlock()
+	g.registry.onNewBuffer = cb
+}
+
+// JitterBuffer returns the JitterBuffer of the remote stream with the given SSRC, bound
+// to the interceptor with the given ID. Different PeerConnections may receive the same
+// SSRC, so the ID of the interceptor is part of the key.
+func (g *InterceptorFactory) JitterBuffer(id string, ssrc uint32) (*JitterBuffer, bool) {
+	g.registry.mu.Lock()
+	defer g.registry.mu.Unlock()
+	jb, ok := g.registry.buffers[bufferKey{id: id, ssrc: ssrc}]
+
+	return jb, ok
+}
+
+// bufferKey identifies a remote stream of an interceptor created by a factory.
+type bufferKey struct {
+	id   string
+	ssrc uint32
+}
+
+// bufferRegistry tracks the JitterBuffers of all interceptors created by a factory.
+type bufferRegistry struct {
+	mu          sync.Mutex
+	buffers     map[bufferKey]*JitterBuffer
+	onNewBuffer NewJitterBufferCallback
+}
+
+func (r *bufferRegistry) add(id string, ssrc uint32, jb *JitterBuffer) {
+	r.mu.Lock()
+	r.buffers[bufferKey{id: id, ssrc: ssrc}] = jb
+	onNewBuffer := r.onNewBuffer
+	r.mu.Unlock()
+
+	if onNewBuffer != nil {
+		onNewBuffer(id, ssrc, jb)
+	}
+}
+
+func (r *bufferRegistry) remove(id string, ssrc uint32, jb *JitterBuffer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := bufferKey{id: id, ssrc: ssrc}
+	if r.buffers[key] == jb {
+		delete(r.buffers, key)
+	}
+}
+
+// ReceiverInterceptor places a JitterBuffer per remote stream in the chain to smooth
+// packet arrival and allow for network jitter
 //
 //	The Interceptor is designed to fit in a RemoteStream
 //	pipeline and buffer incoming packets for a short period (currently
@@ -56,11 +117,21 @@ func (g *InterceptorFactory) NewInterceptor(_ string) (interceptor.Interceptor,
 //	returned in the case that the initial buffering was sufficient and
 //	playback began but the caller is consuming packets (or they are not
 //	arriving) quickly enough.
+//
+//	With WithScheduledRelease, packets are instead read in the background and
+//	released when due, and Read blocks until the next packet is released.
+//
+//	Each remote stream has its own JitterBuffer, keyed by SSRC, which can be
+//	looked up with JitterBuffer or InterceptorFactory.JitterBuffer.
 type ReceiverInterceptor struct {
 	interceptor.NoOp
-	buffer        *JitterBuffer
+	id            string
+	buffers       map[uint32]*JitterBuffer
+	bufferOpts    []Option
+	scheduled     bool
+	loops         map[uint32]*releaseLoop
+	registry      *bufferRegistry
 	m             sync.Mutex
-	wg            sync.WaitGroup
 	close         chan struct{}
 	log           logging.LeveledLogger
 	loggerFactory logging.LoggerFactory
@@ -68,14 +139,33 @@ type ReceiverInterceptor struct {
 
 // NewInterceptor returns a new InterceptorFactory.
 func NewInterceptor(opts ...ReceiverInterceptorOption) (*InterceptorFactory, error) {
-	return &InterceptorFactory{opts}, nil
+	return &InterceptorFactory{
+		opts:     opts,
+		registry: &bufferRegistry{buffers: make(map[bufferKey]*JitterBuffer)},
+	}, nil
 }
 
 // BindRemoteStream lets you modify any incoming RTP packets. It is called once for per RemoteStream.
 // The returned method will be called once per rtp packet.
 func (i *ReceiverInterceptor) BindRemoteStream(
-	_ *interceptor.StreamInfo, reader interceptor.RTPReader,
+	info *interceptor.StreamInfo, reader interceptor.RTPReader,
 ) interceptor.RTPReader {
+	buffer := New(append([]Option{WithClockRate(info.ClockRate)}, i.bufferOpts...)...)
+	i.m.Lock()
+	i.buffers[info.SSRC] = buffer
+	i.m.Unlock()
+	i.registry.add(i.id, info.SSRC, buffer)
+
+	if i.scheduled {
+		loop := newReleaseLoop(buffer, reader, defaultReleaseInterval, i.log)
+		i.m.Lock()
+		i.loops[info.SSRC] = loop
+		i.m.Unlock()
+		loop.start(loop.run)
+
+		return loop
+	}
+
 	return interceptor.RTPReaderFunc(func(b []byte, a interceptor.Attributes) (int, interceptor.Attributes, error) {
 		buf := make([]byte, len(b))
 		n, attr, err := reader.Read(buf, a)
@@ -86,37 +176,67 @@ func (i *ReceiverInterceptor) BindRemoteStream(
 		if err := packet.Unmarshal(buf); err != nil {
 			return 0, nil, err
 		}
-		i.m.Lock()
-		defer i.m.Unlock()
-		i.buffer.Push(packet)
-		if i.buffer.state == Emitting {
-			newPkt, err := i.buffer.Pop()
-			if err != nil {
-				return 0, nil, err
-			}
-			nlen, err := newPkt.MarshalTo(b)
-
-			return nlen, attr, err
+		buffer.Push(packet)
+		newPkt, err := buffer.Pop()
+		if errors.Is(err, ErrPopWhileBuffering) {
+			return n, attr, err
 		}
+		if err != nil {
+			return 0, nil, err
+		}
+		nlen, err := newPkt.MarshalTo(b)
 
-		return n, attr, ErrPopWhileBuffering
+		return nlen, attr, err
 	})
 }
 
 // UnbindRemoteStream is called when the Stream is removed. It can be used to clean up any data related to that track.
-func (i *ReceiverInterceptor) UnbindRemoteStream(_ *interceptor.StreamInfo) {
-	defer i.wg.Wait()
+func (i *ReceiverInterceptor) UnbindRemoteStream(info *interceptor.StreamInfo) {
 	i.m.Lock()
-	defer i.m.Unlock()
-	i.buffer.Clear(true)
+	loop, ok := i.loops[info.SSRC]
+	if ok {
+		loop.close()
+		delete(i.loops, info.SSRC)
+	}
+	if buffer, ok := i.buffers[info.SSRC]; ok {
+		buffer.Clear(true)
+		i.registry.remove(i.id, info.SSRC, buffer)
+		delete(i.buffers, info.SSRC)
+	}
+	i.m.Unlock()
+
+	if ok {
+		loop.wait()
+	}
 }
 
 // Close closes the interceptor.
 func (i *ReceiverInterceptor) Close() error {
-	defer i.wg.Wait()
 	i.m.Lock()
-	defer i.m.Unlock()
-	i.buffer.Clear(true)
+	for ssrc, buffer := range i.buffers {
+		buffer.Clear(true)
+		i.registry.remove(i.id, ssrc, buffer)
+	}
+	i.buffers = make(map[uint32]*JitterBuffer)
+	loops := i.loops
+	for _, loop := range loops {
+		loop.close()
+	}
+	i.loops = make(map[uint32]*releaseLoop)
+	i.m.Unlock()
+
+	for _, loop := range loops {
+		loop.wait()
+	}
 
 	return nil
 }
+
+// JitterBuffer returns the JitterBuffer of the remote stream with the given SSRC.
+func (i *ReceiverInterceptor) JitterBuffer(ssrc uint32) (*JitterBuffer, bool) {
+	i.m.Lock()
+	defer i.m.Unlock()
+	buffer, ok := i.buffers[ssrc]
+
+	return buffer, ok
+}
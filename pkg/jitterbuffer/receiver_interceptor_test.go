@@ -5,6 +5,8 @@ package jitterbuffer
 
 import (
 	"bytes"
+	"io"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -96,3 +98,308 @@ func TestReceiverBuffersAndPlaysout(t *testing.T) {
 	err = testInterceptor.Close()
 	assert.NoError(t, err)
 }
+
+func TestReceiverPerStreamBuffers(t *testing.T) {
+	factory, err := NewInterceptor(WithJitterBufferOptions(WithMinimumPacketCount(5)))
+	assert.NoError(t, err)
+
+	var created []uint32
+	factory.OnNewJitterBuffer(func(id string, ssrc uint32, jb *JitterBuffer) {
+		assert.Equal(t, "pc", id)
+		assert.NotNil(t, jb)
+		created = append(created, ssrc)
+	})
+
+	testInterceptor, err := factory.NewInterceptor("pc")
+	assert.NoError(t, err)
+
+	streamA := test.NewMockStream(&interceptor.StreamInfo{SSRC: 1, ClockRate: 90000}, testInterceptor)
+	streamB := test.NewMockStream(&interceptor.StreamInfo{SSRC: 2, ClockRate: 90000}, testInterceptor)
+	assert.ElementsMatch(t, []uint32{1, 2}, created)
+
+	bufferA, ok := factory.JitterBuffer("pc", 1)
+	assert.True(t, ok)
+	bufferB, ok := factory.JitterBuffer("pc", 2)
+	assert.True(t, ok)
+	assert.NotSame(t, bufferA, bufferB)
+
+	receiver, ok := testInterceptor.(*ReceiverInterceptor)
+	assert.True(t, ok)
+	receiverBuffer, ok := receiver.JitterBuffer(1)
+	assert.True(t, ok)
+	assert.Same(t, bufferA, receiverBuffer)
+
+	// Only stream A receives enough packets to start playout
+	for s := 0; s < 5; s++ {
+		streamA.ReceiveRTP(&rtp.Packet{Header: rtp.Header{SSRC: 1, SequenceNumber: uint16(1000 + s)}}) //nolint:gosec // G115
+	}
+	for s := 0; s < 3; s++ {
+		streamB.ReceiveRTP(&rtp.Packet{Header: rtp.Header{SSRC: 2, SequenceNumber: uint16(s)}}) //nolint:gosec // G115
+	}
+
+	read := <-streamA.ReadRTP()
+	assert.NoError(t, read.Err)
+	assert.EqualValues(t, 1000, read.Packet.SequenceNumber)
+
+	time.Sleep(50 * time.Millisecond)
+	select {
+	case read := <-streamB.ReadRTP():
+		assert.ErrorIs(t, read.Err, ErrPopWhileBuffering)
+	default:
+	}
+	assert.Equal(t, Emitting, bufferA.state)
+	assert.Equal(t, Buffering, bufferB.state)
+
+	// Unbinding a stream removes its buffer only
+	testInterceptor.UnbindRemoteStream(&interceptor.StreamInfo{SSRC: 2})
+	_, ok = factory.JitterBuffer("pc", 2)
+	assert.False(t, ok)
+	_, ok = factory.JitterBuffer("pc", 1)
+	assert.True(t, ok)
+
+	assert.NoError(t, streamA.Close())
+	assert.NoError(t, streamB.Close())
+	_, ok = factory.JitterBuffer("pc", 1)
+	assert.False(t, ok)
+}
+
+func TestReceiverBuffersPerInterceptor(t *testing.T) {
+	factory, err := NewInterceptor()
+	assert.NoError(t, err)
+
+	interceptorA, err := factory.NewInterceptor("a")
+	assert.NoError(t, err)
+	interceptorB, err := factory.NewInterceptor("b")
+	assert.NoError(t, err)
+
+	// Two PeerConnections may receive the same SSRC
+	streamA := test.NewMockStream(&interceptor.StreamInfo{SSRC: 1, ClockRate: 90000}, interceptorA)
+	streamB := test.NewMockStream(&interceptor.StreamInfo{SSRC: 1, ClockRate: 90000}, interceptorB)
+
+	bufferA, ok := factory.JitterBuffer("a", 1)
+	assert.True(t, ok)
+	bufferB, ok := factory.JitterBuffer("b", 1)
+	assert.True(t, ok)
+	assert.NotSame(t, bufferA, bufferB)
+
+	// Closing one keeps the buffer of the other
+	assert.NoError(t, streamA.Close())
+	_, ok = factory.JitterBuffer("a", 1)
+	assert.False(t, ok)
+	buffer, ok := factory.JitterBuffer("b", 1)
+	assert.True(t, ok)
+	assert.Same(t, bufferB, buffer)
+
+	assert.NoError(t, streamB.Close())
+}
+
+func TestReceiverScheduledRelease(t *testing.T) {
+	factory, err := NewInterceptor(
+		WithScheduledRelease(),
+		WithJitterBufferOptions(WithAdaptiveDelay(20*time.Millisecond, 100*time.Millisecond)),
+	)
+	assert.NoError(t, err)
+
+	testInterceptor, err := factory.NewInterceptor("")
+	assert.NoError(t, err)
+
+	stream := test.NewMockStream(&interceptor.StreamInfo{SSRC: 1, ClockRate: 90000}, testInterceptor)
+	defer func() {
+		assert.NoError(t, stream.Close())
+	}()
+
+	// Packet 2 is lost, the others are released in order without ErrPopWhileBuffering
+	for _, seq := range []uint16{0, 1, 3} {
+		stream.ReceiveRTP(&rtp.Packet{Header: rtp.Header{SSRC: 1, SequenceNumber: seq, Timestamp: uint32(seq) * 3000}})
+	}
+	for _, seq := range []uint16{0, 1, 3} {
+		select {
+		case read := <-stream.ReadRTP():
+			assert.NoError(t, read.Err)
+			assert.Equal(t, seq, read.Packet.SequenceNumber)
+		case <-time.After(time.Second):
+			assert.FailNow(t, "packet not released")
+		}
+	}
+
+	buffer, ok := factory.JitterBuffer("", 1)
+	assert.True(t, ok)
+	stream.ReceiveRTP(&rtp.Packet{Header: rtp.Header{SSRC: 1, SequenceNumber: 2, Timestamp: 6000}})
+	assert.Eventually(t, func() bool {
+		buffer.mutex.Lock()
+		defer buffer.mutex.Unlock()
+
+		return buffer.stats.LateCount == 1
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestReceiverScheduledReleaseAttributes(t *testing.T) {
+	factory, err := NewInterceptor(WithScheduledRelease(), WithJitterBufferOptions(WithMinimumPacketCount(1)))
+	assert.NoError(t, err)
+	testInterceptor, err := factory.NewInterceptor("")
+	assert.NoError(t, err)
+
+	packets := make(chan *rtp.Packet, 1)
+	reader := testInterceptor.BindRemoteStream(
+		&interceptor.StreamInfo{SSRC: 1, ClockRate: 90000},
+		interceptor.RTPReaderFunc(func(b []byte, _ interceptor.Attributes) (int, interceptor.Attributes, error) {
+			pkt, ok := <-packets
+			if !ok {
+				return 0, nil, io.EOF
+			}
+			n, err := pkt.MarshalTo(b)
+
+			return n, interceptor.Attributes{"seq": pkt.SequenceNumber}, err
+		}),
+	)
+
+	packets <- &rtp.Packet{Header: rtp.Header{SSRC: 1, SequenceNumber: 7}}
+	buf := make([]byte, 1500)
+	_, attr, err := reader.Read(buf, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(7), attr.Get("seq"))
+
+	close(packets)
+	assert.NoError(t, testInterceptor.Close())
+}
+
+func TestReceiverScheduledReleaseClose(t *testing.T) {
+	factory, err := NewInterceptor(WithScheduledRelease())
+	assert.NoError(t, err)
+	testInterceptor, err := factory.NewInterceptor("")
+	assert.NoError(t, err)
+
+	// The remote stream never runs out of packets
+	var reads atomic.Int64
+	reader := testInterceptor.BindRemoteStream(
+		&interceptor.StreamInfo{SSRC: 1, ClockRate: 90000},
+		interceptor.RTPReaderFunc(func(b []byte, _ interceptor.Attributes) (int, interceptor.Attributes, error) {
+			seq := reads.Add(1)
+			pkt := &rtp.Packet{Header: rtp.Header{SSRC: 1, SequenceNumber: uint16(seq)}} //nolint:gosec // G115
+			n, err := pkt.MarshalTo(b)
+
+			return n, nil, err
+		}),
+	)
+	_, _, err = reader.Read(make([]byte, 1500), nil)
+	assert.NoError(t, err)
+
+	closed := make(chan error)
+	go func() {
+		closed <- testInterceptor.Close()
+	}()
+	select {
+	case err := <-closed:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		assert.FailNow(t, "close did not return")
+	}
+
+	// Close waited for the reading goroutine, which does not read anymore
+	count := reads.Load()
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, count, reads.Load())
+
+	_, _, err = reader.Read(make([]byte, 1500), nil)
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestReceiverScheduledReleaseDiscardedAttributes(t *testing.T) {
+	factory, err := NewInterceptor(WithScheduledRelease(), WithJitterBufferOptions(WithMinimumPacketCount(2)))
+	assert.NoError(t, err)
+	testInterceptor, err := factory.NewInterceptor("")
+	assert.NoError(t, err)
+
+	type read struct {
+		seq  uint16
+		attr string
+	}
+	reads := make(chan read, 3)
+	reader := testInterceptor.BindRemoteStream(
+		&interceptor.StreamInfo{SSRC: 1, ClockRate: 90000},
+		interceptor.RTPReaderFunc(func(b []byte, _ interceptor.Attributes) (int, interceptor.Attributes, error) {
+			r, ok := <-reads
+			if !ok {
+				return 0, nil, io.EOF
+			}
+			n, err := (&rtp.Packet{Header: rtp.Header{SSRC: 1, SequenceNumber: r.seq}}).MarshalTo(b)
+
+			return n, interceptor.Attributes{"attr": r.attr}, err
+		}),
+	)
+
+	// The duplicate is discarded, and does not replace the attributes of the buffered packet
+	reads <- read{7, "first"}
+	reads <- read{7, "duplicate"}
+	reads <- read{8, "second"}
+	buf := make([]byte, 1500)
+	for _, expected := range []string{"first", "second"} {
+		_, attr, err := reader.Read(buf, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, attr.Get("attr"))
+	}
+	loop, ok := reader.(*releaseLoop)
+	assert.True(t, ok)
+	loop.attributesLock.Lock()
+	assert.Empty(t, loop.attributes)
+	loop.attributesLock.Unlock()
+
+	close(reads)
+	assert.NoError(t, testInterceptor.Close())
+}
+
+func TestReceiverScheduledReleaseUnbindOneStream(t *testing.T) {
+	factory, err := NewInterceptor(WithScheduledRelease(), WithJitterBufferOptions(WithMinimumPacketCount(1)))
+	assert.NoError(t, err)
+	testInterceptor, err := factory.NewInterceptor("")
+	assert.NoError(t, err)
+
+	bind := func(ssrc uint32) (chan *rtp.Packet, interceptor.RTPReader) {
+		packets := make(chan *rtp.Packet, 1)
+		reader := testInterceptor.BindRemoteStream(
+			&interceptor.StreamInfo{SSRC: ssrc, ClockRate: 90000},
+			interceptor.RTPReaderFunc(func(b []byte, _ interceptor.Attributes) (int, interceptor.Attributes, error) {
+				pkt, ok := <-packets
+				if !ok {
+					return 0, nil, io.EOF
+				}
+				n, err := pkt.MarshalTo(b)
+
+				return n, nil, err
+			}),
+		)
+
+		return packets, reader
+	}
+	packetsA, readerA := bind(1)
+	packetsB, readerB := bind(2)
+
+	// Both streams are read, stream B keeps waiting for its next packet
+	buf := make([]byte, 1500)
+	packetsA <- &rtp.Packet{Header: rtp.Header{SSRC: 1}}
+	_, _, err = readerA.Read(buf, nil)
+	assert.NoError(t, err)
+	packetsB <- &rtp.Packet{Header: rtp.Header{SSRC: 2}}
+	_, _, err = readerB.Read(buf, nil)
+	assert.NoError(t, err)
+
+	// Unbinding stream A waits for its reader only
+	close(packetsA)
+	unbound := make(chan struct{})
+	go func() {
+		testInterceptor.UnbindRemoteStream(&interceptor.StreamInfo{SSRC: 1})
+		close(unbound)
+	}()
+	select {
+	case <-unbound:
+	case <-time.After(time.Second):
+		assert.FailNow(t, "unbind waited for the other stream")
+	}
+
+	packetsB <- &rtp.Packet{Header: rtp.Header{SSRC: 2, SequenceNumber: 1}}
+	_, _, err = readerB.Read(buf, nil)
+	assert.NoError(t, err)
+
+	close(packetsB)
+	assert.NoError(t, testInterceptor.Close())
+}
@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package jitterbuffer
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/logging"
+	"github.com/pion/rtp"
+)
+
+// defaultReleaseInterval is how often buffered packets are checked for release.
+const defaultReleaseInterval = 5 * time.Millisecond
+
+// releaseLoop reads the packets of a remote stream in the background, and releases them
+// from the JitterBuffer into a channel when they are due.
+type releaseLoop struct {
+	buffer   *JitterBuffer
+	reader   interceptor.RTPReader
+	interval time.Duration
+	log      logging.LeveledLogger
+	// wg tracks the goroutines of the loop.
+	wg sync.WaitGroup
+
+	released chan *rtp.Packet
+	// pushed wakes up the release goroutine when a packet was pushed.
+	pushed chan struct{}
+
+	// attributes holds the attributes read with the buffered packets by sequence number.
+	// The lock is held while a packet is pushed, so that its attributes are stored before
+	// it is released.
+	attributesLock sync.Mutex
+	attributes     map[uint16]interceptor.Attributes
+
+	readOnce sync.Once
+	readDone chan struct{}
+	readErr  error
+
+	closeLock sync.Mutex
+	closed    bool
+	done      chan struct{}
+}
+
+func newReleaseLoop(
+	buffer *JitterBuffer, reader interceptor.RTPReader, interval time.Duration, log logging.LeveledLogger,
+) *releaseLoop {
+	return &releaseLoop{
+		buffer:     buffer,
+		reader:     reader,
+		interval:   interval,
+		log:        log,
+		released:   make(chan *rtp.Packet),
+		pushed:     make(chan struct{}, 1),
+		attributes: map[uint16]interceptor.Attributes{},
+		readDone:   make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+}
+
+// start runs f in a goroutine tracked by the WaitGroup of the loop, unless the loop is
+// closed.
+func (l *releaseLoop) start(f func()) {
+	l.closeLock.Lock()
+	defer l.closeLock.Unlock()
+	if l.closed {
+		return
+	}
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+		f()
+	}()
+}
+
+// Read blocks until the next packet is released. Reading from the remote stream
+// starts with the first call.
+func (l *releaseLoop) Read(b []byte, a interceptor.Attributes) (int, interceptor.Attributes, error) {
+	l.readOnce.Do(func() {
+		size := len(b)
+		l.start(func() {
+			l.readPackets(size)
+		})
+	})
+
+	var packet *rtp.Packet
+	select {
+	case packet = <-l.released:
+	case <-l.readDone:
+		return 0, nil, l.readErr
+	case <-l.done:
+		return 0, nil, io.EOF
+	}
+
+	l.attributesLock.Lock()
+	attr, ok := l.attributes[packet.SequenceNumber]
+	delete(l.attributes, packet.SequenceNumber)
+	l.attributesLock.Unlock()
+	if !ok || attr == nil {
+		attr = a
+	}
+	if attr == nil {
+		attr = interceptor.Attributes{}
+	}
+	n, err := packet.MarshalTo(b)
+
+	return n, attr, err
+}
+
+// readPackets pushes the packets read from the remote stream into the JitterBuffer,
+// until reading fails or the loop is closed.
+func (l *releaseLoop) readPackets(size int) {
+	for {
+		buf := make([]byte, size)
+		n, attr, err := l.reader.Read(buf, interceptor.Attributes{})
+		select {
+		case <-l.done:
+			return
+		default:
+		}
+		if err != nil {
+			l.readErr = err
+			close(l.readDone)
+
+			return
+		}
+
+		packet := &rtp.Packet{}
+		if err := packet.Unmarshal(buf[:n]); err != nil {
+			l.log.Warnf("failed to unmarshal RTP packet: %v", err)
+
+			continue
+		}
+		l.attributesLock.Lock()
+		if l.buffer.push(packet) {
+			l.attributes[packet.SequenceNumber] = attr
+		}
+		l.attributesLock.Unlock()
+
+		select {
+		case l.pushed <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// run releases due packets until the loop is closed.
+func (l *releaseLoop) run() {
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			packet, err := l.buffer.popBuffered()
+			if err != nil {
+				break
+			}
+			select {
+			case l.released <- packet:
+			case <-l.done:
+				return
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-l.pushed:
+		case <-l.done:
+			return
+		}
+	}
+}
+
+// close stops the loop. The goroutines of the loop exit once a pending read of the
+// remote stream returns.
+func (l *releaseLoop) close() {
+	l.closeLock.Lock()
+	defer l.closeLock.Unlock()
+	if !l.closed {
+		l.closed = true
+		close(l.done)
+	}
+}
+
+// wait blocks until the goroutines of the loop exited.
+func (l *releaseLoop) wait() {
+	l.wg.Wait()
+}
@@ -8,10 +8,12 @@ import (
 	"errors"
 	"log/slog"
 	"maps"
+	"math"
 	"sync"
 	"time"
 
 	"github.com/pion/interceptor"
+	"github.com/pion/interceptor/internal/padding"
 	"github.com/pion/logging"
 	"github.com/pion/rtp"
 )
@@ -52,6 +54,31 @@ func Interval(interval time.Duration) Option {
 	}
 }
 
+// MaxQueueTime configures how long video, FEC and padding packets may wait in the
+// queue. Older packets are dropped instead of being sent late. Audio packets and
+// retransmissions are never dropped. Packets are dropped one by one, so the receiver
+// may have to request the rest of a partially dropped frame. By default, and with a
+// zero duration, no packets are dropped.
+func MaxQueueTime(d time.Duration) Option {
+	return func(i *Interceptor) error {
+		i.maxQueueTime = d
+
+		return nil
+	}
+}
+
+// PaddingRate configures the rate in bits per second up to which the interceptor sends
+// padding when the local streams send less. Padding retransmits recently sent packets on
+// the RTX SSRC of a stream, or is sent in padding-only packets on the RTX SSRC if there
+// are no recent packets. Streams without an RTX SSRC do not carry padding.
+func PaddingRate(rate int) Option {
+	return func(i *Interceptor) error {
+		i.paddingBudget.SetRate(rate)
+
+		return nil
+	}
+}
+
 // WithLoggerFactory sets a logger factory for the interceptor.
 func WithLoggerFactory(loggerFactory logging.LoggerFactory) Option {
 	return func(i *Interceptor) error {
@@ -98,6 +125,33 @@ func (f *InterceptorFactory) SetRate(id string, r int) {
 	i.setRate(r)
 }
 
+// SetPaddingRate updates the padding rate of the pacing interceptor with the given ID.
+func (f *InterceptorFactory) SetPaddingRate(id string, r int) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	i, ok := f.interceptors[id]
+	if !ok {
+		return
+	}
+	i.paddingBudget.SetRate(r)
+}
+
+// SetWeight updates the weight of the stream with the given SSRC in the pacing
+// interceptor with the given ID. Streams of the same priority class share the pacing
+// rate in proportion to their weights. The default weight is 1, and a weight that is not
+// positive and finite restores it. Weights below 0.01 are raised to 0.01.
+func (f *InterceptorFactory) SetWeight(id string, ssrc uint32, weight float64) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	i, ok := f.interceptors[id]
+	if !ok {
+		return
+	}
+	i.setWeight(ssrc, weight)
+}
+
 func (f *InterceptorFactory) remove(id string) {
 	f.lock.Lock()
 	defer f.lock.Unlock()
@@ -110,19 +164,24 @@ func (f *InterceptorFactory) NewInterceptor(id string) (interceptor.Interceptor,
 	defer f.lock.Unlock()
 
 	interceptor := &Interceptor{
-		NoOp:        interceptor.NoOp{},
-		initialRate: 1_000_000,
-		interval:    5 * time.Millisecond,
-		queueSize:   1_000_000,
+		NoOp:         interceptor.NoOp{},
+		initialRate:  1_000_000,
+		interval:     5 * time.Millisecond,
+		queueSize:    1_000_000,
+		maxQueueTime: 0,
 		pacerFactory: func(initialRate, burst int) pacer {
 			return newRateLimitPacer(initialRate, burst)
 		},
-		limit:   nil,
-		queue:   nil,
-		closed:  make(chan struct{}),
-		wg:      sync.WaitGroup{},
-		id:      id,
-		onClose: f.remove,
+		limit:          nil,
+		queue:          nil,
+		weights:        map[uint32]float64{},
+		padding:        padding.NewGenerator(),
+		paddingBudget:  &padding.Budget{},
+		paddingWriters: map[uint32]interceptor.RTPWriter{},
+		closed:         make(chan struct{}),
+		wg:             sync.WaitGroup{},
+		id:             id,
+		onClose:        f.remove,
 	}
 	for _, opt := range f.opts {
 		if err := opt(interceptor); err != nil {
@@ -152,6 +211,10 @@ func (f *InterceptorFactory) NewInterceptor(id string) (interceptor.Interceptor,
 
 // Interceptor implements packet pacing using a token bucket filter and sends
 // packets at a fixed interval.
+//
+// Packets are queued per stream in priority classes. Audio is sent first, then
+// retransmissions, video, FEC and padding. Within a class, streams are served in
+// weighted round robin, see InterceptorFactory.SetWeight.
 type Interceptor struct {
 	interceptor.NoOp
 	log           logging.LeveledLogger
@@ -161,12 +224,22 @@ type Interceptor struct {
 	initialRate  int
 	interval     time.Duration
 	queueSize    int
+	maxQueueTime time.Duration
 	pacerFactory pacerFactory
 
 	// limiter and queue
 	limit pacer
 	queue chan packet
 
+	weightsLock sync.Mutex
+	weights     map[uint32]float64
+
+	// padding
+	padding            *padding.Generator
+	paddingBudget      *padding.Budget
+	paddingWritersLock sync.Mutex
+	paddingWriters     map[uint32]interceptor.RTPWriter
+
 	// shutdown
 	closed  chan struct{}
 	wg      sync.WaitGroup
@@ -190,11 +263,43 @@ func (i *Interceptor) setRate(r int) {
 	i.limit.SetRate(r, burst(r, i.interval))
 }
 
+// setWeight updates the round robin weight of a stream.
+func (i *Interceptor) setWeight(ssrc uint32, weight float64) {
+	i.weightsLock.Lock()
+	defer i.weightsLock.Unlock()
+
+	if weight <= 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+		delete(i.weights, ssrc)
+
+		return
+	}
+	i.weights[ssrc] = max(weight, minWeight)
+}
+
+// weight returns the round robin weight of a stream.
+func (i *Interceptor) weight(ssrc uint32) float64 {
+	i.weightsLock.Lock()
+	defer i.weightsLock.Unlock()
+
+	if weight, ok := i.weights[ssrc]; ok {
+		return weight
+	}
+
+	return 1
+}
+
 // BindLocalStream implements interceptor.Interceptor.
 func (i *Interceptor) BindLocalStream(
 	info *interceptor.StreamInfo,
 	writer interceptor.RTPWriter,
 ) interceptor.RTPWriter {
+	i.padding.AddStream(info)
+	if info.SSRCRetransmission != 0 {
+		i.paddingWritersLock.Lock()
+		i.paddingWriters[info.SSRCRetransmission] = writer
+		i.paddingWritersLock.Unlock()
+	}
+
 	return interceptor.RTPWriterFunc(func(
 		header *rtp.Header,
 		payload []byte,
@@ -210,6 +315,9 @@ func (i *Interceptor) BindLocalStream(
 			header:     &hdr,
 			payload:    pay,
 			attributes: attr,
+			class:      classify(info, header, payload),
+			ssrc:       header.SSRC,
+			enqueued:   time.Now(),
 		}:
 		case <-i.closed:
 			return 0, errPacerClosed
@@ -221,6 +329,14 @@ func (i *Interceptor) BindLocalStream(
 	})
 }
 
+// UnbindLocalStream implements interceptor.Interceptor.
+func (i *Interceptor) UnbindLocalStream(info *interceptor.StreamInfo) {
+	i.padding.RemoveStream(info.SSRC)
+	i.paddingWritersLock.Lock()
+	delete(i.paddingWriters, info.SSRCRetransmission)
+	i.paddingWritersLock.Unlock()
+}
+
 // Close implements interceptor.Interceptor.
 func (i *Interceptor) Close() error {
 	defer i.wg.Wait()
@@ -233,33 +349,73 @@ func (i *Interceptor) Close() error {
 func (i *Interceptor) loop() {
 	ticker := time.NewTicker(i.interval)
 	defer ticker.Stop()
-	queue := make([]packet, 0)
+	queue := newPriorityQueue(i.weight)
 	for {
 		select {
 		case now := <-ticker.C:
-			for len(queue) > 0 && i.limit.Budget(now) > 8*float64(queue[0].len()) {
-				i.limit.AllowN(now, 8*queue[0].len())
-				var next packet
-				next, queue = queue[0], queue[1:]
+			if i.maxQueueTime > 0 && queue.len() > 0 {
+				for c, n := range queue.dropStale(now.Add(-i.maxQueueTime)) {
+					i.log.Warnf("dropped %v %v packets queued for more than %v", n, c, i.maxQueueTime)
+				}
+			}
+			i.paddingBudget.Update(now)
+			for next := queue.peek(); next != nil && i.limit.Budget(now) > 8*float64(next.len()); next = queue.peek() {
+				i.limit.AllowN(now, 8*next.len())
+				i.padding.OnSent(next.header, next.payload, now)
+				i.paddingBudget.Consume(next.len())
 				if _, err := next.writer.Write(next.header, next.payload, next.attributes); err != nil {
 					slog.Warn("error on writing RTP packet", "error", err)
 				}
+				queue.pop()
+			}
+			if queue.len() == 0 {
+				i.sendPadding(now)
 			}
 		case pkt := <-i.queue:
-			queue = append(queue, pkt)
+			queue.push(pkt)
 		case <-i.closed:
 			return
 		}
 	}
 }
 
+// sendPadding sends padding packets while the padding budget and the pacing budget allow.
+func (i *Interceptor) sendPadding(now time.Time) {
+	for i.paddingBudget.Available() {
+		header, payload, ok := i.padding.Next(now)
+		if !ok {
+			return
+		}
+		size := header.MarshalSize() + len(payload) + int(header.PaddingSize)
+		if i.limit.Budget(now) <= 8*float64(size) {
+			return
+		}
+		i.paddingWritersLock.Lock()
+		writer, ok := i.paddingWriters[header.SSRC]
+		i.paddingWritersLock.Unlock()
+		if !ok {
+			return
+		}
+
+		i.limit.AllowN(now, 8*size)
+		i.padding.OnSent(header, payload, now)
+		i.paddingBudget.Consume(size)
+		if _, err := writer.Write(header, payload, nil); err != nil {
+			slog.Warn("error on writing RTP padding packet", "error", err)
+		}
+	}
+}
+
 type packet struct {
 	writer     interceptor.RTPWriter
 	header     *rtp.Header
 	payload    []byte
 	attributes interceptor.Attributes
+	class      class
+	ssrc       uint32
+	enqueued   time.Time
 }
 
 func (p *packet) len() int {
-	return p.header.MarshalSize() + len(p.payload)
+	return p.header.MarshalSize() + len(p.payload) + int(p.header.PaddingSize)
 }
@@ -4,6 +4,7 @@
 package pacing
 
 import (
+	"math"
 	"sync"
 	"testing"
 	"time"
@@ -142,4 +143,170 @@ func TestInterceptor(t *testing.T) {
 		case <-time.After(10 * time.Millisecond):
 		}
 	})
+	t.Run("sends_retransmissions_before_video", func(t *testing.T) {
+		mp := &mockPacer{}
+		i := NewInterceptor(
+			setPacerFactory(func(initialRate, burst int) pacer {
+				return mp
+			}),
+			Interval(time.Millisecond),
+		)
+
+		pacer, err := i.NewInterceptor("")
+		assert.NoError(t, err)
+
+		stream := test.NewMockStream(&interceptor.StreamInfo{
+			SSRC:               1,
+			SSRCRetransmission: 2,
+			MimeType:           "video/VP8",
+		}, pacer)
+		defer func() {
+			assert.NoError(t, stream.Close())
+		}()
+
+		for seq := uint16(0); seq < 3; seq++ {
+			assert.NoError(t, stream.WriteRTP(&rtp.Packet{
+				Header:  rtp.Header{SSRC: 1, SequenceNumber: seq},
+				Payload: make([]byte, 1000),
+			}))
+		}
+		assert.NoError(t, stream.WriteRTP(&rtp.Packet{
+			Header:  rtp.Header{SSRC: 2, SequenceNumber: 10},
+			Payload: make([]byte, 1000),
+		}))
+		time.Sleep(10 * time.Millisecond)
+
+		mp.lock.Lock()
+		mp.allow = true
+		mp.budget = 8 * 10_000
+		mp.lock.Unlock()
+
+		var ssrcs []uint32
+		for len(ssrcs) < 4 {
+			select {
+			case p := <-stream.WrittenRTP():
+				ssrcs = append(ssrcs, p.SSRC)
+			case <-time.After(time.Second):
+				assert.FailNow(t, "no RTP packet written")
+			}
+		}
+		assert.Equal(t, []uint32{2, 1, 1, 1}, ssrcs)
+	})
+	t.Run("sends_padding_up_to_padding_rate", func(t *testing.T) {
+		mp := &mockPacer{allow: true, budget: 8 * 10_000}
+		i := NewInterceptor(
+			setPacerFactory(func(initialRate, burst int) pacer {
+				return mp
+			}),
+			Interval(time.Millisecond),
+			PaddingRate(1_000_000),
+		)
+
+		pacer, err := i.NewInterceptor("")
+		assert.NoError(t, err)
+
+		stream := test.NewMockStream(&interceptor.StreamInfo{
+			SSRC:                      1,
+			SSRCRetransmission:        2,
+			PayloadType:               96,
+			PayloadTypeRetransmission: 97,
+			MimeType:                  "video/VP8",
+		}, pacer)
+		defer func() {
+			assert.NoError(t, stream.Close())
+		}()
+
+		assert.NoError(t, stream.WriteRTP(&rtp.Packet{
+			Header:  rtp.Header{SSRC: 1, PayloadType: 96, SequenceNumber: 7, Timestamp: 1000},
+			Payload: []byte{1, 2, 3},
+		}))
+
+		var media, padding int
+		for media == 0 || padding < 3 {
+			select {
+			case p := <-stream.WrittenRTP():
+				if p.SSRC == 1 {
+					media++
+
+					continue
+				}
+				// The media packet is retransmitted as payload padding
+				padding++
+				assert.Equal(t, uint32(2), p.SSRC)
+				assert.Equal(t, uint8(97), p.PayloadType)
+				assert.Equal(t, uint32(1000), p.Timestamp)
+				assert.Equal(t, []byte{0, 7, 1, 2, 3}, p.Payload)
+			case <-time.After(time.Second):
+				assert.FailNow(t, "no padding written")
+			}
+		}
+		assert.Equal(t, 1, media)
+	})
+	t.Run("drops_stale_packets_only_if_configured", func(t *testing.T) {
+		for _, c := range []struct {
+			name    string
+			opts    []Option
+			written bool
+		}{
+			{"default", nil, true},
+			{"max_queue_time", []Option{MaxQueueTime(time.Millisecond)}, false},
+		} {
+			t.Run(c.name, func(t *testing.T) {
+				mp := &mockPacer{}
+				i := NewInterceptor(append([]Option{
+					setPacerFactory(func(initialRate, burst int) pacer {
+						return mp
+					}),
+					Interval(time.Millisecond),
+				}, c.opts...)...)
+
+				pacer, err := i.NewInterceptor("")
+				assert.NoError(t, err)
+
+				stream := test.NewMockStream(&interceptor.StreamInfo{SSRC: 1, MimeType: "video/VP8"}, pacer)
+				defer func() {
+					assert.NoError(t, stream.Close())
+				}()
+
+				// The packet waits for pacing budget longer than the maximum queue time
+				assert.NoError(t, stream.WriteRTP(&rtp.Packet{
+					Header:  rtp.Header{SSRC: 1},
+					Payload: make([]byte, 1000),
+				}))
+				time.Sleep(50 * time.Millisecond)
+				mp.lock.Lock()
+				mp.allow = true
+				mp.budget = 8 * 10_000
+				mp.lock.Unlock()
+
+				select {
+				case <-stream.WrittenRTP():
+					assert.True(t, c.written, "stale RTP packet written")
+				case <-time.After(30 * time.Millisecond):
+					assert.False(t, c.written, "no RTP packet written")
+				}
+			})
+		}
+	})
+	t.Run("ignores_invalid_weights", func(t *testing.T) {
+		i := NewInterceptor()
+		pacer, err := i.NewInterceptor("")
+		assert.NoError(t, err)
+		defer func() {
+			assert.NoError(t, pacer.Close())
+		}()
+		p, ok := pacer.(*Interceptor)
+		assert.True(t, ok)
+
+		i.SetWeight("", 1, math.NaN())
+		assert.Equal(t, 1.0, p.weight(1))
+		i.SetWeight("", 1, math.Inf(1))
+		assert.Equal(t, 1.0, p.weight(1))
+		i.SetWeight("", 1, 1e-9)
+		assert.Equal(t, minWeight, p.weight(1))
+		i.SetWeight("", 1, 3)
+		assert.Equal(t, 3.0, p.weight(1))
+		i.SetWeight("", 1, 0)
+		assert.Equal(t, 1.0, p.weight(1))
+	})
 }
@@ -0,0 +1,251 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package pacing
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/rtp"
+)
+
+// class is the priority class of a packet. Packets of a class are sent before packets
+// of the classes that follow it.
+type class int
+
+const (
+	classAudio class = iota
+	classRetransmission
+	classVideo
+	classFEC
+	classPadding
+	numClasses
+)
+
+func (c class) String() string {
+	switch c {
+	case classAudio:
+		return "audio"
+	case classRetransmission:
+		return "retransmission"
+	case classVideo:
+		return "video"
+	case classFEC:
+		return "fec"
+	case classPadding:
+		return "padding"
+	default:
+		return "unknown"
+	}
+}
+
+// droppable returns true if stale packets of the class may be dropped. Audio is cheap and
+// retransmissions were requested by the receiver, so they are always sent.
+func (c class) droppable() bool {
+	return c == classVideo || c == classFEC || c == classPadding
+}
+
+// classify returns the priority class of a packet of a local stream.
+func classify(info *interceptor.StreamInfo, header *rtp.Header, payload []byte) class {
+	switch {
+	case header.Padding && len(payload) == 0:
+		return classPadding
+	case info.SSRCRetransmission != 0 && header.SSRC == info.SSRCRetransmission,
+		info.PayloadTypeRetransmission != 0 && header.PayloadType == info.PayloadTypeRetransmission:
+		return classRetransmission
+	case info.SSRCForwardErrorCorrection != 0 && header.SSRC == info.SSRCForwardErrorCorrection,
+		info.PayloadTypeForwardErrorCorrection != 0 && header.PayloadType == info.PayloadTypeForwardErrorCorrection:
+		return classFEC
+	case strings.HasPrefix(strings.ToLower(info.MimeType), "audio/"):
+		return classAudio
+	default:
+		return classVideo
+	}
+}
+
+const (
+	// quantum is the number of bytes a stream with weight 1 may send per round.
+	quantum = 1200
+	// minWeight is the lowest weight of a stream.
+	minWeight = 0.01
+)
+
+// streamQueue holds the queued packets of a stream within a class. It is removed when
+// it runs empty, which resets its deficit.
+type streamQueue struct {
+	ssrc    uint32
+	deficit float64
+	// served is true if the stream got its quantum in the current round.
+	served  bool
+	packets []packet
+}
+
+// classQueue schedules the streams of a class with deficit round robin, so that each
+// stream gets a share of the bytes sent in proportion to its weight.
+type classQueue struct {
+	streams map[uint32]*streamQueue
+	active  []*streamQueue
+	next    int
+}
+
+// priorityQueue holds the packets waiting to be paced. Classes are served in strict
+// order of priority, and the streams within a class are served in weighted round robin.
+type priorityQueue struct {
+	classes [numClasses]classQueue
+	weight  func(ssrc uint32) float64
+	size    int
+}
+
+func newPriorityQueue(weight func(ssrc uint32) float64) *priorityQueue {
+	q := &priorityQueue{weight: weight}
+	for c := range q.classes {
+		q.classes[c].streams = map[uint32]*streamQueue{}
+	}
+
+	return q
+}
+
+func (q *priorityQueue) len() int {
+	return q.size
+}
+
+func (q *priorityQueue) push(pkt packet) {
+	cq := &q.classes[pkt.class]
+	s, ok := cq.streams[pkt.ssrc]
+	if !ok {
+		s = &streamQueue{ssrc: pkt.ssrc}
+		cq.streams[pkt.ssrc] = s
+		cq.active = append(cq.active, s)
+	}
+	s.packets = append(s.packets, pkt)
+	q.size++
+}
+
+// peek returns the next packet to send, or nil if the queue is empty. Repeated calls
+// return the same packet until it is removed with pop.
+func (q *priorityQueue) peek() *packet {
+	for c := range q.classes {
+		if s := q.classes[c].current(q.weight); s != nil {
+			return &s.packets[0]
+		}
+	}
+
+	return nil
+}
+
+// pop removes the packet returned by peek.
+func (q *priorityQueue) pop() {
+	for c := range q.classes {
+		cq := &q.classes[c]
+		s := cq.current(q.weight)
+		if s == nil {
+			continue
+		}
+		s.deficit -= float64(s.packets[0].len())
+		s.packets[0] = packet{}
+		s.packets = s.packets[1:]
+		if len(s.packets) == 0 {
+			cq.remove(cq.next)
+		}
+		q.size--
+
+		return
+	}
+}
+
+// dropStale removes packets of droppable classes that were queued before deadline and
+// returns the number of packets removed per class.
+func (q *priorityQueue) dropStale(deadline time.Time) map[class]int {
+	var dropped map[class]int
+	for c := range q.classes {
+		if !class(c).droppable() {
+			continue
+		}
+		cq := &q.classes[c]
+		for idx := 0; idx < len(cq.active); {
+			s := cq.active[idx]
+			n := 0
+			for n < len(s.packets) && s.packets[n].enqueued.Before(deadline) {
+				s.packets[n] = packet{}
+				n++
+			}
+			if n == 0 {
+				idx++
+
+				continue
+			}
+			if dropped == nil {
+				dropped = map[class]int{}
+			}
+			dropped[class(c)] += n
+			q.size -= n
+			s.packets = s.packets[n:]
+			if len(s.packets) > 0 {
+				idx++
+
+				continue
+			}
+			if idx < cq.next {
+				cq.next--
+			}
+			cq.remove(idx)
+		}
+	}
+
+	return dropped
+}
+
+// current returns the stream whose head packet is sent next, or nil if no stream of the
+// class has packets. Each stream gets its quantum once per round, and sends until its
+// deficit does not cover its head packet anymore. Rounds in which no stream can send are
+// skipped in one step.
+func (cq *classQueue) current(weight func(ssrc uint32) float64) *streamQueue {
+	if len(cq.active) == 0 {
+		return nil
+	}
+	for visited := 1; ; visited++ {
+		s := cq.active[cq.next]
+		if !s.served {
+			s.deficit += quantum * weight(s.ssrc)
+			s.served = true
+		}
+		if s.deficit >= float64(s.packets[0].len()) {
+			return s
+		}
+		s.served = false
+		cq.next = (cq.next + 1) % len(cq.active)
+		if visited == len(cq.active) {
+			cq.skipRounds(weight)
+			visited = 0
+		}
+	}
+}
+
+// skipRounds adds the quanta of the rounds before the next round in which a stream can
+// send its head packet. It is called after a round in which no stream could send.
+func (cq *classQueue) skipRounds(weight func(ssrc uint32) float64) {
+	rounds := math.Inf(1)
+	for _, s := range cq.active {
+		missing := float64(s.packets[0].len()) - s.deficit
+		rounds = min(rounds, math.Ceil(missing/(quantum*weight(s.ssrc)))-1)
+	}
+	if rounds <= 0 {
+		return
+	}
+	for _, s := range cq.active {
+		s.deficit += rounds * quantum * weight(s.ssrc)
+	}
+}
+
+// remove removes the empty stream at idx. If it was the current stream, the round robin
+// continues with the stream after it.
+func (cq *classQueue) remove(idx int) {
+	delete(cq.streams, cq.active[idx].ssrc)
+	cq.active = append(cq.active[:idx], cq.active[idx+1:]...)
+	if cq.next >= len(cq.active) {
+		cq.next = 0
+	}
+}
@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package pacing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/assert"
+)
+
+func queuedPacket(c class, ssrc uint32, seq uint16, size int, enqueued time.Time) packet {
+	return packet{
+		header:   &rtp.Header{SSRC: ssrc, SequenceNumber: seq},
+		payload:  make([]byte, size-12),
+		class:    c,
+		ssrc:     ssrc,
+		enqueued: enqueued,
+	}
+}
+
+// drain pops all packets and returns their SSRCs and sequence numbers in order.
+func drain(q *priorityQueue) [][2]int {
+	var order [][2]int
+	for next := q.peek(); next != nil; next = q.peek() {
+		order = append(order, [2]int{int(next.header.SSRC), int(next.header.SequenceNumber)})
+		q.pop()
+	}
+
+	return order
+}
+
+func TestClassify(t *testing.T) {
+	video := &interceptor.StreamInfo{
+		SSRC:                              1,
+		SSRCRetransmission:                2,
+		SSRCForwardErrorCorrection:        3,
+		PayloadType:                       96,
+		PayloadTypeRetransmission:         97,
+		PayloadTypeForwardErrorCorrection: 98,
+		MimeType:                          "video/VP8",
+	}
+	audio := &interceptor.StreamInfo{SSRC: 4, PayloadType: 111, MimeType: "audio/opus"}
+
+	for _, c := range []struct {
+		info    *interceptor.StreamInfo
+		header  rtp.Header
+		payload []byte
+		class   class
+	}{
+		{video, rtp.Header{SSRC: 1, PayloadType: 96}, []byte{1}, classVideo},
+		{video, rtp.Header{SSRC: 2, PayloadType: 97}, []byte{1}, classRetransmission},
+		{video, rtp.Header{SSRC: 1, PayloadType: 97}, []byte{1}, classRetransmission},
+		{video, rtp.Header{SSRC: 3, PayloadType: 98}, []byte{1}, classFEC},
+		{video, rtp.Header{SSRC: 2, PayloadType: 97, Padding: true, PaddingSize: 200}, nil, classPadding},
+		{audio, rtp.Header{SSRC: 4, PayloadType: 111}, []byte{1}, classAudio},
+	} {
+		assert.Equal(t, c.class, classify(c.info, &c.header, c.payload), "%v", c.header)
+	}
+}
+
+func TestPriorityQueue(t *testing.T) {
+	equal := func(uint32) float64 { return 1 }
+	now := time.Now()
+
+	t.Run("serves classes in order of priority", func(t *testing.T) {
+		q := newPriorityQueue(equal)
+		q.push(queuedPacket(classPadding, 5, 0, 100, now))
+		q.push(queuedPacket(classVideo, 1, 0, 1200, now))
+		q.push(queuedPacket(classVideo, 1, 1, 1200, now))
+		q.push(queuedPacket(classFEC, 3, 0, 100, now))
+		q.push(queuedPacket(classRetransmission, 2, 0, 1200, now))
+		q.push(queuedPacket(classAudio, 4, 0, 100, now))
+		assert.Equal(t, 6, q.len())
+
+		assert.Equal(t, [][2]int{{4, 0}, {2, 0}, {1, 0}, {1, 1}, {3, 0}, {5, 0}}, drain(q))
+		assert.Equal(t, 0, q.len())
+		assert.Nil(t, q.peek())
+	})
+
+	t.Run("higher priority packets overtake queued packets", func(t *testing.T) {
+		q := newPriorityQueue(equal)
+		q.push(queuedPacket(classVideo, 1, 0, 1200, now))
+		q.push(queuedPacket(classVideo, 1, 1, 1200, now))
+		assert.Equal(t, uint16(0), q.peek().header.SequenceNumber)
+		q.pop()
+		q.push(queuedPacket(classAudio, 4, 0, 100, now))
+		assert.Equal(t, [][2]int{{4, 0}, {1, 1}}, drain(q))
+	})
+
+	t.Run("round robin between streams of a class", func(t *testing.T) {
+		q := newPriorityQueue(equal)
+		for seq := 0; seq < 3; seq++ {
+			q.push(queuedPacket(classVideo, 1, uint16(seq), 1200, now)) //nolint:gosec // G115
+		}
+		for seq := 0; seq < 3; seq++ {
+			q.push(queuedPacket(classVideo, 2, uint16(seq), 1200, now)) //nolint:gosec // G115
+		}
+		assert.Equal(t, [][2]int{{1, 0}, {2, 0}, {1, 1}, {2, 1}, {1, 2}, {2, 2}}, drain(q))
+	})
+
+	t.Run("shares bytes in proportion to weights", func(t *testing.T) {
+		q := newPriorityQueue(func(ssrc uint32) float64 {
+			if ssrc == 1 {
+				return 2
+			}
+
+			return 1
+		})
+		for seq := 0; seq < 4; seq++ {
+			q.push(queuedPacket(classVideo, 1, uint16(seq), 1200, now)) //nolint:gosec // G115
+		}
+		// Stream 2 sends small packets, but gets no more bytes per round than its quantum
+		for seq := 0; seq < 6; seq++ {
+			q.push(queuedPacket(classVideo, 2, uint16(seq), 400, now)) //nolint:gosec // G115
+		}
+		assert.Equal(t, [][2]int{
+			{1, 0}, {1, 1}, {2, 0}, {2, 1}, {2, 2},
+			{1, 2}, {1, 3}, {2, 3}, {2, 4}, {2, 5},
+		}, drain(q))
+	})
+
+	t.Run("skips rounds for streams with small weights", func(t *testing.T) {
+		q := newPriorityQueue(func(ssrc uint32) float64 {
+			if ssrc == 1 {
+				return minWeight
+			}
+
+			return 0.5
+		})
+		// Stream 1 sends in round 100, stream 2 sends every second round before
+		q.push(queuedPacket(classVideo, 1, 0, 1200, now))
+		for seq := 0; seq < 60; seq++ {
+			q.push(queuedPacket(classVideo, 2, uint16(seq), 1200, now)) //nolint:gosec // G115
+		}
+		order := drain(q)
+		assert.Len(t, order, 61)
+		assert.Equal(t, [2]int{1, 0}, order[49])
+	})
+
+	t.Run("drops stale packets of droppable classes", func(t *testing.T) {
+		q := newPriorityQueue(equal)
+		old := now.Add(-time.Second)
+		q.push(queuedPacket(classAudio, 4, 0, 100, old))
+		q.push(queuedPacket(classRetransmission, 2, 0, 1200, old))
+		q.push(queuedPacket(classVideo, 1, 0, 1200, old))
+		q.push(queuedPacket(classVideo, 1, 1, 1200, old))
+		q.push(queuedPacket(classVideo, 1, 2, 1200, now))
+		q.push(queuedPacket(classVideo, 6, 0, 1200, old))
+		q.push(queuedPacket(classVideo, 7, 0, 1200, now))
+		q.push(queuedPacket(classPadding, 5, 0, 100, old))
+
+		dropped := q.dropStale(now.Add(-500 * time.Millisecond))
+		assert.Equal(t, map[class]int{classVideo: 3, classPadding: 1}, dropped)
+		assert.Equal(t, 4, q.len())
+		assert.Equal(t, [][2]int{{4, 0}, {2, 0}, {1, 2}, {7, 0}}, drain(q))
+
+		assert.Nil(t, q.dropStale(now))
+	})
+
+	t.Run("keeps round robin position when streams are dropped", func(t *testing.T) {
+		q := newPriorityQueue(equal)
+		old := now.Add(-time.Second)
+		q.push(queuedPacket(classVideo, 1, 0, 1200, now))
+		q.push(queuedPacket(classVideo, 1, 1, 1200, now))
+		q.push(queuedPacket(classVideo, 2, 0, 1200, old))
+		q.push(queuedPacket(classVideo, 3, 0, 1200, now))
+		q.pop()
+
+		// Stream 3 is next after stream 1, also when stream 2 between them is dropped
+		q.dropStale(now.Add(-500 * time.Millisecond))
+		assert.Equal(t, [][2]int{{3, 0}, {1, 1}}, drain(q))
+	})
+}
@@ -0,0 +1,395 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+// Package remb provides an interceptor that estimates the bandwidth of received
+// streams and reports it to the sender with REMB.
+package remb
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/interceptor/pkg/gcc"
+	"github.com/pion/logging"
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+)
+
+const (
+	absSendTimeURI = "http://www.webrtc.org/experiments/rtp-hdrext/abs-send-time"
+
+	// processInterval is how often received packets are added to the estimates.
+	processInterval = 25 * time.Millisecond
+	// decreaseThreshold is the fraction of the last sent estimate below which a lower
+	// estimate is sent immediately.
+	decreaseThreshold = 0.97
+)
+
+var errClosed = errors.New("interceptor is closed")
+
+// TickerFactory is a factory to create new tickers.
+type TickerFactory func(d time.Duration) ticker
+
+// SenderInterceptorFactory is a interceptor.Factory for a SenderInterceptor.
+type SenderInterceptorFactory struct {
+	opts []Option
+}
+
+// NewInterceptor constructs a new SenderInterceptor.
+func (f *SenderInterceptorFactory) NewInterceptor(_ string) (interceptor.Interceptor, error) {
+	senderInterceptor := &SenderInterceptor{
+		NoOp:       interceptor.NoOp{},
+		interval:   200 * time.Millisecond,
+		senderSSRC: rand.Uint32(), // #nosec
+		streams:    map[uint32]*stream{},
+		packetChan: make(chan packet),
+		newTicker: func(d time.Duration) ticker {
+			return &timeTicker{time.NewTicker(d)}
+		},
+		now:   time.Now,
+		close: make(chan struct{}),
+	}
+	for _, opt := range f.opts {
+		if err := opt(senderInterceptor); err != nil {
+			return nil, err
+		}
+	}
+
+	if senderInterceptor.loggerFactory == nil {
+		senderInterceptor.loggerFactory = logging.NewDefaultLoggerFactory()
+	}
+	if senderInterceptor.log == nil {
+		senderInterceptor.log = senderInterceptor.loggerFactory.NewLogger("remb_interceptor")
+	}
+
+	return senderInterceptor, nil
+}
+
+// NewSenderInterceptor returns a new SenderInterceptorFactory configured with the given options.
+func NewSenderInterceptor(opts ...Option) (*SenderInterceptorFactory, error) {
+	return &SenderInterceptorFactory{opts: opts}, nil
+}
+
+// SenderInterceptor estimates the bandwidth of the remote streams with the delay based
+// part of GCC, and periodically sends the estimate in REMB packets as specified in:
+// https://datatracker.ietf.org/doc/html/draft-alvestrand-rmcat-remb-03
+//
+// The send times of packets are read from the abs-send-time header extension, which is
+// shared by all streams of the sender. The transport wide congestion control header
+// extension carries no send times, so streams without abs-send-time use their RTP
+// timestamps as send times instead, and are estimated separately. The REMB bitrate is
+// the sum of these estimates.
+type SenderInterceptor struct {
+	interceptor.NoOp
+	log           logging.LeveledLogger
+	loggerFactory logging.LoggerFactory
+
+	lock             sync.Mutex
+	wg               sync.WaitGroup
+	interval         time.Duration
+	senderSSRC       uint32
+	estimatorOptions []gcc.ReceiveSideBWEOption
+	absSendTime      *gcc.ReceiveSideBWE
+	streams          map[uint32]*stream
+	packetChan       chan packet
+	newTicker        TickerFactory
+	now              func() time.Time
+	close            chan struct{}
+}
+
+// stream is a remote stream covered by the estimate.
+type stream struct {
+	estimator *gcc.ReceiveSideBWE
+	// shared is true if the estimator is the one of the abs-send-time streams.
+	shared bool
+}
+
+type packet struct {
+	estimator *gcc.ReceiveSideBWE
+	received  gcc.ReceivedPacket
+}
+
+// BindRTCPWriter lets you modify any outgoing RTCP packets. It is called once per PeerConnection. The returned method
+// will be called once per packet batch.
+func (s *SenderInterceptor) BindRTCPWriter(writer interceptor.RTCPWriter) interceptor.RTCPWriter {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.isClosed() {
+		return writer
+	}
+
+	s.wg.Add(1)
+	go s.loop(writer)
+
+	return writer
+}
+
+// BindRemoteStream lets you modify any incoming RTP packets.
+// It is called once for per RemoteStream. The returned method
+// will be called once per rtp packet.
+func (s *SenderInterceptor) BindRemoteStream(
+	info *interceptor.StreamInfo, reader interceptor.RTPReader,
+) interceptor.RTPReader {
+	var hdrExtID uint8
+	for _, e := range info.RTPHeaderExtensions {
+		if e.URI == absSendTimeURI {
+			hdrExtID = uint8(e.ID) //nolint:gosec // G115
+
+			break
+		}
+	}
+	if hdrExtID == 0 && info.ClockRate == 0 {
+		return reader
+	}
+
+	estimator, err := s.addStream(info.SSRC, hdrExtID != 0)
+	if err != nil {
+		s.log.Errorf("failed to create bandwidth estimator: %v", err)
+
+		return reader
+	}
+
+	var departure func(header *rtp.Header, arrival time.Time) (time.Time, bool)
+	if hdrExtID != 0 {
+		departure = absSendTimeDeparture(hdrExtID)
+	} else {
+		departure = rtpTimestampDeparture(info.ClockRate)
+	}
+
+	return interceptor.RTPReaderFunc(func(b []byte, a interceptor.Attributes) (int, interceptor.Attributes, error) {
+		i, attr, err := reader.Read(b, a)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		if attr == nil {
+			attr = make(interceptor.Attributes)
+		}
+		header, err := attr.GetRTPHeader(b[:i])
+		if err != nil {
+			return 0, nil, err
+		}
+
+		arrival := s.now()
+		sent, ok := departure(header, arrival)
+		if !ok {
+			return i, attr, nil
+		}
+		p := packet{
+			estimator: estimator,
+			received:  gcc.ReceivedPacket{Departure: sent, Arrival: arrival, Size: i},
+		}
+		select {
+		case <-s.close:
+			return 0, nil, errClosed
+		case s.packetChan <- p:
+		}
+
+		return i, attr, nil
+	})
+}
+
+// absSendTimeDeparture returns the send times of packets from the abs-send-time header
+// extension with the given ID.
+func absSendTimeDeparture(hdrExtID uint8) func(*rtp.Header, time.Time) (time.Time, bool) {
+	return func(header *rtp.Header, arrival time.Time) (time.Time, bool) {
+		ext := header.GetExtension(hdrExtID)
+		if ext == nil {
+			return time.Time{}, false
+		}
+		var absSendTime rtp.AbsSendTimeExtension
+		if err := absSendTime.Unmarshal(ext); err != nil {
+			return time.Time{}, false
+		}
+
+		return absSendTime.Estimate(arrival), true
+	}
+}
+
+// rtpTimestampDeparture returns the send times of packets from their RTP timestamps,
+// relative to the arrival of the first packet.
+func rtpTimestampDeparture(clockRate uint32) func(*rtp.Header, time.Time) (time.Time, bool) {
+	var (
+		base          time.Time
+		lastTimestamp uint32
+		elapsed       int64
+	)
+
+	return func(header *rtp.Header, arrival time.Time) (time.Time, bool) {
+		if base.IsZero() {
+			base = arrival
+		} else {
+			// The signed difference handles wrap around and reordering.
+			elapsed += int64(int32(header.Timestamp - lastTimestamp)) //nolint:gosec // G115
+		}
+		lastTimestamp = header.Timestamp
+
+		return base.Add(time.Duration(float64(elapsed) / float64(clockRate) * float64(time.Second))), true
+	}
+}
+
+// addStream returns the estimator of a new remote stream.
+func (s *SenderInterceptor) addStream(ssrc uint32, absSendTime bool) (*gcc.ReceiveSideBWE, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !absSendTime {
+		estimator, err := s.newEstimator()
+		if err != nil {
+			return nil, err
+		}
+		s.streams[ssrc] = &stream{estimator: estimator}
+
+		return estimator, nil
+	}
+
+	if s.absSendTime == nil {
+		estimator, err := s.newEstimator()
+		if err != nil {
+			return nil, err
+		}
+		s.absSendTime = estimator
+	}
+	s.streams[ssrc] = &stream{estimator: s.absSendTime, shared: true}
+
+	return s.absSendTime, nil
+}
+
+func (s *SenderInterceptor) newEstimator() (*gcc.ReceiveSideBWE, error) {
+	opts := append([]gcc.ReceiveSideBWEOption{gcc.ReceiveSideBWELoggerFactory(s.loggerFactory)}, s.estimatorOptions...)
+
+	return gcc.NewReceiveSideBWE(opts...)
+}
+
+// UnbindRemoteStream is called when the Stream is removed. It can be used to clean up any data related to that track.
+func (s *SenderInterceptor) UnbindRemoteStream(info *interceptor.StreamInfo) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	st, ok := s.streams[info.SSRC]
+	if !ok {
+		return
+	}
+	delete(s.streams, info.SSRC)
+	if !st.shared {
+		if err := st.estimator.Close(); err != nil {
+			s.log.Errorf("failed to close bandwidth estimator: %v", err)
+		}
+	}
+}
+
+// Close closes the interceptor.
+func (s *SenderInterceptor) Close() error {
+	defer s.wg.Wait()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.isClosed() {
+		return nil
+	}
+	close(s.close)
+
+	var errs []error
+	for _, st := range s.streams {
+		if !st.shared {
+			errs = append(errs, st.estimator.Close())
+		}
+	}
+	if s.absSendTime != nil {
+		errs = append(errs, s.absSendTime.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *SenderInterceptor) isClosed() bool {
+	select {
+	case <-s.close:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *SenderInterceptor) loop(writer interceptor.RTCPWriter) {
+	defer s.wg.Done()
+
+	pending := map[*gcc.ReceiveSideBWE][]gcc.ReceivedPacket{}
+	var lastSent time.Time
+	lastBitrate := 0
+
+	t := s.newTicker(processInterval)
+	for {
+		select {
+		case <-s.close:
+			t.Stop()
+
+			return
+
+		case p := <-s.packetChan:
+			pending[p.estimator] = append(pending[p.estimator], p.received)
+
+		case <-t.Ch():
+			for estimator, packets := range pending {
+				if err := estimator.OnPacketsReceived(packets); err != nil {
+					// The stream was removed.
+					s.log.Debugf("dropping received packets: %v", err)
+				}
+				delete(pending, estimator)
+			}
+
+			now := s.now()
+			remb := s.buildREMB()
+			if remb == nil {
+				continue
+			}
+			bitrate := int(remb.Bitrate)
+			decreased := float64(bitrate) < decreaseThreshold*float64(lastBitrate)
+			if !decreased && !lastSent.IsZero() && now.Sub(lastSent) < s.interval {
+				continue
+			}
+			if _, err := writer.Write([]rtcp.Packet{remb}, nil); err != nil {
+				s.log.Error(err.Error())
+			}
+			lastSent = now
+			lastBitrate = bitrate
+		}
+	}
+}
+
+// buildREMB returns a REMB with the sum of the estimates of the covered streams, or nil
+// if no stream is covered.
+func (s *SenderInterceptor) buildREMB() *rtcp.ReceiverEstimatedMaximumBitrate {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(s.streams) == 0 {
+		return nil
+	}
+
+	ssrcs := make([]uint32, 0, len(s.streams))
+	bitrate, shared := 0, false
+	for ssrc, st := range s.streams {
+		ssrcs = append(ssrcs, ssrc)
+		if st.shared {
+			shared = true
+		} else {
+			bitrate += st.estimator.GetTargetBitrate()
+		}
+	}
+	if shared {
+		bitrate += s.absSendTime.GetTargetBitrate()
+	}
+	slices.Sort(ssrcs)
+
+	return &rtcp.ReceiverEstimatedMaximumBitrate{
+		SenderSSRC: s.senderSSRC,
+		Bitrate:    float32(bitrate),
+		SSRCs:      ssrcs,
+	}
+}
@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package remb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/interceptor/internal/test"
+	"github.com/pion/interceptor/pkg/gcc"
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestInterceptor(t *testing.T, mNow *test.MockTime, mTick *test.MockTicker) interceptor.Interceptor {
+	t.Helper()
+
+	f, err := NewSenderInterceptor(
+		SenderTicker(func(time.Duration) ticker {
+			return mTick
+		}),
+		SenderNow(mNow.Now),
+		BandwidthEstimatorOptions(gcc.ReceiveSideBWEInitialBitrate(1_000_000)),
+	)
+	require.NoError(t, err)
+
+	i, err := f.NewInterceptor("")
+	require.NoError(t, err)
+
+	return i
+}
+
+func receive(t *testing.T, stream *test.MockStream, header rtp.Header) {
+	t.Helper()
+
+	stream.ReceiveRTP(&rtp.Packet{Header: header, Payload: make([]byte, 1000)})
+	r := <-stream.ReadRTP()
+	require.NoError(t, r.Err)
+}
+
+func TestSenderInterceptor(t *testing.T) {
+	zero := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("no REMB without covered streams", func(t *testing.T) {
+		mNow := &test.MockTime{}
+		mTick := &test.MockTicker{C: make(chan time.Time)}
+		i := newTestInterceptor(t, mNow, mTick)
+
+		stream := test.NewMockStream(&interceptor.StreamInfo{SSRC: 1}, i)
+		defer func() {
+			assert.NoError(t, stream.Close())
+		}()
+
+		receive(t, stream, rtp.Header{SSRC: 1})
+		mTick.Tick(zero)
+
+		select {
+		case pkts := <-stream.WrittenRTCP():
+			assert.Fail(t, "unexpected RTCP", pkts)
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("abs-send-time streams", func(t *testing.T) {
+		mNow := &test.MockTime{}
+		mNow.SetNow(zero)
+		mTick := &test.MockTicker{C: make(chan time.Time)}
+		i := newTestInterceptor(t, mNow, mTick)
+
+		ext := []interceptor.RTPHeaderExtension{{URI: absSendTimeURI, ID: 3}}
+		video := test.NewMockStream(&interceptor.StreamInfo{SSRC: 1, RTPHeaderExtensions: ext}, i)
+		defer func() {
+			assert.NoError(t, video.Close())
+		}()
+		// Further streams of the PeerConnection share the RTCP writer of the first
+		i.BindRemoteStream(&interceptor.StreamInfo{SSRC: 2, RTPHeaderExtensions: ext}, nil)
+
+		for n := 0; n < 5; n++ {
+			mNow.SetNow(zero.Add(time.Duration(n) * 10 * time.Millisecond))
+			absSendTime, err := rtp.NewAbsSendTimeExtension(mNow.Now()).Marshal()
+			require.NoError(t, err)
+			header := rtp.Header{SSRC: 1, SequenceNumber: uint16(n)} //nolint:gosec // G115
+			require.NoError(t, header.SetExtension(3, absSendTime))
+			receive(t, video, header)
+		}
+		mTick.Tick(zero)
+
+		pkts := <-video.WrittenRTCP()
+		require.Len(t, pkts, 1)
+		remb, ok := pkts[0].(*rtcp.ReceiverEstimatedMaximumBitrate)
+		require.True(t, ok)
+		assert.Equal(t, []uint32{1, 2}, remb.SSRCs)
+		// One estimator covers both streams
+		assert.Equal(t, float32(1_000_000), remb.Bitrate)
+
+		// The estimate is not sent again before the interval passed
+		mNow.SetNow(zero.Add(100 * time.Millisecond))
+		mTick.Tick(zero)
+		select {
+		case pkts = <-video.WrittenRTCP():
+			assert.Fail(t, "unexpected RTCP", pkts)
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		mNow.SetNow(zero.Add(300 * time.Millisecond))
+		mTick.Tick(zero)
+		pkts = <-video.WrittenRTCP()
+		require.Len(t, pkts, 1)
+	})
+
+	t.Run("RTP timestamp streams", func(t *testing.T) {
+		mNow := &test.MockTime{}
+		mNow.SetNow(zero)
+		mTick := &test.MockTicker{C: make(chan time.Time)}
+		i := newTestInterceptor(t, mNow, mTick)
+
+		video := test.NewMockStream(&interceptor.StreamInfo{SSRC: 1, ClockRate: 90000}, i)
+		defer func() {
+			assert.NoError(t, video.Close())
+		}()
+		i.BindRemoteStream(&interceptor.StreamInfo{SSRC: 2, ClockRate: 48000}, nil)
+
+		mTick.Tick(zero)
+		pkts := <-video.WrittenRTCP()
+		require.Len(t, pkts, 1)
+		remb, ok := pkts[0].(*rtcp.ReceiverEstimatedMaximumBitrate)
+		require.True(t, ok)
+		assert.Equal(t, []uint32{1, 2}, remb.SSRCs)
+		// Each stream is estimated separately
+		assert.Equal(t, float32(2_000_000), remb.Bitrate)
+
+		i.UnbindRemoteStream(&interceptor.StreamInfo{SSRC: 2})
+		mNow.SetNow(zero.Add(time.Second))
+		mTick.Tick(zero)
+		pkts = <-video.WrittenRTCP()
+		require.Len(t, pkts, 1)
+		remb, ok = pkts[0].(*rtcp.ReceiverEstimatedMaximumBitrate)
+		require.True(t, ok)
+		assert.Equal(t, []uint32{1}, remb.SSRCs)
+		assert.Equal(t, float32(1_000_000), remb.Bitrate)
+	})
+}
+
+func TestRTPTimestampDeparture(t *testing.T) {
+	arrival := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	departure := rtpTimestampDeparture(90000)
+
+	sent, ok := departure(&rtp.Header{Timestamp: 0xFFFFFFFF - 8999}, arrival)
+	assert.True(t, ok)
+	assert.Equal(t, arrival, sent)
+
+	// Wrap around
+	sent, _ = departure(&rtp.Header{Timestamp: 9000}, arrival.Add(time.Second))
+	assert.Equal(t, arrival.Add(200*time.Millisecond), sent)
+
+	// Reordered packet
+	sent, _ = departure(&rtp.Header{Timestamp: 0}, arrival.Add(time.Second))
+	assert.Equal(t, arrival.Add(100*time.Millisecond), sent)
+}
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package remb
+
+import (
+	"time"
+
+	"github.com/pion/interceptor/pkg/gcc"
+	"github.com/pion/logging"
+)
+
+// An Option is a function that can be used to configure a SenderInterceptor.
+type Option func(*SenderInterceptor) error
+
+// SenderTicker sets an alternative for time.Ticker.
+func SenderTicker(f TickerFactory) Option {
+	return func(s *SenderInterceptor) error {
+		s.newTicker = f
+
+		return nil
+	}
+}
+
+// SenderNow sets an alternative for the time.Now function.
+func SenderNow(f func() time.Time) Option {
+	return func(s *SenderInterceptor) error {
+		s.now = f
+
+		return nil
+	}
+}
+
+// SendInterval sets the interval at which REMB packets are sent. Decreases of the
+// estimate are sent immediately.
+func SendInterval(interval time.Duration) Option {
+	return func(s *SenderInterceptor) error {
+		s.interval = interval
+
+		return nil
+	}
+}
+
+// BandwidthEstimatorOptions sets the options of the receiver side bandwidth estimators.
+func BandwidthEstimatorOptions(opts ...gcc.ReceiveSideBWEOption) Option {
+	return func(s *SenderInterceptor) error {
+		s.estimatorOptions = append(s.estimatorOptions, opts...)
+
+		return nil
+	}
+}
+
+// WithLoggerFactory sets the logger factory for the interceptor.
+func WithLoggerFactory(loggerFactory logging.LoggerFactory) Option {
+	return func(s *SenderInterceptor) error {
+		s.loggerFactory = loggerFactory
+
+		return nil
+	}
+}
@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package scream
+
+import (
+	"time"
+
+	"github.com/pion/interceptor/internal/cc"
+	"github.com/pion/rtcp"
+)
+
+const (
+	// constants from
+	// https://datatracker.ietf.org/doc/html/rfc8298#section-4.1.1.1
+	qdelayTarget    = 100 * time.Millisecond
+	betaLoss        = 0.8
+	betaECN         = 0.8
+	gainUp          = 1.0
+	gainDown        = 1.0
+	mss             = 1200
+	cwndMin         = 3000
+	rampUpSpeed     = 200_000 // bits per second per second
+	baseDelayWindow = 10      // minutes
+	srttGain        = 1.0 / 8
+
+	// fastStartQdelayFraction is the fraction of the queue delay target that ends fast start.
+	fastStartQdelayFraction = 0.25
+	// bytesInFlightHeadroom is how far cwnd may exceed the bytes in flight and still grow.
+	bytesInFlightHeadroom = 2.0
+
+	// l4sGain is the gain of the moving average of the fraction of CE marked bytes.
+	// Reference: https://datatracker.ietf.org/doc/html/draft-johansson-ccwg-rfc8298bis-screamv2
+	l4sGain = 1.0 / 16
+
+	// defaultRTT is assumed until the RTT is measured.
+	defaultRTT = 100 * time.Millisecond
+	// minRTT bounds the RTT used to derive the bitrate from cwnd.
+	minRTT = 10 * time.Millisecond
+)
+
+// networkController implements the network congestion control of SCReAM. It adjusts the
+// congestion window to keep the queuing delay estimated from one way delays below a target,
+// backs off on loss and ECN marks, and derives the target bitrate from the congestion window.
+// See https://datatracker.ietf.org/doc/html/rfc8298#section-4.1.2
+type networkController struct {
+	minBitrate int
+	maxBitrate int
+	l4s        bool
+
+	cwnd      float64
+	fastStart bool
+	srtt      time.Duration
+	qdelay    time.Duration
+
+	// baseDelays holds the minimum one way delay of each of the last minutes.
+	baseDelays     []time.Duration
+	baseDelayStart time.Time
+
+	lastCongestion time.Time
+	l4sAlpha       float64
+	lossEvents     int
+	ceEvents       int
+
+	targetBitrate  float64
+	lastRateUpdate time.Time
+}
+
+func newNetworkController(initialBitrate, minBitrate, maxBitrate int, l4s bool) *networkController {
+	return &networkController{
+		minBitrate:    minBitrate,
+		maxBitrate:    maxBitrate,
+		l4s:           l4s,
+		cwnd:          max(cwndMin, float64(initialBitrate)*defaultRTT.Seconds()/8),
+		fastStart:     true,
+		targetBitrate: float64(initialBitrate),
+	}
+}
+
+// onFeedback updates the congestion window with the acknowledgments of a feedback report.
+// rtt is the RTT sample of the report, or zero, and maxInFlight is the maximum of the bytes
+// in flight since the previous report.
+func (c *networkController) onFeedback(now time.Time, acks []cc.Acknowledgment, rtt time.Duration, maxInFlight int) {
+	if rtt > 0 {
+		if c.srtt == 0 {
+			c.srtt = rtt
+		} else {
+			c.srtt += time.Duration(srttGain * float64(rtt-c.srtt))
+		}
+	}
+
+	ackedBytes, ceBytes, received, lost := 0, 0, 0, 0
+	var qdelaySum time.Duration
+	for _, ack := range acks {
+		if ack.Departure.IsZero() {
+			continue
+		}
+		if ack.Arrival.IsZero() {
+			lost++
+
+			continue
+		}
+		received++
+		ackedBytes += ack.Size
+		if ack.ECN == rtcp.ECNCE {
+			ceBytes += ack.Size
+		}
+		qdelaySum += c.queueDelay(ack.Arrival.Sub(ack.Departure), now)
+	}
+	if received > 0 {
+		c.qdelay = qdelaySum / time.Duration(received)
+	}
+
+	if c.l4s && ackedBytes > 0 {
+		c.l4sAlpha += l4sGain * (float64(ceBytes)/float64(ackedBytes) - c.l4sAlpha)
+	}
+
+	switch {
+	case lost > 0 && c.canReact(now):
+		c.lossEvents++
+		c.backOff(now, betaLoss)
+	case ceBytes > 0 && c.canReact(now):
+		c.ceEvents++
+		if c.l4s {
+			c.backOff(now, 1-c.l4sAlpha/2)
+		} else {
+			c.backOff(now, betaECN)
+		}
+	case ackedBytes > 0:
+		c.adjust(float64(ackedBytes), maxInFlight)
+	}
+
+	c.updateTargetBitrate(now)
+}
+
+// onFeedbackTimeout resets the congestion window if no feedback was received for long.
+func (c *networkController) onFeedbackTimeout(now time.Time) {
+	c.cwnd = cwndMin
+	c.fastStart = false
+	c.lastCongestion = now
+	c.updateTargetBitrate(now)
+}
+
+// queueDelay returns the one way delay above the base delay, and updates the base delay history.
+func (c *networkController) queueDelay(owd time.Duration, now time.Time) time.Duration {
+	if len(c.baseDelays) == 0 || now.Sub(c.baseDelayStart) >= time.Minute {
+		c.baseDelays = append(c.baseDelays, owd)
+		if len(c.baseDelays) > baseDelayWindow {
+			c.baseDelays = c.baseDelays[1:]
+		}
+		c.baseDelayStart = now
+	}
+	last := len(c.baseDelays) - 1
+	c.baseDelays[last] = min(c.baseDelays[last], owd)
+
+	base := c.baseDelays[0]
+	for _, d := range c.baseDelays[1:] {
+		base = min(base, d)
+	}
+
+	return owd - base
+}
+
+// canReact reports whether an RTT passed since the last reaction to congestion.
+func (c *networkController) canReact(now time.Time) bool {
+	return c.lastCongestion.IsZero() || now.Sub(c.lastCongestion) >= c.rtt()
+}
+
+// backOff reduces the congestion window by a factor and ends fast start.
+func (c *networkController) backOff(now time.Time, beta float64) {
+	c.cwnd = max(cwndMin, beta*c.cwnd)
+	c.fastStart = false
+	c.lastCongestion = now
+}
+
+// adjust grows or shrinks the congestion window depending on the distance of the queue
+// delay from its target. The window only grows if the sender used most of it.
+func (c *networkController) adjust(ackedBytes float64, maxInFlight int) {
+	limited := bytesInFlightHeadroom*float64(maxInFlight) >= c.cwnd
+	if c.fastStart {
+		if c.qdelay < time.Duration(fastStartQdelayFraction*float64(qdelayTarget)) {
+			if limited {
+				c.cwnd += ackedBytes
+			}
+
+			return
+		}
+		c.fastStart = false
+	}
+
+	offTarget := float64(qdelayTarget-c.qdelay) / float64(qdelayTarget)
+	if offTarget > 0 {
+		if limited {
+			c.cwnd += gainUp * offTarget * ackedBytes * mss / c.cwnd
+		}
+	} else {
+		c.cwnd = max(cwndMin, c.cwnd+gainDown*offTarget*ackedBytes*mss/c.cwnd)
+	}
+}
+
+// updateTargetBitrate sets the target bitrate to the bitrate the congestion window allows
+// within an RTT. Increases are limited to rampUpSpeed, decreases apply immediately.
+func (c *networkController) updateTargetBitrate(now time.Time) {
+	rate := c.cwnd * 8 / max(c.rtt(), minRTT).Seconds()
+	if rate > c.targetBitrate {
+		elapsed := 0.0
+		if !c.lastRateUpdate.IsZero() {
+			elapsed = now.Sub(c.lastRateUpdate).Seconds()
+		}
+		rate = min(rate, c.targetBitrate+rampUpSpeed*elapsed)
+	}
+	c.targetBitrate = min(max(rate, float64(c.minBitrate)), float64(c.maxBitrate))
+	c.lastRateUpdate = now
+}
+
+// rtt returns the smoothed RTT, or defaultRTT before it is measured.
+func (c *networkController) rtt() time.Duration {
+	if c.srtt == 0 {
+		return defaultRTT
+	}
+
+	return c.srtt
+}
@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package scream
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/interceptor/internal/cc"
+	"github.com/pion/rtcp"
+	"github.com/stretchr/testify/assert"
+)
+
+// acks returns count acknowledgments of 1000 byte packets sent at departure and
+// received after owd. Packets in lost are not received, packets in marked are CE marked.
+func acks(departure time.Time, owd time.Duration, count int, lost, marked map[int]bool) []cc.Acknowledgment {
+	result := make([]cc.Acknowledgment, count)
+	for i := range result {
+		result[i] = cc.Acknowledgment{
+			SequenceNumber: uint16(i), //nolint:gosec // G115
+			Size:           1000,
+			Departure:      departure,
+			Arrival:        departure.Add(owd),
+		}
+		if lost[i] {
+			result[i].Arrival = time.Time{}
+		}
+		if marked[i] {
+			result[i].ECN = rtcp.ECNCE
+		}
+	}
+
+	return result
+}
+
+func TestNetworkController(t *testing.T) {
+	start := time.Time{}.Add(time.Hour)
+
+	t.Run("fast start grows by acknowledged bytes", func(t *testing.T) {
+		controller := newNetworkController(1_000_000, 50_000, 10_000_000, false)
+		assert.Equal(t, 12_500.0, controller.cwnd)
+
+		controller.onFeedback(start, acks(start, 20*time.Millisecond, 10, nil, nil), 50*time.Millisecond, 12_500)
+		assert.True(t, controller.fastStart)
+		assert.Equal(t, 22_500.0, controller.cwnd)
+
+		// The window does not grow if the sender does not use it
+		controller.onFeedback(start, acks(start, 20*time.Millisecond, 10, nil, nil), 50*time.Millisecond, 1000)
+		assert.Equal(t, 22_500.0, controller.cwnd)
+	})
+
+	t.Run("queue delay above target reduces window", func(t *testing.T) {
+		controller := newNetworkController(1_000_000, 50_000, 10_000_000, false)
+		controller.onFeedback(start, acks(start, 20*time.Millisecond, 10, nil, nil), 50*time.Millisecond, 12_500)
+		cwnd := controller.cwnd
+
+		now := start.Add(100 * time.Millisecond)
+		controller.onFeedback(now, acks(now, 220*time.Millisecond, 10, nil, nil), 50*time.Millisecond, 12_500)
+		assert.Equal(t, 200*time.Millisecond, controller.qdelay)
+		assert.False(t, controller.fastStart)
+		assert.Less(t, controller.cwnd, cwnd)
+	})
+
+	t.Run("loss reduces window once per RTT", func(t *testing.T) {
+		controller := newNetworkController(1_000_000, 50_000, 10_000_000, false)
+		rtt := 50 * time.Millisecond
+		controller.onFeedback(start, acks(start, 20*time.Millisecond, 10, map[int]bool{3: true}, nil), rtt, 12_500)
+		assert.Equal(t, 1, controller.lossEvents)
+		assert.Equal(t, 10_000.0, controller.cwnd)
+
+		now := start.Add(rtt / 2)
+		controller.onFeedback(now, acks(now, 20*time.Millisecond, 10, map[int]bool{3: true}, nil), rtt, 12_500)
+		assert.Equal(t, 1, controller.lossEvents)
+		assert.GreaterOrEqual(t, controller.cwnd, 10_000.0)
+
+		now = start.Add(rtt)
+		controller.onFeedback(now, acks(now, 20*time.Millisecond, 10, map[int]bool{3: true}, nil), rtt, 12_500)
+		assert.Equal(t, 2, controller.lossEvents)
+		assert.Less(t, controller.cwnd, 10_000.0)
+	})
+
+	t.Run("classic ECN reduces window by constant factor", func(t *testing.T) {
+		controller := newNetworkController(1_000_000, 50_000, 10_000_000, false)
+		controller.onFeedback(start, acks(start, 20*time.Millisecond, 10, nil, map[int]bool{0: true}), 50*time.Millisecond, 0)
+		assert.Equal(t, 1, controller.ceEvents)
+		assert.Equal(t, 10_000.0, controller.cwnd)
+	})
+
+	t.Run("L4S reduces window in proportion to marking", func(t *testing.T) {
+		controller := newNetworkController(1_000_000, 50_000, 10_000_000, true)
+		controller.onFeedback(start, acks(start, 20*time.Millisecond, 10, nil, map[int]bool{0: true}), 50*time.Millisecond, 0)
+		assert.Equal(t, 1, controller.ceEvents)
+		assert.InDelta(t, 0.1/16, controller.l4sAlpha, 1e-9)
+		assert.InDelta(t, 12_500*(1-0.1/32), controller.cwnd, 1e-6)
+	})
+
+	t.Run("target bitrate ramps up", func(t *testing.T) {
+		controller := newNetworkController(1_000_000, 50_000, 10_000_000, false)
+		controller.onFeedback(start, acks(start, 20*time.Millisecond, 10, nil, nil), 50*time.Millisecond, 100_000)
+		// No increase on the first update
+		assert.Equal(t, 1_000_000.0, controller.targetBitrate)
+
+		now := start.Add(time.Second)
+		controller.onFeedback(now, acks(now, 20*time.Millisecond, 10, nil, nil), 50*time.Millisecond, 100_000)
+		assert.Equal(t, 1_000_000.0+rampUpSpeed, controller.targetBitrate)
+
+		// Decreases apply immediately, to the rate of the minimum window over the RTT
+		controller.onFeedbackTimeout(now)
+		assert.Equal(t, float64(cwndMin*8*20), controller.targetBitrate)
+	})
+}
@@ -0,0 +1,427 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+// Package scream implements Self-Clocked Rate Adaptation for Multimedia (SCReAM)
+// congestion control as defined in RFC 8298, as a bandwidth estimator for the cc interceptor.
+package scream
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/interceptor/internal/cc"
+	"github.com/pion/interceptor/internal/ntp"
+	"github.com/pion/logging"
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+)
+
+const (
+	transportCCURI = "http://www.ietf.org/id/draft-holmer-rmcat-transport-wide-cc-extensions-01"
+
+	defaultInitialBitrate = 300_000
+	defaultMinBitrate     = 50_000
+	defaultMaxBitrate     = 50_000_000
+
+	// pacingInterval is how often queued packets are checked for transmission.
+	pacingInterval = 5 * time.Millisecond
+	// pacingFactor is the factor of the target bitrate packets are paced at, so that the
+	// pacer does not add delay to bursts of the encoder.
+	pacingFactor = 1.5
+	// maxBurst bounds the unused pacing budget.
+	maxBurst = 2 * pacingInterval
+	// feedbackTimeout is how long the sender waits for feedback before it resets the
+	// congestion window, and how long a packet without feedback counts as in flight.
+	feedbackTimeout = time.Second
+	// maxQueueDelay is how long a packet may wait in the queue before it is discarded.
+	maxQueueDelay = time.Second
+)
+
+var (
+	// ErrClosed is returned when the BandwidthEstimator is used after it was closed.
+	ErrClosed = errors.New("scream bandwidth estimator closed")
+	// ErrUnknownStream is returned when a packet is written for an SSRC that was never added.
+	ErrUnknownStream = errors.New("unknown ssrc")
+)
+
+// Option configures a BandwidthEstimator.
+type Option func(*BandwidthEstimator) error
+
+// InitialBitrate sets the initial target bitrate.
+func InitialBitrate(rate int) Option {
+	return func(e *BandwidthEstimator) error {
+		e.initialBitrate = rate
+
+		return nil
+	}
+}
+
+// MinBitrate sets the minimum target bitrate.
+func MinBitrate(rate int) Option {
+	return func(e *BandwidthEstimator) error {
+		e.minBitrate = rate
+
+		return nil
+	}
+}
+
+// MaxBitrate sets the maximum target bitrate.
+func MaxBitrate(rate int) Option {
+	return func(e *BandwidthEstimator) error {
+		e.maxBitrate = rate
+
+		return nil
+	}
+}
+
+// WithL4S enables the scalable congestion response of L4S, which reduces the congestion
+// window in proportion to the fraction of CE marked bytes, instead of by a constant factor
+// for each marking. CE marks are only reported in RFC 8888 feedback, and the packets have
+// to be sent with the ECT(1) codepoint by the transport.
+func WithL4S() Option {
+	return func(e *BandwidthEstimator) error {
+		e.l4s = true
+
+		return nil
+	}
+}
+
+// WithLoggerFactory sets the logger factory of the BandwidthEstimator.
+func WithLoggerFactory(factory logging.LoggerFactory) Option {
+	return func(e *BandwidthEstimator) error {
+		e.loggerFactory = factory
+
+		return nil
+	}
+}
+
+type queuedPacket struct {
+	header     *rtp.Header
+	payload    []byte
+	attributes interceptor.Attributes
+	enqueued   time.Time
+}
+
+// BandwidthEstimator implements SCReAM congestion control. Packets are queued and
+// transmitted while the bytes in flight are below the congestion window, so that the
+// transmission is clocked by the feedback, and paced at a multiple of the target bitrate.
+// The congestion window is adjusted from TWCC or RFC 8888 feedback.
+type BandwidthEstimator struct {
+	lock sync.Mutex
+
+	initialBitrate int
+	minBitrate     int
+	maxBitrate     int
+	l4s            bool
+
+	controller      *networkController
+	feedbackAdapter *cc.FeedbackAdapter
+	writers         map[uint32]interceptor.RTPWriter
+
+	queue          []queuedPacket
+	droppedPackets int
+	budget         float64
+	lastTransmit   time.Time
+	maxInFlight    int
+	lastFeedback   time.Time
+	latestBitrate  int
+
+	onTargetBitrateChange func(bitrate int)
+
+	wake      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+
+	loggerFactory logging.LoggerFactory
+	log           logging.LeveledLogger
+}
+
+// NewBandwidthEstimator returns a new SCReAM BandwidthEstimator.
+func NewBandwidthEstimator(opts ...Option) (*BandwidthEstimator, error) {
+	e := &BandwidthEstimator{
+		initialBitrate:  defaultInitialBitrate,
+		minBitrate:      defaultMinBitrate,
+		maxBitrate:      defaultMaxBitrate,
+		feedbackAdapter: cc.NewFeedbackAdapter(),
+		writers:         map[uint32]interceptor.RTPWriter{},
+		wake:            make(chan struct{}, 1),
+		done:            make(chan struct{}),
+	}
+	for _, opt := range opts {
+		if err := opt(e); err != nil {
+			return nil, err
+		}
+	}
+	if e.loggerFactory == nil {
+		e.loggerFactory = logging.NewDefaultLoggerFactory()
+	}
+	e.log = e.loggerFactory.NewLogger("scream")
+	e.latestBitrate = e.initialBitrate
+	e.controller = newNetworkController(e.initialBitrate, e.minBitrate, e.maxBitrate, e.l4s)
+
+	e.wg.Add(1)
+	go e.run()
+
+	return e, nil
+}
+
+// AddStream adds a new stream to the bandwidth estimator. Packets written to the
+// returned writer are queued until the congestion window allows sending them.
+func (e *BandwidthEstimator) AddStream(
+	info *interceptor.StreamInfo, writer interceptor.RTPWriter,
+) interceptor.RTPWriter {
+	var hdrExtID uint8
+	for _, ext := range info.RTPHeaderExtensions {
+		if ext.URI == transportCCURI {
+			hdrExtID = uint8(ext.ID) //nolint:gosec // G115
+
+			break
+		}
+	}
+
+	streamWriter := interceptor.RTPWriterFunc(
+		func(header *rtp.Header, payload []byte, attributes interceptor.Attributes) (int, error) {
+			if hdrExtID != 0 {
+				if attributes == nil {
+					attributes = make(interceptor.Attributes)
+				}
+				attributes.Set(cc.TwccExtensionAttributesKey, hdrExtID)
+			}
+			if err := e.feedbackAdapter.OnSent(time.Now(), header, len(payload), attributes); err != nil {
+				return 0, err
+			}
+
+			return writer.Write(header, payload, attributes)
+		},
+	)
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.writers[info.SSRC] = streamWriter
+	if info.SSRCRetransmission != 0 {
+		e.writers[info.SSRCRetransmission] = streamWriter
+	}
+
+	return e
+}
+
+// Write queues a packet for transmission.
+func (e *BandwidthEstimator) Write(header *rtp.Header, payload []byte, attributes interceptor.Attributes) (int, error) {
+	e.lock.Lock()
+	if _, ok := e.writers[header.SSRC]; !ok {
+		e.lock.Unlock()
+
+		return 0, fmt.Errorf("%w: %v", ErrUnknownStream, header.SSRC)
+	}
+	hdr := header.Clone()
+	e.queue = append(e.queue, queuedPacket{
+		header:     &hdr,
+		payload:    append([]byte(nil), payload...),
+		attributes: attributes,
+		enqueued:   time.Now(),
+	})
+	e.lock.Unlock()
+
+	select {
+	case e.wake <- struct{}{}:
+	default:
+	}
+
+	return header.MarshalSize() + len(payload), nil
+}
+
+// WriteRTCP adds some RTCP feedback to the bandwidth estimator.
+func (e *BandwidthEstimator) WriteRTCP(pkts []rtcp.Packet, _ interceptor.Attributes) error {
+	select {
+	case <-e.done:
+		return ErrClosed
+	default:
+	}
+
+	now := time.Now()
+	for _, pkt := range pkts {
+		var acks []cc.Acknowledgment
+		var feedbackSentTime time.Time
+		switch fb := pkt.(type) {
+		case *rtcp.TransportLayerCC:
+			var err error
+			acks, err = e.feedbackAdapter.OnTransportCCFeedback(now, fb)
+			if err != nil {
+				return err
+			}
+			for _, ack := range acks {
+				if ack.Arrival.After(feedbackSentTime) {
+					feedbackSentTime = ack.Arrival
+				}
+			}
+		case *rtcp.CCFeedbackReport:
+			acks = e.feedbackAdapter.OnRFC8888Feedback(now, fb)
+			feedbackSentTime = ntp.ToTime(uint64(fb.ReportTimestamp) << 16)
+		default:
+			continue
+		}
+
+		e.onFeedback(now, acks, feedbackRTT(now, feedbackSentTime, acks))
+	}
+
+	return nil
+}
+
+// feedbackRTT returns the minimum RTT of the acknowledged packets, without the time
+// the packets waited for the feedback at the receiver, or zero if no packet was received.
+func feedbackRTT(now, feedbackSentTime time.Time, acks []cc.Acknowledgment) time.Duration {
+	rtt := time.Duration(math.MaxInt64)
+	for _, ack := range acks {
+		if ack.Arrival.IsZero() || ack.Departure.IsZero() {
+			continue
+		}
+		rtt = min(rtt, now.Sub(ack.Departure)-feedbackSentTime.Sub(ack.Arrival))
+	}
+	if rtt == math.MaxInt64 || rtt < 0 {
+		return 0
+	}
+
+	return rtt
+}
+
+// onFeedback updates the congestion window, and transmits packets the window allows now.
+func (e *BandwidthEstimator) onFeedback(now time.Time, acks []cc.Acknowledgment, rtt time.Duration) {
+	e.lock.Lock()
+	e.controller.onFeedback(now, acks, rtt, e.maxInFlight)
+	e.maxInFlight = e.feedbackAdapter.OutstandingBytes(now.Add(-feedbackTimeout))
+	e.lastFeedback = now
+	e.updateBitrate()
+	e.lock.Unlock()
+
+	select {
+	case e.wake <- struct{}{}:
+	default:
+	}
+}
+
+// updateBitrate notifies about changes of the target bitrate.
+// This method should be called with lock held.
+func (e *BandwidthEstimator) updateBitrate() {
+	bitrate := int(e.controller.targetBitrate)
+	if bitrate == e.latestBitrate {
+		return
+	}
+	e.latestBitrate = bitrate
+	if e.onTargetBitrateChange != nil {
+		go e.onTargetBitrateChange(bitrate)
+	}
+}
+
+func (e *BandwidthEstimator) run() {
+	defer e.wg.Done()
+
+	ticker := time.NewTicker(pacingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-e.done:
+			return
+		case <-ticker.C:
+		case <-e.wake:
+		}
+		e.transmit(time.Now())
+	}
+}
+
+// transmit sends queued packets while the congestion window and the pacing budget allow.
+func (e *BandwidthEstimator) transmit(now time.Time) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	inFlight := e.feedbackAdapter.OutstandingBytes(now.Add(-feedbackTimeout))
+	if inFlight > 0 && !e.lastFeedback.IsZero() && now.Sub(e.lastFeedback) > feedbackTimeout {
+		e.log.Warnf("no feedback for %v, resetting congestion window", now.Sub(e.lastFeedback))
+		e.controller.onFeedbackTimeout(now)
+		e.lastFeedback = now
+		e.updateBitrate()
+	}
+
+	for len(e.queue) > 0 && now.Sub(e.queue[0].enqueued) > maxQueueDelay {
+		e.queue = e.queue[1:]
+		e.droppedPackets++
+	}
+
+	rate := pacingFactor * e.controller.targetBitrate
+	if !e.lastTransmit.IsZero() {
+		e.budget = min(e.budget+rate*now.Sub(e.lastTransmit).Seconds()/8, rate*maxBurst.Seconds()/8)
+	}
+	e.lastTransmit = now
+
+	for len(e.queue) > 0 {
+		packet := e.queue[0]
+		size := packet.header.MarshalSize() + len(packet.payload)
+		if e.budget < 0 || (inFlight > 0 && float64(inFlight+size) > e.controller.cwnd) {
+			return
+		}
+		e.queue = e.queue[1:]
+		e.budget -= float64(size)
+		inFlight += size
+		e.maxInFlight = max(e.maxInFlight, inFlight)
+
+		writer := e.writers[packet.header.SSRC]
+		e.lock.Unlock()
+		if _, err := writer.Write(packet.header, packet.payload, packet.attributes); err != nil {
+			e.log.Errorf("failed to write packet: %v", err)
+		}
+		e.lock.Lock()
+	}
+}
+
+// GetTargetBitrate returns the current target bitrate in bits per second.
+func (e *BandwidthEstimator) GetTargetBitrate() int {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	return e.latestBitrate
+}
+
+// OnTargetBitrateChange sets the callback that is called when the target
+// bitrate in bits per second changes.
+func (e *BandwidthEstimator) OnTargetBitrateChange(f func(bitrate int)) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.onTargetBitrateChange = f
+}
+
+// GetStats returns some internal statistics of the bandwidth estimator.
+func (e *BandwidthEstimator) GetStats() map[string]any {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	return map[string]any{
+		"targetBitrate":  e.latestBitrate,
+		"cwnd":           int(e.controller.cwnd),
+		"bytesInFlight":  e.feedbackAdapter.OutstandingBytes(time.Now().Add(-feedbackTimeout)),
+		"queueDelay":     float64(e.controller.qdelay.Microseconds()) / 1000.0,
+		"srtt":           float64(e.controller.srtt.Microseconds()) / 1000.0,
+		"fastStart":      e.controller.fastStart,
+		"l4sAlpha":       e.controller.l4sAlpha,
+		"lossEvents":     e.controller.lossEvents,
+		"ceEvents":       e.controller.ceEvents,
+		"queuedPackets":  len(e.queue),
+		"droppedPackets": e.droppedPackets,
+	}
+}
+
+// Close stops the bandwidth estimator. Queued packets are discarded.
+func (e *BandwidthEstimator) Close() error {
+	e.closeOnce.Do(func() {
+		close(e.done)
+	})
+	e.wg.Wait()
+
+	return nil
+}
@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package scream
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/interceptor/internal/ntp"
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockRTPRecorder records the headers of the RTP packets written to it.
+type mockRTPRecorder struct {
+	lock    sync.Mutex
+	headers []rtp.Header
+}
+
+func (m *mockRTPRecorder) Write(header *rtp.Header, payload []byte, _ interceptor.Attributes) (int, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.headers = append(m.headers, header.Clone())
+
+	return header.MarshalSize() + len(payload), nil
+}
+
+func (m *mockRTPRecorder) count() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return len(m.headers)
+}
+
+func TestBandwidthEstimator(t *testing.T) {
+	bwe, err := NewBandwidthEstimator(InitialBitrate(1_000_000), MinBitrate(100_000), MaxBitrate(5_000_000))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, bwe.Close())
+	}()
+	assert.Equal(t, 1_000_000, bwe.GetTargetBitrate())
+
+	recorder := &mockRTPRecorder{}
+	writer := bwe.AddStream(&interceptor.StreamInfo{SSRC: 1}, recorder)
+
+	_, err = writer.Write(&rtp.Header{SSRC: 2}, make([]byte, 1000), nil)
+	assert.ErrorIs(t, err, ErrUnknownStream)
+
+	// RFC 8888 feedback counts the payload size only
+	for i := 0; i < 10; i++ {
+		_, err = writer.Write(&rtp.Header{SSRC: 1, SequenceNumber: uint16(i)}, make([]byte, 1000), nil) //nolint:gosec // G115
+		require.NoError(t, err)
+	}
+	require.Eventually(t, func() bool { return recorder.count() == 10 }, time.Second, time.Millisecond)
+	assert.Equal(t, 10_000, bwe.GetStats()["bytesInFlight"])
+
+	blocks := make([]rtcp.CCFeedbackMetricBlock, 10)
+	for i := range blocks {
+		blocks[i].Received = true
+	}
+	require.NoError(t, bwe.WriteRTCP([]rtcp.Packet{&rtcp.CCFeedbackReport{
+		ReportTimestamp: ntp.ToNTP32(time.Now()),
+		ReportBlocks:    []rtcp.CCFeedbackReportBlock{{MediaSSRC: 1, MetricBlocks: blocks}},
+	}}, nil))
+
+	stats := bwe.GetStats()
+	for _, key := range []string{
+		"targetBitrate", "cwnd", "bytesInFlight", "queueDelay", "srtt", "fastStart",
+		"l4sAlpha", "lossEvents", "ceEvents", "queuedPackets", "droppedPackets",
+	} {
+		assert.Contains(t, stats, key)
+	}
+	assert.Equal(t, 0, stats["bytesInFlight"])
+	assert.Equal(t, true, stats["fastStart"])
+	assert.Equal(t, 22_500, stats["cwnd"])
+}
+
+func TestBandwidthEstimator_CongestionWindowLimitsSending(t *testing.T) {
+	bwe, err := NewBandwidthEstimator(InitialBitrate(100_000), MinBitrate(100_000))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, bwe.Close())
+	}()
+
+	recorder := &mockRTPRecorder{}
+	writer := bwe.AddStream(&interceptor.StreamInfo{SSRC: 1}, recorder)
+
+	// The initial window of 3000 bytes allows three packets without feedback
+	for i := 0; i < 10; i++ {
+		_, err = writer.Write(&rtp.Header{SSRC: 1, SequenceNumber: uint16(i)}, make([]byte, 988), nil) //nolint:gosec // G115
+		require.NoError(t, err)
+	}
+	require.Eventually(t, func() bool { return recorder.count() == 3 }, time.Second, time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 3, recorder.count())
+	assert.Equal(t, 7, bwe.GetStats()["queuedPackets"])
+}
+
+func TestBandwidthEstimator_ErrorOnWriteRTCPAtClosedState(t *testing.T) {
+	bwe, err := NewBandwidthEstimator()
+	require.NoError(t, err)
+
+	pkts := []rtcp.Packet{&rtcp.TransportLayerCC{}}
+	require.NoError(t, bwe.WriteRTCP(pkts, nil))
+	require.NoError(t, bwe.Close())
+	require.ErrorIs(t, bwe.WriteRTCP(pkts, nil), ErrClosed)
+}
@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package videoframe
+
+import (
+	"github.com/pion/rtp/codecs/av1/obu"
+)
+
+// AV1 aggregation header bits.
+// Reference: AV1 RTP Payload Format Section 4.4, libwebrtc video_rtp_depacketizer_av1.cc
+//
+//	 0 1 2 3 4 5 6 7
+//	+-+-+-+-+-+-+-+-+
+//	|Z|Y| W |N|-|-|-|
+//	+-+-+-+-+-+-+-+-+
+const (
+	av1ZBit       = 0x80
+	av1YBit       = 0x40
+	av1WMask      = 0x30
+	av1WShift     = 4
+	av1NBit       = 0x08
+	av1HeaderSize = 1
+)
+
+// AV1 OBU header bits.
+// Reference: AV1 Bitstream Specification Section 5.3.2
+const (
+	av1OBUExtensionFlag = 0x04
+	av1OBUHasSizeField  = 0x02
+
+	// av1MaxLeb128Size is the maximum size of a leb128 encoded obu_size field.
+	av1MaxLeb128Size = 8
+)
+
+// depacketizeAV1Payload parses the aggregation header of an AV1 RTP payload.
+// The returned payload is the complete RTP payload, including the aggregation header,
+// since OBU elements can only be reassembled once all packets of the frame are known.
+// See assembleAV1Frame.
+//
+// Without a dependency descriptor, AV1 has no frame begin bit: every packet that does
+// not continue an OBU fragment (Z=0) is marked as IsFirstPacketInFrame and
+// VideoPacketBuffer finds the actual start of the frame by timestamp, as for H.264.
+// The N bit marks the first packet of a coded video sequence, which is treated as a keyframe.
+//
+// Reference: libwebrtc video_rtp_depacketizer_av1.cc Parse()
+func depacketizeAV1Payload(payload []byte, marker bool) (*RTPVideoHeader, []byte, error) {
+	if len(payload) < av1HeaderSize {
+		return nil, nil, errAV1ShortPacket
+	}
+
+	aggregationHeader := payload[0]
+
+	header := &RTPVideoHeader{
+		Codec:                VideoCodecAV1,
+		FrameType:            FrameTypeDelta,
+		IsFirstPacketInFrame: aggregationHeader&av1ZBit == 0,
+		IsLastPacketInFrame:  marker,
+		PictureID:            NoPictureID,
+		TemporalIdx:          NoTemporalIdx,
+		TL0PicIdx:            NoTL0PicIdx,
+		SpatialIdx:           NoSpatialIdx,
+	}
+
+	// libwebrtc: frame_type = (aggregation_header & kNBit) ? kVideoFrameKey : kVideoFrameDelta
+	if aggregationHeader&av1NBit != 0 {
+		header.FrameType = FrameTypeKey
+	}
+
+	return header, payload, nil
+}
+
+// assembleAV1Frame reassembles the OBU elements of the given AV1 RTP payloads
+// into a single frame in the low overhead bitstream format, where every OBU
+// carries an obu_size field.
+// OBU fragments split across packets (Y and Z bits) are joined.
+//
+// Reference: libwebrtc video_rtp_depacketizer_av1.cc AssembleFrame()
+func assembleAV1Frame(payloads [][]byte) ([]byte, error) {
+	obus, err := splitAV1OBUElements(payloads)
+	if err != nil {
+		return nil, err
+	}
+
+	totalSize := 0
+	for _, element := range obus {
+		totalSize += len(element) + av1MaxLeb128Size
+	}
+
+	frame := make([]byte, 0, totalSize)
+	for _, element := range obus {
+		frame, err = appendAV1OBUWithSize(frame, element)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return frame, nil
+}
+
+// splitAV1OBUElements returns the complete OBUs contained in the given RTP payloads.
+// Reference: libwebrtc video_rtp_depacketizer_av1.cc ParseObus()
+//
+//nolint:cyclop
+func splitAV1OBUElements(payloads [][]byte) ([][]byte, error) {
+	var obus [][]byte
+	expectContinuation := false
+
+	for _, payload := range payloads {
+		if len(payload) < av1HeaderSize {
+			return nil, errAV1ShortPacket
+		}
+
+		aggregationHeader := payload[0]
+		startsWithFragment := aggregationHeader&av1ZBit != 0
+		if startsWithFragment != expectContinuation {
+			return nil, errAV1UnexpectedFragment
+		}
+		expectContinuation = aggregationHeader&av1YBit != 0
+		numElements := int(aggregationHeader&av1WMask) >> av1WShift
+
+		payload = payload[av1HeaderSize:]
+		for elementIdx := 1; len(payload) > 0; elementIdx++ {
+			// The last of W elements has no length field. With W=0 every element has one.
+			elementSize := len(payload)
+			if elementIdx != numElements {
+				size, n, err := obu.ReadLeb128(payload)
+				if err != nil || size > uint(len(payload))-n { //nolint:gosec // G115
+					return nil, errAV1InvalidOBUElement
+				}
+				payload = payload[n:]
+				elementSize = int(size) //nolint:gosec // G115
+			}
+
+			element := payload[:elementSize]
+			payload = payload[elementSize:]
+
+			if elementIdx == 1 && startsWithFragment {
+				if len(obus) == 0 {
+					return nil, errAV1UnexpectedFragment
+				}
+				obus[len(obus)-1] = append(obus[len(obus)-1], element...)
+
+				continue
+			}
+			obus = append(obus, append([]byte(nil), element...))
+		}
+	}
+
+	if expectContinuation {
+		// The last packet of a frame must not end with an OBU fragment
+		return nil, errAV1UnexpectedFragment
+	}
+
+	return obus, nil
+}
+
+// appendAV1OBUWithSize appends an OBU to dst with obu_has_size_field set.
+// Reference: libwebrtc video_rtp_depacketizer_av1.cc CalculateObuSizes()
+func appendAV1OBUWithSize(dst, element []byte) ([]byte, error) {
+	if len(element) == 0 {
+		return nil, errAV1InvalidOBU
+	}
+
+	obuHeader := element[0]
+	headerSize := 1
+	if obuHeader&av1OBUExtensionFlag != 0 {
+		headerSize++
+	}
+	if len(element) < headerSize {
+		return nil, errAV1InvalidOBU
+	}
+
+	payload := element[headerSize:]
+	if obuHeader&av1OBUHasSizeField != 0 {
+		size, n, err := obu.ReadLeb128(payload)
+		if err != nil || size > uint(len(payload))-n { //nolint:gosec // G115
+			return nil, errAV1InvalidOBU
+		}
+		payload = payload[n : n+size]
+	}
+
+	dst = append(dst, obuHeader|av1OBUHasSizeField)
+	dst = append(dst, element[1:headerSize]...)
+	dst = append(dst, obu.WriteToLeb128(uint(len(payload)))...)
+	dst = append(dst, payload...)
+
+	return dst, nil
+}
@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package videoframe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Minimal AV1 OBUs without obu_size fields, as sent in RTP.
+var (
+	testAV1SequenceHeader = []byte{0x08, 0x00, 0x00, 0x00}
+	testAV1Frame          = []byte{0x30, 0x10, 0x20, 0x30, 0x40, 0x50}
+	testAV1FrameWithExt   = []byte{0x34, 0x28, 0xAA, 0xBB}
+)
+
+// withOBUSize returns the OBU in the low overhead bitstream format.
+func withOBUSize(element []byte, headerSize int) []byte {
+	out := []byte{element[0] | av1OBUHasSizeField}
+	out = append(out, element[1:headerSize]...)
+	out = append(out, byte(len(element)-headerSize))
+
+	return append(out, element[headerSize:]...)
+}
+
+func TestDepacketizeAV1_Header(t *testing.T) {
+	payload := append([]byte{0x18}, testAV1SequenceHeader...) // W=1 N=1
+	header, out, err := depacketizeAV1Payload(payload, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, VideoCodecAV1, header.Codec)
+	assert.True(t, header.IsFirstPacketInFrame)
+	assert.True(t, header.IsLastPacketInFrame)
+	assert.Equal(t, FrameTypeKey, header.FrameType)
+	assert.Nil(t, header.Generic)
+	assert.Equal(t, payload, out, "Aggregation header must be kept for frame assembly")
+
+	header, _, err = depacketizeAV1Payload([]byte{0x90, 0x01}, false) // Z=1 W=1
+	require.NoError(t, err)
+	assert.False(t, header.IsFirstPacketInFrame)
+	assert.Equal(t, FrameTypeDelta, header.FrameType)
+
+	_, _, err = depacketizeAV1Payload(nil, false)
+	assert.ErrorIs(t, err, errAV1ShortPacket)
+}
+
+func TestAssembleAV1Frame_AggregatedOBUs(t *testing.T) {
+	// W=2: the first element has a length field, the last one does not
+	payload := []byte{0x28, byte(len(testAV1SequenceHeader))}
+	payload = append(payload, testAV1SequenceHeader...)
+	payload = append(payload, testAV1Frame...)
+
+	frame, err := assembleAV1Frame([][]byte{payload})
+	require.NoError(t, err)
+
+	want := append(withOBUSize(testAV1SequenceHeader, 1), withOBUSize(testAV1Frame, 1)...)
+	assert.Equal(t, want, frame)
+
+	// W=0: every element has a length field
+	payload = []byte{0x00, byte(len(testAV1SequenceHeader))}
+	payload = append(payload, testAV1SequenceHeader...)
+	payload = append(payload, byte(len(testAV1FrameWithExt)))
+	payload = append(payload, testAV1FrameWithExt...)
+
+	frame, err = assembleAV1Frame([][]byte{payload})
+	require.NoError(t, err)
+
+	want = append(withOBUSize(testAV1SequenceHeader, 1), withOBUSize(testAV1FrameWithExt, 2)...)
+	assert.Equal(t, want, frame)
+}
+
+func TestAssembleAV1Frame_Fragments(t *testing.T) {
+	first := append([]byte{0x50}, testAV1Frame[:2]...)   // Y=1 W=1
+	middle := append([]byte{0xD0}, testAV1Frame[2:4]...) // Z=1 Y=1 W=1
+	last := append([]byte{0x90}, testAV1Frame[4:]...)    // Z=1 W=1
+
+	frame, err := assembleAV1Frame([][]byte{first, middle, last})
+	require.NoError(t, err)
+	assert.Equal(t, withOBUSize(testAV1Frame, 1), frame)
+
+	_, err = assembleAV1Frame([][]byte{middle, last})
+	assert.ErrorIs(t, err, errAV1UnexpectedFragment, "Frame must not start with a continuation")
+
+	_, err = assembleAV1Frame([][]byte{first, middle})
+	assert.ErrorIs(t, err, errAV1UnexpectedFragment, "Frame must not end with a fragment")
+
+	_, err = assembleAV1Frame([][]byte{first, append([]byte{0x10}, testAV1Frame[2:]...)})
+	assert.ErrorIs(t, err, errAV1UnexpectedFragment, "Continuation must be signaled with Z")
+}
+
+func TestAssembleAV1Frame_ExistingSizeField(t *testing.T) {
+	// OBU with obu_size=2 followed by trailing bytes that are not part of the OBU
+	element := []byte{0x32, 0x02, 0xAA, 0xBB, 0xCC}
+	frame, err := assembleAV1Frame([][]byte{append([]byte{0x10}, element...)})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x32, 0x02, 0xAA, 0xBB}, frame)
+
+	_, err = assembleAV1Frame([][]byte{{0x10, 0x32, 0x05, 0xAA}})
+	assert.ErrorIs(t, err, errAV1InvalidOBU)
+}
+
+func TestAssembleAV1Frame_InvalidElementLength(t *testing.T) {
+	_, err := assembleAV1Frame([][]byte{{0x00, 0x10, 0x08}})
+	assert.ErrorIs(t, err, errAV1InvalidOBUElement)
+
+	_, err = assembleAV1Frame([][]byte{{}})
+	assert.ErrorIs(t, err, errAV1ShortPacket)
+}
@@ -0,0 +1,477 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package videoframe
+
+// dependencyDescriptorURI is the URI of the Dependency Descriptor RTP header extension.
+// Reference: AV1 RTP Payload Format Appendix A
+const dependencyDescriptorURI = "https://aomediacodec.github.io/av1-rtp-spec/#dependency-descriptor-rtp-header-extension"
+
+// maxDDTemplates is the maximum number of frame dependency templates.
+// Reference: libwebrtc api/transport/rtp/dependency_descriptor.h kMaxTemplates
+const maxDDTemplates = 64
+
+// ddMandatoryFieldsSize is the size of the mandatory descriptor fields in bytes.
+const ddMandatoryFieldsSize = 3
+
+// DecodeTargetIndication describes how a frame relates to a decode target.
+// Reference: AV1 RTP Payload Format Appendix A.8.3, libwebrtc dependency_descriptor.h
+type DecodeTargetIndication uint8
+
+const (
+	// DecodeTargetNotPresent indicates the frame is not part of the decode target.
+	DecodeTargetNotPresent DecodeTargetIndication = iota
+	// DecodeTargetDiscardable indicates no frame of the decode target depends on this frame.
+	DecodeTargetDiscardable
+	// DecodeTargetSwitch indicates the decode target can be switched to at this frame.
+	DecodeTargetSwitch
+	// DecodeTargetRequired indicates the frame is required to decode the decode target.
+	DecodeTargetRequired
+)
+
+// RTPVideoHeaderGeneric contains the frame dependency information signaled
+// in the Dependency Descriptor RTP header extension.
+// This structure is similar to libwebrtc's RTPVideoHeader::GenericDescriptorInfo.
+type RTPVideoHeaderGeneric struct {
+	// FrameID is the unwrapped frame_number.
+	FrameID int64
+
+	// SpatialIdx is the spatial layer of the frame.
+	SpatialIdx int
+
+	// TemporalIdx is the temporal layer of the frame.
+	TemporalIdx int
+
+	// Dependencies are the frame IDs this frame depends on.
+	Dependencies []int64
+
+	// DecodeTargetIndications holds the decode target indication for each decode target.
+	DecodeTargetIndications []DecodeTargetIndication
+
+	// ChainDiffs holds, for each chain, the difference between this frame's ID and
+	// the previous frame in the chain. 0 means there is no previous frame in the chain.
+	ChainDiffs []int
+
+	// DecodeTargetProtectedByChain maps each decode target to the chain protecting it.
+	DecodeTargetProtectedByChain []int
+
+	// ActiveDecodeTargetsBitmask has a bit set for every active decode target.
+	// 0 when not signaled with this frame.
+	ActiveDecodeTargetsBitmask uint32
+}
+
+// frameDependencyTemplate describes the dependencies of a frame.
+// Reference: libwebrtc dependency_descriptor.h FrameDependencyTemplate
+type frameDependencyTemplate struct {
+	spatialID               int
+	temporalID              int
+	decodeTargetIndications []DecodeTargetIndication
+	frameDiffs              []int
+	chainDiffs              []int
+}
+
+// renderResolution is the maximum render resolution of a spatial layer.
+type renderResolution struct {
+	width  uint32
+	height uint32
+}
+
+// frameDependencyStructure is the template dependency structure, sent with keyframes.
+// Reference: libwebrtc dependency_descriptor.h FrameDependencyStructure
+type frameDependencyStructure struct {
+	structureID                  int
+	numDecodeTargets             int
+	numChains                    int
+	decodeTargetProtectedByChain []int
+	resolutions                  []renderResolution
+	templates                    []frameDependencyTemplate
+}
+
+// dependencyDescriptor is a parsed Dependency Descriptor RTP header extension.
+// Reference: libwebrtc dependency_descriptor.h DependencyDescriptor
+type dependencyDescriptor struct {
+	firstPacketInFrame         bool
+	lastPacketInFrame          bool
+	frameNumber                uint16
+	frameDependencies          frameDependencyTemplate
+	resolution                 *renderResolution
+	activeDecodeTargetsBitmask *uint32
+	attachedStructure          *frameDependencyStructure
+}
+
+// ddBitReader reads bit fields from a Dependency Descriptor.
+type ddBitReader struct {
+	data   []byte
+	bitPos int
+}
+
+// readBits reads n bits (n <= 32) as an unsigned integer.
+func (r *ddBitReader) readBits(n int) (uint32, error) {
+	if r.bitPos+n > len(r.data)*8 {
+		return 0, errDDShortBuffer
+	}
+
+	var v uint32
+	for i := 0; i < n; i++ {
+		bit := r.data[r.bitPos/8] >> (7 - r.bitPos%8) & 1
+		v = v<<1 | uint32(bit)
+		r.bitPos++
+	}
+
+	return v, nil
+}
+
+// readBool reads a single bit flag.
+func (r *ddBitReader) readBool() (bool, error) {
+	v, err := r.readBits(1)
+
+	return v == 1, err
+}
+
+// readNonSymmetric reads a non-symmetric unsigned value ns(n) in [0, n).
+// Reference: AV1 Bitstream Specification Section 4.10.7
+func (r *ddBitReader) readNonSymmetric(n uint32) (uint32, error) {
+	w := 0
+	for x := n; x != 0; x >>= 1 {
+		w++
+	}
+	m := uint32(1)<<w - n
+
+	v, err := r.readBits(w - 1)
+	if err != nil || v < m {
+		return v, err
+	}
+
+	extraBit, err := r.readBits(1)
+	if err != nil {
+		return 0, err
+	}
+
+	return v<<1 - m + extraBit, nil
+}
+
+// parseDependencyDescriptor parses a Dependency Descriptor header extension.
+// structure is the latest frame dependency structure received on the stream,
+// it is ignored when the descriptor carries its own structure.
+// Reference: libwebrtc rtp_dependency_descriptor_reader.cc
+//
+//nolint:cyclop
+func parseDependencyDescriptor(data []byte, structure *frameDependencyStructure) (*dependencyDescriptor, error) {
+	if len(data) < ddMandatoryFieldsSize {
+		return nil, errDDShortBuffer
+	}
+
+	r := &ddBitReader{data: data}
+	dd := &dependencyDescriptor{}
+
+	// mandatory_descriptor_fields()
+	startOfFrame, _ := r.readBool()
+	endOfFrame, _ := r.readBool()
+	templateID, _ := r.readBits(6)
+	frameNumber, _ := r.readBits(16)
+	dd.firstPacketInFrame = startOfFrame
+	dd.lastPacketInFrame = endOfFrame
+	dd.frameNumber = uint16(frameNumber) //nolint:gosec // G115
+
+	// extended_descriptor_fields()
+	var structurePresent, activeDecodeTargetsPresent, customDTIs, customFDiffs, customChains bool
+	if len(data) > ddMandatoryFieldsSize {
+		flags, err := r.readBits(5)
+		if err != nil {
+			return nil, err
+		}
+		structurePresent = flags&0x10 != 0
+		activeDecodeTargetsPresent = flags&0x08 != 0
+		customDTIs = flags&0x04 != 0
+		customFDiffs = flags&0x02 != 0
+		customChains = flags&0x01 != 0
+	}
+
+	if structurePresent {
+		attached, err := readTemplateDependencyStructure(r)
+		if err != nil {
+			return nil, err
+		}
+		dd.attachedStructure = attached
+		structure = attached
+
+		bitmask := uint32(1)<<attached.numDecodeTargets - 1
+		dd.activeDecodeTargetsBitmask = &bitmask
+	}
+	if structure == nil {
+		return nil, errDDMissingStructure
+	}
+
+	if activeDecodeTargetsPresent {
+		bitmask, err := r.readBits(structure.numDecodeTargets)
+		if err != nil {
+			return nil, err
+		}
+		dd.activeDecodeTargetsBitmask = &bitmask
+	}
+
+	// frame_dependency_definition()
+	templateIndex := (int(templateID) + maxDDTemplates - structure.structureID) % maxDDTemplates
+	if templateIndex >= len(structure.templates) {
+		return nil, errDDInvalidTemplate
+	}
+	tmpl := structure.templates[templateIndex]
+	dd.frameDependencies = frameDependencyTemplate{
+		spatialID:               tmpl.spatialID,
+		temporalID:              tmpl.temporalID,
+		decodeTargetIndications: append([]DecodeTargetIndication(nil), tmpl.decodeTargetIndications...),
+		frameDiffs:              append([]int(nil), tmpl.frameDiffs...),
+		chainDiffs:              append([]int(nil), tmpl.chainDiffs...),
+	}
+
+	if customDTIs {
+		for i := range dd.frameDependencies.decodeTargetIndications {
+			dti, err := r.readBits(2)
+			if err != nil {
+				return nil, err
+			}
+			dd.frameDependencies.decodeTargetIndications[i] = DecodeTargetIndication(dti)
+		}
+	}
+
+	if customFDiffs {
+		frameDiffs, err := readFrameFDiffs(r)
+		if err != nil {
+			return nil, err
+		}
+		dd.frameDependencies.frameDiffs = frameDiffs
+	}
+
+	if customChains {
+		for i := range dd.frameDependencies.chainDiffs {
+			chainDiff, err := r.readBits(8)
+			if err != nil {
+				return nil, err
+			}
+			dd.frameDependencies.chainDiffs[i] = int(chainDiff)
+		}
+	}
+
+	// Every spatial layer has a resolution if the structure has any
+	// Reference: libwebrtc rtp_dependency_descriptor_reader.cc ReadFrameDependencyDefinition()
+	if len(structure.resolutions) > 0 && dd.frameDependencies.spatialID < len(structure.resolutions) {
+		resolution := structure.resolutions[dd.frameDependencies.spatialID]
+		dd.resolution = &resolution
+	}
+
+	return dd, nil
+}
+
+// readTemplateDependencyStructure reads template_dependency_structure().
+// Reference: AV1 RTP Payload Format Appendix A.8.2
+//
+//nolint:cyclop
+func readTemplateDependencyStructure(r *ddBitReader) (*frameDependencyStructure, error) {
+	structureID, err := r.readBits(6)
+	if err != nil {
+		return nil, err
+	}
+	numDecodeTargetsMinusOne, err := r.readBits(5)
+	if err != nil {
+		return nil, err
+	}
+
+	structure := &frameDependencyStructure{
+		structureID:      int(structureID),
+		numDecodeTargets: int(numDecodeTargetsMinusOne) + 1,
+	}
+
+	// template_layers()
+	spatialID, temporalID := 0, 0
+	for {
+		if len(structure.templates) == maxDDTemplates {
+			return nil, errDDTooManyTemplates
+		}
+		structure.templates = append(structure.templates, frameDependencyTemplate{
+			spatialID:  spatialID,
+			temporalID: temporalID,
+		})
+
+		nextLayerIdc, err := r.readBits(2)
+		if err != nil {
+			return nil, err
+		}
+		if nextLayerIdc == 3 {
+			break
+		}
+		switch nextLayerIdc {
+		case 1:
+			temporalID++
+		case 2:
+			temporalID = 0
+			spatialID++
+		}
+	}
+
+	// template_dtis()
+	for i := range structure.templates {
+		dtis := make([]DecodeTargetIndication, structure.numDecodeTargets)
+		for j := range dtis {
+			dti, err := r.readBits(2)
+			if err != nil {
+				return nil, err
+			}
+			dtis[j] = DecodeTargetIndication(dti)
+		}
+		structure.templates[i].decodeTargetIndications = dtis
+	}
+
+	// template_fdiffs()
+	for i := range structure.templates {
+		for {
+			fdiffFollows, err := r.readBool()
+			if err != nil {
+				return nil, err
+			}
+			if !fdiffFollows {
+				break
+			}
+			fdiffMinusOne, err := r.readBits(4)
+			if err != nil {
+				return nil, err
+			}
+			structure.templates[i].frameDiffs = append(structure.templates[i].frameDiffs, int(fdiffMinusOne)+1)
+		}
+	}
+
+	// template_chains()
+	numChains, err := r.readNonSymmetric(uint32(structure.numDecodeTargets) + 1) //nolint:gosec // G115
+	if err != nil {
+		return nil, err
+	}
+	structure.numChains = int(numChains)
+	if numChains > 0 {
+		structure.decodeTargetProtectedByChain = make([]int, structure.numDecodeTargets)
+		for i := range structure.decodeTargetProtectedByChain {
+			chain, err := r.readNonSymmetric(numChains)
+			if err != nil {
+				return nil, err
+			}
+			structure.decodeTargetProtectedByChain[i] = int(chain)
+		}
+		for i := range structure.templates {
+			chainDiffs := make([]int, numChains)
+			for j := range chainDiffs {
+				chainDiff, err := r.readBits(4)
+				if err != nil {
+					return nil, err
+				}
+				chainDiffs[j] = int(chainDiff)
+			}
+			structure.templates[i].chainDiffs = chainDiffs
+		}
+	}
+
+	// render_resolutions()
+	resolutionsPresent, err := r.readBool()
+	if err != nil {
+		return nil, err
+	}
+	if resolutionsPresent {
+		numSpatialLayers := structure.templates[len(structure.templates)-1].spatialID + 1
+		for i := 0; i < numSpatialLayers; i++ {
+			widthMinusOne, err := r.readBits(16)
+			if err != nil {
+				return nil, err
+			}
+			heightMinusOne, err := r.readBits(16)
+			if err != nil {
+				return nil, err
+			}
+			structure.resolutions = append(structure.resolutions, renderResolution{
+				width:  widthMinusOne + 1,
+				height: heightMinusOne + 1,
+			})
+		}
+	}
+
+	return structure, nil
+}
+
+// readFrameFDiffs reads frame_fdiffs().
+// Reference: AV1 RTP Payload Format Appendix A.8.4
+func readFrameFDiffs(r *ddBitReader) ([]int, error) {
+	var frameDiffs []int
+	for {
+		nextFDiffSize, err := r.readBits(2)
+		if err != nil {
+			return nil, err
+		}
+		if nextFDiffSize == 0 {
+			return frameDiffs, nil
+		}
+		fdiffMinusOne, err := r.readBits(4 * int(nextFDiffSize))
+		if err != nil {
+			return nil, err
+		}
+		frameDiffs = append(frameDiffs, int(fdiffMinusOne)+1)
+	}
+}
+
+// dependencyDescriptorReceiver keeps the per-stream state needed to interpret
+// Dependency Descriptors: the latest frame dependency structure and the frame_number unwrapper.
+// Reference: libwebrtc rtp_video_stream_receiver2.cc ParseGenericDependenciesExtension()
+type dependencyDescriptorReceiver struct {
+	structure        *frameDependencyStructure
+	structureFrameID int64
+	frameIDUnwrapper sequenceUnwrapper
+}
+
+// applyToHeader parses a Dependency Descriptor and applies it to the packet's video header.
+// Frame boundaries, frame type, layer indices, resolution and the generic frame
+// dependency information are taken from the descriptor.
+// Returns false if the packet should be dropped because the descriptor cannot be interpreted.
+func (d *dependencyDescriptorReceiver) applyToHeader(header *RTPVideoHeader, ext []byte) bool {
+	dd, err := parseDependencyDescriptor(ext, d.structure)
+	if err != nil {
+		// Either invalid, or a packet from before the relevant structure arrived
+		return false
+	}
+
+	frameID := d.frameIDUnwrapper.unwrap(dd.frameNumber)
+
+	// Dependency descriptor with a structure is the first packet of a keyframe
+	if dd.attachedStructure != nil {
+		if d.structure != nil && d.structureFrameID > frameID {
+			// Keyframe older than the current structure
+			return false
+		}
+		d.structure = dd.attachedStructure
+		d.structureFrameID = frameID
+		header.FrameType = FrameTypeKey
+	} else {
+		header.FrameType = FrameTypeDelta
+	}
+
+	generic := &RTPVideoHeaderGeneric{
+		FrameID:                      frameID,
+		SpatialIdx:                   dd.frameDependencies.spatialID,
+		TemporalIdx:                  dd.frameDependencies.temporalID,
+		DecodeTargetIndications:      dd.frameDependencies.decodeTargetIndications,
+		ChainDiffs:                   dd.frameDependencies.chainDiffs,
+		DecodeTargetProtectedByChain: d.structure.decodeTargetProtectedByChain,
+	}
+	for _, fdiff := range dd.frameDependencies.frameDiffs {
+		generic.Dependencies = append(generic.Dependencies, frameID-int64(fdiff))
+	}
+	if dd.activeDecodeTargetsBitmask != nil {
+		generic.ActiveDecodeTargetsBitmask = *dd.activeDecodeTargetsBitmask
+	}
+	header.Generic = generic
+
+	header.IsFirstPacketInFrame = dd.firstPacketInFrame
+	header.IsLastPacketInFrame = header.IsLastPacketInFrame || dd.lastPacketInFrame
+	header.SpatialIdx = int8(dd.frameDependencies.spatialID)   //nolint:gosec // G115
+	header.TemporalIdx = int8(dd.frameDependencies.temporalID) //nolint:gosec // G115
+	if dd.resolution != nil {
+		header.Width = dd.resolution.width
+		header.Height = dd.resolution.height
+	}
+
+	return true
+}
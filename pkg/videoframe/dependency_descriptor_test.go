@@ -0,0 +1,260 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package videoframe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ddBitWriter builds Dependency Descriptors for tests.
+type ddBitWriter struct {
+	data   []byte
+	bitPos int
+}
+
+func (w *ddBitWriter) writeBits(v uint32, n int) {
+	for i := n - 1; i >= 0; i-- {
+		if w.bitPos%8 == 0 {
+			w.data = append(w.data, 0)
+		}
+		if v>>i&1 == 1 {
+			w.data[len(w.data)-1] |= 1 << (7 - w.bitPos%8)
+		}
+		w.bitPos++
+	}
+}
+
+func (w *ddBitWriter) writeMandatory(first, last bool, templateID uint32, frameNumber uint16) {
+	w.writeBits(boolBit(first), 1)
+	w.writeBits(boolBit(last), 1)
+	w.writeBits(templateID, 6)
+	w.writeBits(uint32(frameNumber), 16)
+}
+
+func boolBit(b bool) uint32 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+// writeTestL1T2Structure writes a structure with one spatial and two temporal layers:
+//
+//	template 0: S0T0 keyframe, no references
+//	template 1: S0T0 delta, references the previous T0 frame (fdiff 2)
+//	template 2: S0T1 delta, references the previous frame (fdiff 1)
+//
+// Decode target 0 is T0 only, decode target 1 is T0+T1. Both are protected by chain 0.
+// Resolution of spatial layer 0 is 640x360.
+func (w *ddBitWriter) writeTestL1T2Structure() {
+	w.writeBits(0, 6) // template_id_offset
+	w.writeBits(1, 5) // dt_cnt_minus_one
+
+	// template_layers(): same layer, next temporal layer, end
+	w.writeBits(0, 2)
+	w.writeBits(1, 2)
+	w.writeBits(3, 2)
+
+	// template_dtis()
+	for _, dti := range []DecodeTargetIndication{
+		DecodeTargetSwitch, DecodeTargetSwitch,
+		DecodeTargetRequired, DecodeTargetRequired,
+		DecodeTargetNotPresent, DecodeTargetDiscardable,
+	} {
+		w.writeBits(uint32(dti), 2)
+	}
+
+	// template_fdiffs()
+	w.writeBits(0, 1)
+	w.writeBits(1, 1)
+	w.writeBits(1, 4) // fdiff 2
+	w.writeBits(0, 1)
+	w.writeBits(1, 1)
+	w.writeBits(0, 4) // fdiff 1
+	w.writeBits(0, 1)
+
+	// template_chains(): chain_cnt=1 as ns(3), protected_by_chain ns(1) needs no bits
+	w.writeBits(1, 1)
+	w.writeBits(0, 1)
+	w.writeBits(0, 4)
+	w.writeBits(2, 4)
+	w.writeBits(1, 4)
+
+	// render_resolutions()
+	w.writeBits(1, 1)
+	w.writeBits(639, 16)
+	w.writeBits(359, 16)
+}
+
+func testDDKeyframe(frameNumber uint16) []byte {
+	w := &ddBitWriter{}
+	w.writeMandatory(true, true, 0, frameNumber)
+	w.writeBits(0x10, 5) // template_dependency_structure_present_flag
+	w.writeTestL1T2Structure()
+
+	return w.data
+}
+
+func testDDDelta(templateID uint32, frameNumber uint16) []byte {
+	w := &ddBitWriter{}
+	w.writeMandatory(true, true, templateID, frameNumber)
+
+	return w.data
+}
+
+func TestParseDependencyDescriptor_Structure(t *testing.T) {
+	dd, err := parseDependencyDescriptor(testDDKeyframe(100), nil)
+	require.NoError(t, err)
+
+	assert.True(t, dd.firstPacketInFrame)
+	assert.True(t, dd.lastPacketInFrame)
+	assert.Equal(t, uint16(100), dd.frameNumber)
+
+	structure := dd.attachedStructure
+	require.NotNil(t, structure)
+	assert.Equal(t, 2, structure.numDecodeTargets)
+	assert.Equal(t, 1, structure.numChains)
+	assert.Equal(t, []int{0, 0}, structure.decodeTargetProtectedByChain)
+	require.Len(t, structure.templates, 3)
+	assert.Equal(t, 1, structure.templates[2].temporalID)
+	assert.Equal(t, []int{2}, structure.templates[1].frameDiffs)
+	assert.Equal(t, []int{1}, structure.templates[2].chainDiffs)
+
+	assert.Equal(t, []DecodeTargetIndication{DecodeTargetSwitch, DecodeTargetSwitch},
+		dd.frameDependencies.decodeTargetIndications)
+	assert.Empty(t, dd.frameDependencies.frameDiffs)
+	assert.Equal(t, []int{0}, dd.frameDependencies.chainDiffs)
+
+	require.NotNil(t, dd.resolution)
+	assert.Equal(t, renderResolution{width: 640, height: 360}, *dd.resolution)
+	require.NotNil(t, dd.activeDecodeTargetsBitmask)
+	assert.Equal(t, uint32(0b11), *dd.activeDecodeTargetsBitmask)
+}
+
+func TestParseDependencyDescriptor_UsesPreviousStructure(t *testing.T) {
+	key, err := parseDependencyDescriptor(testDDKeyframe(100), nil)
+	require.NoError(t, err)
+
+	dd, err := parseDependencyDescriptor(testDDDelta(2, 101), key.attachedStructure)
+	require.NoError(t, err)
+
+	assert.Nil(t, dd.attachedStructure)
+	assert.Nil(t, dd.activeDecodeTargetsBitmask)
+	assert.Equal(t, 1, dd.frameDependencies.temporalID)
+	assert.Equal(t, []int{1}, dd.frameDependencies.frameDiffs)
+	assert.Equal(t, []DecodeTargetIndication{DecodeTargetNotPresent, DecodeTargetDiscardable},
+		dd.frameDependencies.decodeTargetIndications)
+}
+
+func TestParseDependencyDescriptor_CustomFields(t *testing.T) {
+	key, err := parseDependencyDescriptor(testDDKeyframe(100), nil)
+	require.NoError(t, err)
+
+	w := &ddBitWriter{}
+	w.writeMandatory(true, false, 1, 104)
+	w.writeBits(0b01111, 5) // active decode targets, custom dtis, fdiffs and chains
+	w.writeBits(0b01, 2)    // active_decode_targets_bitmask
+	w.writeBits(uint32(DecodeTargetRequired), 2)
+	w.writeBits(uint32(DecodeTargetNotPresent), 2)
+	w.writeBits(1, 2) // next_fdiff_size
+	w.writeBits(3, 4) // fdiff 4
+	w.writeBits(2, 2)
+	w.writeBits(19, 8) // fdiff 20
+	w.writeBits(0, 2)
+	w.writeBits(4, 8) // frame_chain_fdiff
+
+	dd, err := parseDependencyDescriptor(w.data, key.attachedStructure)
+	require.NoError(t, err)
+
+	assert.False(t, dd.lastPacketInFrame)
+	require.NotNil(t, dd.activeDecodeTargetsBitmask)
+	assert.Equal(t, uint32(0b01), *dd.activeDecodeTargetsBitmask)
+	assert.Equal(t, []DecodeTargetIndication{DecodeTargetRequired, DecodeTargetNotPresent},
+		dd.frameDependencies.decodeTargetIndications)
+	assert.Equal(t, []int{4, 20}, dd.frameDependencies.frameDiffs)
+	assert.Equal(t, []int{4}, dd.frameDependencies.chainDiffs)
+}
+
+func TestParseDependencyDescriptor_Errors(t *testing.T) {
+	key, err := parseDependencyDescriptor(testDDKeyframe(100), nil)
+	require.NoError(t, err)
+
+	_, err = parseDependencyDescriptor([]byte{0x80, 0x00}, key.attachedStructure)
+	assert.ErrorIs(t, err, errDDShortBuffer)
+
+	_, err = parseDependencyDescriptor(testDDDelta(1, 101), nil)
+	assert.ErrorIs(t, err, errDDMissingStructure)
+
+	_, err = parseDependencyDescriptor(testDDDelta(3, 101), key.attachedStructure)
+	assert.ErrorIs(t, err, errDDInvalidTemplate)
+
+	_, err = parseDependencyDescriptor(testDDKeyframe(100)[:6], nil)
+	assert.ErrorIs(t, err, errDDShortBuffer)
+}
+
+func TestDDBitReader_NonSymmetric(t *testing.T) {
+	// ns(5): w=3, m=3. Values 0-2 use 2 bits, values 3-4 use 3 bits.
+	tests := []struct {
+		bits  uint32
+		nbits int
+		want  uint32
+	}{
+		{bits: 0b00, nbits: 2, want: 0},
+		{bits: 0b10, nbits: 2, want: 2},
+		{bits: 0b110, nbits: 3, want: 3},
+		{bits: 0b111, nbits: 3, want: 4},
+	}
+
+	for _, tt := range tests {
+		w := &ddBitWriter{}
+		w.writeBits(tt.bits, tt.nbits)
+		r := &ddBitReader{data: w.data}
+
+		got, err := r.readNonSymmetric(5)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got)
+		assert.Equal(t, tt.nbits, r.bitPos)
+	}
+}
+
+func TestDependencyDescriptorReceiver_ApplyToHeader(t *testing.T) {
+	receiver := &dependencyDescriptorReceiver{}
+
+	// Delta frame before any structure is dropped
+	header := &RTPVideoHeader{Codec: VideoCodecAV1}
+	assert.False(t, receiver.applyToHeader(header, testDDDelta(1, 65534)))
+
+	header = &RTPVideoHeader{Codec: VideoCodecAV1}
+	require.True(t, receiver.applyToHeader(header, testDDKeyframe(65534)))
+	assert.Equal(t, FrameTypeKey, header.FrameType)
+	assert.True(t, header.IsFirstPacketInFrame)
+	assert.True(t, header.IsLastPacketInFrame)
+	assert.Equal(t, uint32(640), header.Width)
+	assert.Equal(t, uint32(360), header.Height)
+	require.NotNil(t, header.Generic)
+	keyFrameID := header.Generic.FrameID
+	assert.Empty(t, header.Generic.Dependencies)
+
+	// frame_number wraps
+	header = &RTPVideoHeader{Codec: VideoCodecAV1}
+	require.True(t, receiver.applyToHeader(header, testDDDelta(1, 0)))
+	assert.Equal(t, FrameTypeDelta, header.FrameType)
+	assert.Equal(t, keyFrameID+2, header.Generic.FrameID)
+	assert.Equal(t, []int64{keyFrameID}, header.Generic.Dependencies)
+	assert.Equal(t, []int{2}, header.Generic.ChainDiffs)
+	assert.Equal(t, []int{0, 0}, header.Generic.DecodeTargetProtectedByChain)
+
+	header = &RTPVideoHeader{Codec: VideoCodecAV1}
+	require.True(t, receiver.applyToHeader(header, testDDDelta(2, 1)))
+	assert.Equal(t, int8(1), header.TemporalIdx)
+	assert.Equal(t, []int64{keyFrameID + 2}, header.Generic.Dependencies)
+
+	// Keyframe older than the current structure is dropped
+	header = &RTPVideoHeader{Codec: VideoCodecAV1}
+	assert.False(t, receiver.applyToHeader(header, testDDKeyframe(65533)))
+}
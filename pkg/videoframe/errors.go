@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package videoframe
+
+import "errors"
+
+var (
+	errH264ShortPacket          = errors.New("H.264 packet too short")
+	errH264UnsupportedNaluType  = errors.New("unsupported H.264 packetization type")
+	errH264InvalidSTAPALength   = errors.New("invalid H.264 STAP-A NAL unit length")
+	errH264ExpGolombOutOfBounds = errors.New("exp-Golomb code out of bounds")
+
+	errH265ShortPacket         = errors.New("H.265 packet too short")
+	errH265UnsupportedNaluType = errors.New("unsupported H.265 packetization type")
+	errH265InvalidAPLength     = errors.New("invalid H.265 aggregation packet NAL unit length")
+
+	errAV1ShortPacket        = errors.New("AV1 packet too short")
+	errAV1InvalidOBUElement  = errors.New("invalid AV1 OBU element length")
+	errAV1UnexpectedFragment = errors.New("unexpected AV1 OBU fragment")
+	errAV1InvalidOBU         = errors.New("invalid AV1 OBU")
+	errDDShortBuffer         = errors.New("dependency descriptor too short")
+	errDDMissingStructure    = errors.New("dependency descriptor without frame dependency structure")
+	errDDInvalidTemplate     = errors.New("dependency descriptor references unknown template")
+	errDDTooManyTemplates    = errors.New("dependency descriptor has too many templates")
+
+	errFrameBufferClosed      = errors.New("frame buffer closed")
+	errFrameBufferDisabled    = errors.New("frame buffer not enabled")
+	errInvalidFrameBufferSize = errors.New("frame buffer size must be positive")
+	errInvalidPlayoutDelay    = errors.New("invalid playout delay bounds")
+	errUnknownStream          = errors.New("unknown stream")
+
+	errInvalidKeyFrameRequestMethod   = errors.New("invalid keyframe request method")
+	errInvalidUndecodableTimeout      = errors.New("undecodable timeout must be positive")
+	errInvalidKeyFrameRequestInterval = errors.New("keyframe request interval must not be negative")
+	errKeyFrameRequestsDisabled       = errors.New("keyframe requests not enabled")
+
+	errInvalidFrameQueueSize   = errors.New("frame queue size must be positive")
+	errInvalidFrameQueuePolicy = errors.New("invalid frame queue policy")
+)
@@ -5,6 +5,7 @@ package videoframe
 
 import (
 	"sync/atomic"
+	"time"
 )
 
 // EncodedFrame represents a complete video frame assembled from RTP packets.
@@ -42,11 +43,22 @@ type EncodedFrame struct {
 	// Height is the frame height in pixels (if available).
 	Height uint32
 
+	// SpatialIdx is the spatial layer index of this frame. -1 if not present.
+	SpatialIdx int8
+
 	// NumReferences is the number of frames this frame references.
 	NumReferences int
 
 	// References contains frame IDs that this frame references.
 	References [5]int64
+
+	// IsLastSpatialLayer indicates this is the last frame of its temporal unit,
+	// i.e. the last packet of the frame had the RTP marker bit set.
+	IsLastSpatialLayer bool
+
+	// RenderTime is the local time at which the frame should be rendered.
+	// It is set by FrameBuffer when the frame is released and is zero otherwise.
+	RenderTime time.Time
 }
 
 // VideoFrameAssembler assembles complete video frames from packets.
@@ -103,11 +115,16 @@ func (a *VideoFrameAssembler) AssembleFrame(packets []*BufferedPacket) *EncodedF
 		LastSeqNumUnwrapped:  lastPkt.SequenceNumber,
 		Timestamp:            firstPkt.Timestamp,
 		Data:                 data,
+		SpatialIdx:           NoSpatialIdx,
+		IsLastSpatialLayer:   lastPkt.MarkerBit,
 	}
 
-	// Extract frame type from first packet's VideoHeader
+	// Extract frame type, spatial layer and resolution from first packet's VideoHeader
 	if firstPkt.VideoHeader != nil {
 		frame.FrameType = firstPkt.VideoHeader.FrameType
+		frame.SpatialIdx = firstPkt.VideoHeader.SpatialIdx
+		frame.Width = firstPkt.VideoHeader.Width
+		frame.Height = firstPkt.VideoHeader.Height
 	}
 
 	return frame
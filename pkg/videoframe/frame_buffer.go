@@ -0,0 +1,419 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package videoframe
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultMaxFramesBuffered is the default maximum number of frames in a FrameBuffer.
+	// Reference: libwebrtc video/video_stream_buffer_controller.cc kMaxFramesBuffered
+	defaultMaxFramesBuffered = 800
+
+	// decodedHistorySize is the number of decoded frame IDs remembered to resolve references.
+	// Reference: libwebrtc modules/video_coding/frame_buffer3.cc kMaxFramesHistory
+	decodedHistorySize = 1 << 13
+
+	// maxAllowedFrameDelay is how late a frame may be before it is dropped
+	// in favor of a later decodable frame.
+	// Reference: libwebrtc video/frame_decode_timing.cc kMaxAllowedFrameDelay
+	maxAllowedFrameDelay = 5 * time.Millisecond
+)
+
+// FrameBuffer orders frames with resolved references for decoding.
+// This is similar to libwebrtc's FrameBuffer (modules/video_coding/frame_buffer3.cc)
+// combined with the frame release timing of VideoStreamBufferController.
+//
+// Frames are grouped in temporal units (frames sharing an RTP timestamp, e.g. the
+// spatial layers of a picture). A temporal unit is decodable when it is complete and
+// all of its references outside the unit have been released. Temporal units are
+// released in frame ID order:
+//   - When the next decodable unit is released, all earlier frames are dropped
+//   - Frames referencing a frame that was skipped can never be decoded and are dropped
+//   - A unit is released at its render time minus the render delay. A unit that is
+//     already late is dropped if a later unit is decodable
+//
+// The render time of a frame is derived from its RTP timestamp, the inter-frame delay
+// variation and the resulting jitter estimate, see frameTiming.
+//
+// This struct is safe for concurrent use.
+type FrameBuffer struct {
+	mu sync.Mutex
+
+	maxFrames int
+	timing    *frameTiming
+	now       func() time.Time
+
+	// frames are the buffered frames sorted by ID.
+	frames []*EncodedFrame
+	// ready are the frames of the released temporal unit not yet returned by NextFrame.
+	ready []*EncodedFrame
+
+	decoded              map[int64]struct{}
+	lastDecodedID        int64
+	lastDecodedTimestamp uint32
+	hasDecoded           bool
+
+	droppedFrames int
+
+	notify    chan struct{}
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// NewFrameBuffer creates a new FrameBuffer.
+func NewFrameBuffer(opts ...FrameBufferOption) (*FrameBuffer, error) {
+	b := &FrameBuffer{
+		maxFrames: defaultMaxFramesBuffered,
+		timing:    newFrameTiming(),
+		now:       time.Now,
+		decoded:   make(map[int64]struct{}),
+		notify:    make(chan struct{}, 1),
+		closed:    make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		if err := opt(b); err != nil {
+			return nil, err
+		}
+	}
+
+	return b, nil
+}
+
+// InsertFrame adds a frame with resolved references to the buffer.
+// Returns false if the frame was rejected: it is a duplicate, it is older than the last
+// released frame, one of its references can never be decoded, or the buffer is full.
+// Reference: libwebrtc frame_buffer3.cc InsertFrame()
+//
+//nolint:cyclop
+func (b *FrameBuffer) InsertFrame(frame *EncodedFrame) bool {
+	if frame == nil {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.validReferences(frame) {
+		return false
+	}
+
+	if b.hasDecoded && frame.ID <= b.lastDecodedID {
+		// A keyframe with an old ID but a newer timestamp indicates the stream was restarted
+		if frame.FrameType != FrameTypeKey || !isNewerTimestamp(frame.Timestamp, b.lastDecodedTimestamp) {
+			return false
+		}
+		b.clear()
+	}
+
+	if len(b.frames) >= b.maxFrames {
+		if frame.FrameType != FrameTypeKey {
+			return false
+		}
+		b.clear()
+	}
+
+	idx := sort.Search(len(b.frames), func(i int) bool { return b.frames[i].ID >= frame.ID })
+	if idx < len(b.frames) && b.frames[idx].ID == frame.ID {
+		return false
+	}
+
+	b.frames = append(b.frames, nil)
+	copy(b.frames[idx+1:], b.frames[idx:])
+	b.frames[idx] = frame
+
+	b.timing.onFrameReceived(frame.Timestamp, b.now())
+
+	select {
+	case b.notify <- struct{}{}:
+	default:
+	}
+
+	return true
+}
+
+// NextFrame blocks until the next frame is due for decoding and returns it.
+// Frames are returned in decoding order with RenderTime set.
+// Returns the context error if ctx is done, or an error if the buffer is closed.
+// Reference: libwebrtc video_stream_buffer_controller.cc FrameReadyForDecode()
+func (b *FrameBuffer) NextFrame(ctx context.Context) (*EncodedFrame, error) {
+	for {
+		b.mu.Lock()
+		if len(b.ready) > 0 {
+			frame := b.ready[0]
+			b.ready = b.ready[1:]
+			b.mu.Unlock()
+
+			return frame, nil
+		}
+
+		wait, hasFrame := b.releaseDueFrames()
+		b.mu.Unlock()
+
+		if hasFrame {
+			continue
+		}
+
+		if err := b.wait(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// wait blocks until a frame is inserted, the timeout expires, ctx is done or the buffer is closed.
+// A zero timeout waits without a time limit.
+func (b *FrameBuffer) wait(ctx context.Context, timeout time.Duration) error {
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-b.closed:
+		return errFrameBufferClosed
+	case <-b.notify:
+	case <-expired:
+	}
+
+	return nil
+}
+
+// DroppedFrames returns the number of frames dropped without being released.
+func (b *FrameBuffer) DroppedFrames() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.droppedFrames
+}
+
+// Close unblocks pending NextFrame calls. The buffer must not be used after Close.
+func (b *FrameBuffer) Close() error {
+	b.closeOnce.Do(func() {
+		close(b.closed)
+	})
+
+	return nil
+}
+
+// releaseDueFrames moves the next decodable temporal unit to the ready frames if it is due.
+// Returns true if frames are ready, otherwise the time to wait for the next unit,
+// or zero if no unit is decodable.
+// This method should be called with mu held.
+// Reference: libwebrtc video_stream_buffer_controller.cc OnFrameReady() and frame_decode_scheduler
+func (b *FrameBuffer) releaseDueFrames() (time.Duration, bool) {
+	for {
+		start, end, ok := b.nextDecodableTemporalUnit(0)
+		if !ok {
+			return 0, false
+		}
+
+		now := b.now()
+		renderTime := b.timing.renderTime(b.frames[start].Timestamp, now)
+		wait := renderTime.Add(-b.timing.renderDelay).Sub(now)
+
+		if wait < -maxAllowedFrameDelay {
+			if _, _, later := b.nextDecodableTemporalUnit(end); later {
+				// Too late and a later unit can be decoded instead
+				b.dropFrames(end)
+
+				continue
+			}
+		}
+
+		if wait > 0 {
+			return wait, false
+		}
+
+		unit := b.extractTemporalUnit(start, end)
+		for _, frame := range unit {
+			frame.RenderTime = renderTime
+		}
+		b.ready = append(b.ready, unit...)
+
+		return 0, true
+	}
+}
+
+// nextDecodableTemporalUnit returns the index range of the first decodable temporal
+// unit starting at or after index from.
+// A temporal unit is complete when its last frame is the last spatial layer or
+// a frame with a different timestamp follows it.
+// This method should be called with mu held.
+// Reference: libwebrtc frame_buffer3.cc FindNextAndLastDecodableTemporalUnit()
+func (b *FrameBuffer) nextDecodableTemporalUnit(from int) (start, end int, ok bool) {
+	for start = from; start < len(b.frames); start = end {
+		timestamp := b.frames[start].Timestamp
+		end = start + 1
+		for end < len(b.frames) && b.frames[end].Timestamp == timestamp && !b.frames[end-1].IsLastSpatialLayer {
+			end++
+		}
+
+		complete := b.frames[end-1].IsLastSpatialLayer || end < len(b.frames)
+		if complete && b.isDecodable(start, end) {
+			return start, end, true
+		}
+	}
+
+	return 0, 0, false
+}
+
+// isDecodable reports whether every reference of the frames in [start, end) is
+// either an earlier frame of the unit or has been released.
+// This method should be called with mu held.
+func (b *FrameBuffer) isDecodable(start, end int) bool {
+	for i := start; i < end; i++ {
+		frame := b.frames[i]
+		for _, ref := range frame.References[:frame.NumReferences] {
+			if _, ok := b.decoded[ref]; ok {
+				continue
+			}
+
+			inUnit := false
+			for j := start; j < i; j++ {
+				if b.frames[j].ID == ref {
+					inUnit = true
+
+					break
+				}
+			}
+			if !inUnit {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// extractTemporalUnit removes the frames in [start, end) and marks them as decoded.
+// Earlier frames are dropped.
+// This method should be called with mu held.
+// Reference: libwebrtc frame_buffer3.cc ExtractNextDecodableTemporalUnit()
+func (b *FrameBuffer) extractTemporalUnit(start, end int) []*EncodedFrame {
+	unit := append([]*EncodedFrame(nil), b.frames[start:end]...)
+	b.dropFrames(start)
+	b.frames = b.frames[end-start:]
+
+	for _, frame := range unit {
+		b.decoded[frame.ID] = struct{}{}
+	}
+	last := unit[len(unit)-1]
+	b.lastDecodedID = last.ID
+	b.lastDecodedTimestamp = last.Timestamp
+	b.hasDecoded = true
+
+	b.pruneDecodedHistory()
+	b.dropUndecodableFrames()
+
+	return unit
+}
+
+// dropFrames drops the first n buffered frames.
+// This method should be called with mu held.
+// Reference: libwebrtc frame_buffer3.cc DropNextDecodableTemporalUnit()
+func (b *FrameBuffer) dropFrames(n int) {
+	b.droppedFrames += n
+	b.frames = b.frames[n:]
+}
+
+// dropUndecodableFrames drops frames that reference a frame which was skipped,
+// directly or through another dropped frame.
+// This method should be called with mu held.
+func (b *FrameBuffer) dropUndecodableFrames() {
+	var dropped map[int64]struct{}
+	kept := b.frames[:0]
+
+	for _, frame := range b.frames {
+		undecodable := false
+		for _, ref := range frame.References[:frame.NumReferences] {
+			if _, ok := dropped[ref]; ok || !b.canBeDecoded(ref) {
+				undecodable = true
+
+				break
+			}
+		}
+
+		if undecodable {
+			if dropped == nil {
+				dropped = make(map[int64]struct{})
+			}
+			dropped[frame.ID] = struct{}{}
+			b.droppedFrames++
+
+			continue
+		}
+		kept = append(kept, frame)
+	}
+
+	b.frames = kept
+}
+
+// validReferences reports whether the references of the frame can still be decoded.
+// Reference: libwebrtc frame_buffer3.cc ValidReferences()
+// This method should be called with mu held.
+func (b *FrameBuffer) validReferences(frame *EncodedFrame) bool {
+	if frame.NumReferences < 0 || frame.NumReferences > len(frame.References) {
+		return false
+	}
+
+	for _, ref := range frame.References[:frame.NumReferences] {
+		if ref >= frame.ID || !b.canBeDecoded(ref) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// canBeDecoded reports whether a referenced frame was released or may still be.
+// This method should be called with mu held.
+func (b *FrameBuffer) canBeDecoded(id int64) bool {
+	if !b.hasDecoded || id > b.lastDecodedID {
+		return true
+	}
+	_, ok := b.decoded[id]
+
+	return ok
+}
+
+// pruneDecodedHistory forgets decoded frame IDs that are too old to be referenced.
+// This method should be called with mu held.
+// Reference: libwebrtc modules/video_coding/utility/decoded_frames_history.cc
+func (b *FrameBuffer) pruneDecodedHistory() {
+	if len(b.decoded) <= decodedHistorySize {
+		return
+	}
+
+	for id := range b.decoded {
+		if id <= b.lastDecodedID-decodedHistorySize {
+			delete(b.decoded, id)
+		}
+	}
+}
+
+// clear drops all buffered frames and the decoded history.
+// This method should be called with mu held.
+// Reference: libwebrtc frame_buffer3.cc Clear()
+func (b *FrameBuffer) clear() {
+	b.droppedFrames += len(b.frames)
+	b.frames = nil
+	b.decoded = make(map[int64]struct{})
+	b.lastDecodedID = 0
+	b.lastDecodedTimestamp = 0
+	b.hasDecoded = false
+}
+
+// isNewerTimestamp reports whether RTP timestamp a is newer than b, handling wrap-around.
+func isNewerTimestamp(a, b uint32) bool {
+	return a != b && a-b < 1<<31
+}
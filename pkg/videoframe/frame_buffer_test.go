@@ -0,0 +1,254 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package videoframe
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testFrame creates a frame at 30fps with the given ID and references.
+func testFrame(id int64, frameType FrameType, refs ...int64) *EncodedFrame {
+	frame := &EncodedFrame{
+		ID:                 id,
+		Timestamp:          uint32(id * 3000), //nolint:gosec // G115
+		FrameType:          frameType,
+		IsLastSpatialLayer: true,
+		NumReferences:      len(refs),
+	}
+	copy(frame.References[:], refs)
+
+	return frame
+}
+
+// testFrameBuffer is a FrameBuffer with a manually advanced clock and no render delay.
+type testFrameBuffer struct {
+	*FrameBuffer
+
+	t     *testing.T
+	start time.Time
+	now   time.Time
+}
+
+func newTestFrameBuffer(t *testing.T, opts ...FrameBufferOption) *testFrameBuffer {
+	t.Helper()
+
+	buffer, err := NewFrameBuffer(append([]FrameBufferOption{WithRenderDelay(0)}, opts...)...)
+	require.NoError(t, err)
+
+	b := &testFrameBuffer{FrameBuffer: buffer, t: t, start: time.Unix(1000, 0)}
+	b.now = b.start
+	buffer.now = func() time.Time { return b.now }
+
+	return b
+}
+
+// insert inserts the frame at the time it is expected by its timestamp.
+func (b *testFrameBuffer) insert(frame *EncodedFrame) bool {
+	if arrival := b.start.Add(time.Duration(frame.Timestamp) * time.Second / videoClockRate); arrival.After(b.now) {
+		b.now = arrival
+	}
+
+	return b.InsertFrame(frame)
+}
+
+// next advances the clock by the minimum jitter delay and returns the next frame if it is due, or nil.
+func (b *testFrameBuffer) next() *EncodedFrame {
+	b.t.Helper()
+
+	b.now = b.now.Add(time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	frame, err := b.NextFrame(ctx)
+	if err != nil {
+		require.ErrorIs(b.t, err, context.DeadlineExceeded)
+
+		return nil
+	}
+
+	return frame
+}
+
+func TestFrameBuffer_ReleasesInDecodeOrder(t *testing.T) {
+	buffer := newTestFrameBuffer(t)
+
+	// Delta frames arrive before the frames they reference
+	assert.True(t, buffer.insert(testFrame(2, FrameTypeDelta, 1)))
+	assert.Nil(t, buffer.next(), "Frame 2 must wait for frame 1")
+
+	assert.True(t, buffer.insert(testFrame(1, FrameTypeDelta, 0)))
+	assert.Nil(t, buffer.next(), "Frame 1 must wait for frame 0")
+
+	assert.True(t, buffer.insert(testFrame(0, FrameTypeKey)))
+
+	for _, id := range []int64{0, 1, 2} {
+		frame := buffer.next()
+		require.NotNil(t, frame)
+		assert.Equal(t, id, frame.ID)
+		assert.False(t, frame.RenderTime.IsZero())
+	}
+	assert.Equal(t, 0, buffer.DroppedFrames())
+}
+
+func TestFrameBuffer_RejectsFrames(t *testing.T) {
+	buffer := newTestFrameBuffer(t)
+
+	assert.False(t, buffer.InsertFrame(nil))
+	assert.False(t, buffer.insert(testFrame(3, FrameTypeDelta, 3)), "Reference must precede the frame")
+
+	require.True(t, buffer.insert(testFrame(0, FrameTypeKey)))
+	assert.False(t, buffer.insert(testFrame(0, FrameTypeKey)), "Duplicate frame")
+
+	require.NotNil(t, buffer.next())
+	assert.False(t, buffer.insert(testFrame(0, FrameTypeKey)), "Frame already released")
+}
+
+func TestFrameBuffer_DropsUndecodableFrames(t *testing.T) {
+	buffer := newTestFrameBuffer(t)
+
+	require.True(t, buffer.insert(testFrame(0, FrameTypeKey)))
+	frame := buffer.next()
+	require.NotNil(t, frame)
+	assert.Equal(t, int64(0), frame.ID)
+
+	// Frame 1 is lost: frames 2 and 3 can never be decoded
+	require.True(t, buffer.insert(testFrame(2, FrameTypeDelta, 1)))
+	require.True(t, buffer.insert(testFrame(3, FrameTypeDelta, 2)))
+	require.True(t, buffer.insert(testFrame(4, FrameTypeKey)))
+
+	frame = buffer.next()
+	require.NotNil(t, frame)
+	assert.Equal(t, int64(4), frame.ID)
+	assert.Equal(t, 2, buffer.DroppedFrames())
+
+	assert.False(t, buffer.insert(testFrame(5, FrameTypeDelta, 1)), "Reference was skipped")
+	assert.True(t, buffer.insert(testFrame(5, FrameTypeDelta, 4)))
+}
+
+func TestFrameBuffer_DropsTransitiveDependents(t *testing.T) {
+	buffer := newTestFrameBuffer(t)
+
+	require.True(t, buffer.insert(testFrame(0, FrameTypeKey)))
+	require.True(t, buffer.insert(testFrame(2, FrameTypeDelta, 0)))
+	require.True(t, buffer.insert(testFrame(3, FrameTypeDelta, 1)))
+	require.True(t, buffer.insert(testFrame(4, FrameTypeDelta, 3)))
+	require.True(t, buffer.insert(testFrame(5, FrameTypeDelta, 2)))
+
+	var ids []int64
+	for frame := buffer.next(); frame != nil; frame = buffer.next() {
+		ids = append(ids, frame.ID)
+	}
+	assert.Equal(t, []int64{0, 2, 5}, ids)
+	assert.Equal(t, 2, buffer.DroppedFrames())
+}
+
+func TestFrameBuffer_TemporalUnit(t *testing.T) {
+	buffer := newTestFrameBuffer(t)
+
+	// Two spatial layers of the same picture
+	base := testFrame(10, FrameTypeKey)
+	base.IsLastSpatialLayer = false
+	upper := testFrame(11, FrameTypeKey, 10)
+	upper.Timestamp = base.Timestamp
+
+	require.True(t, buffer.insert(base))
+	assert.Nil(t, buffer.next(), "Temporal unit is incomplete")
+
+	require.True(t, buffer.insert(upper))
+	first := buffer.next()
+	second := buffer.next()
+	require.NotNil(t, first)
+	require.NotNil(t, second)
+	assert.Equal(t, int64(10), first.ID)
+	assert.Equal(t, int64(11), second.ID)
+	assert.Equal(t, first.RenderTime, second.RenderTime)
+}
+
+func TestFrameBuffer_WaitsForRenderTime(t *testing.T) {
+	buffer := newTestFrameBuffer(t, WithPlayoutDelay(100*time.Millisecond, time.Second))
+
+	require.True(t, buffer.insert(testFrame(0, FrameTypeKey)))
+	assert.Nil(t, buffer.next(), "Frame must be held until its render time")
+
+	buffer.now = buffer.start.Add(100 * time.Millisecond)
+	frame := buffer.next()
+	require.NotNil(t, frame)
+	assert.Equal(t, buffer.start.Add(100*time.Millisecond), frame.RenderTime)
+}
+
+func TestFrameBuffer_DropsLateFrames(t *testing.T) {
+	buffer := newTestFrameBuffer(t)
+
+	require.True(t, buffer.insert(testFrame(0, FrameTypeKey)))
+	require.NotNil(t, buffer.next())
+
+	// Frames 1 and 2 arrive late, and frame 2 does not depend on frame 1
+	buffer.now = buffer.start.Add(200 * time.Millisecond)
+	require.True(t, buffer.insert(testFrame(1, FrameTypeDelta, 0)))
+	require.True(t, buffer.insert(testFrame(2, FrameTypeDelta, 0)))
+
+	frame := buffer.next()
+	require.NotNil(t, frame)
+	assert.Equal(t, int64(2), frame.ID)
+	assert.Equal(t, 1, buffer.DroppedFrames())
+}
+
+func TestFrameBuffer_Full(t *testing.T) {
+	buffer := newTestFrameBuffer(t, WithFrameBufferMaxFrames(2))
+
+	require.True(t, buffer.insert(testFrame(1, FrameTypeDelta, 0)))
+	require.True(t, buffer.insert(testFrame(2, FrameTypeDelta, 1)))
+	assert.False(t, buffer.insert(testFrame(3, FrameTypeDelta, 2)), "Delta frame must not be buffered when full")
+
+	assert.True(t, buffer.insert(testFrame(4, FrameTypeKey)), "Keyframe clears a full buffer")
+	assert.Equal(t, 2, buffer.DroppedFrames())
+
+	frame := buffer.next()
+	require.NotNil(t, frame)
+	assert.Equal(t, int64(4), frame.ID)
+}
+
+func TestFrameBuffer_StreamRestart(t *testing.T) {
+	buffer := newTestFrameBuffer(t)
+
+	require.True(t, buffer.insert(testFrame(100, FrameTypeKey)))
+	require.NotNil(t, buffer.next())
+
+	restart := testFrame(0, FrameTypeKey)
+	restart.Timestamp = 400000
+	assert.True(t, buffer.insert(restart))
+
+	frame := buffer.next()
+	require.NotNil(t, frame)
+	assert.Equal(t, int64(0), frame.ID)
+}
+
+func TestFrameBuffer_Close(t *testing.T) {
+	buffer, err := NewFrameBuffer()
+	require.NoError(t, err)
+
+	done := make(chan error)
+	go func() {
+		_, err := buffer.NextFrame(context.Background())
+		done <- err
+	}()
+
+	require.NoError(t, buffer.Close())
+	assert.ErrorIs(t, <-done, errFrameBufferClosed)
+	assert.NoError(t, buffer.Close())
+}
+
+func TestFrameBuffer_InvalidOptions(t *testing.T) {
+	_, err := NewFrameBuffer(WithFrameBufferMaxFrames(0))
+	assert.ErrorIs(t, err, errInvalidFrameBufferSize)
+
+	_, err = NewFrameBuffer(WithPlayoutDelay(time.Second, 0))
+	assert.ErrorIs(t, err, errInvalidPlayoutDelay)
+}
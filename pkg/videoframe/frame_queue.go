@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package videoframe
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// FrameQueuePolicy decides what happens when a frame is delivered to a full frame channel.
+type FrameQueuePolicy int
+
+const (
+	// FrameQueueDropOldest removes the oldest queued frame to make room for the new frame.
+	FrameQueueDropOldest FrameQueuePolicy = iota
+	// FrameQueueDropNewest drops the new frame.
+	FrameQueueDropNewest
+	// FrameQueueBlock blocks the RTP Read until the frame is received from the channel.
+	// The channel must be drained from another goroutine than the one reading RTP packets.
+	FrameQueueBlock
+)
+
+// defaultFrameQueueSize is the default capacity of a frame channel.
+const defaultFrameQueueSize = 64
+
+// frameSink delivers the resolved frames of a stream to a callback and a bounded channel.
+type frameSink struct {
+	policy FrameQueuePolicy
+
+	// callback is set by ReceiverInterceptor.OnFrame, guarded by the interceptor's streamsMu.
+	callback func(*EncodedFrame)
+
+	// frames only receives frames once subscribed.
+	frames     chan *EncodedFrame
+	subscribed atomic.Bool
+
+	// mu is held while sending to frames, so that frames is not closed during a send.
+	mu     sync.Mutex
+	done   chan struct{}
+	closed bool
+
+	closeOnce sync.Once
+}
+
+func newFrameSink(size int, policy FrameQueuePolicy) *frameSink {
+	return &frameSink{
+		policy: policy,
+		frames: make(chan *EncodedFrame, size),
+		done:   make(chan struct{}),
+	}
+}
+
+// channel returns the frame channel. Frames are queued from the first call on.
+func (s *frameSink) channel() <-chan *EncodedFrame {
+	s.subscribed.Store(true)
+
+	return s.frames
+}
+
+// push delivers a frame to the callback, read from the sink by the caller, and to the channel.
+// It returns false if the channel was full and a frame was dropped.
+func (s *frameSink) push(frame *EncodedFrame, callback func(*EncodedFrame)) bool {
+	if callback != nil {
+		callback(frame)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed || !s.subscribed.Load() {
+		return true
+	}
+
+	select {
+	case s.frames <- frame:
+		return true
+	default:
+	}
+
+	switch s.policy {
+	case FrameQueueBlock:
+		select {
+		case s.frames <- frame:
+		case <-s.done:
+		}
+
+		return true
+	case FrameQueueDropOldest:
+		select {
+		case <-s.frames:
+		default:
+		}
+		select {
+		case s.frames <- frame:
+		default:
+		}
+
+		return false
+	default:
+		return false
+	}
+}
+
+// close closes the channel. A blocked push is released first.
+func (s *frameSink) close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.closed = true
+		close(s.frames)
+	})
+}
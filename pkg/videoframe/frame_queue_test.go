@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package videoframe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrameSink_Unsubscribed(t *testing.T) {
+	sink := newFrameSink(1, FrameQueueBlock)
+
+	var called []*EncodedFrame
+	callback := func(frame *EncodedFrame) { called = append(called, frame) }
+
+	// Without a channel subscriber frames only go to the callback and never block
+	for i := 0; i < 3; i++ {
+		assert.True(t, sink.push(&EncodedFrame{ID: int64(i)}, callback))
+	}
+	assert.Len(t, called, 3)
+
+	sink.close()
+	_, ok := <-sink.channel()
+	assert.False(t, ok)
+}
+
+func TestFrameSink_DropOldest(t *testing.T) {
+	sink := newFrameSink(2, FrameQueueDropOldest)
+	frames := sink.channel()
+
+	assert.True(t, sink.push(&EncodedFrame{ID: 0}, nil))
+	assert.True(t, sink.push(&EncodedFrame{ID: 1}, nil))
+	assert.False(t, sink.push(&EncodedFrame{ID: 2}, nil))
+
+	assert.Equal(t, int64(1), (<-frames).ID)
+	assert.Equal(t, int64(2), (<-frames).ID)
+}
+
+func TestFrameSink_DropNewest(t *testing.T) {
+	sink := newFrameSink(2, FrameQueueDropNewest)
+	frames := sink.channel()
+
+	assert.True(t, sink.push(&EncodedFrame{ID: 0}, nil))
+	assert.True(t, sink.push(&EncodedFrame{ID: 1}, nil))
+	assert.False(t, sink.push(&EncodedFrame{ID: 2}, nil))
+
+	assert.Equal(t, int64(0), (<-frames).ID)
+	assert.Equal(t, int64(1), (<-frames).ID)
+}
+
+func TestFrameSink_Block(t *testing.T) {
+	sink := newFrameSink(1, FrameQueueBlock)
+	frames := sink.channel()
+
+	assert.True(t, sink.push(&EncodedFrame{ID: 0}, nil))
+
+	pushed := make(chan bool)
+	go func() {
+		pushed <- sink.push(&EncodedFrame{ID: 1}, nil)
+	}()
+
+	select {
+	case <-pushed:
+		require.Fail(t, "push should block while the channel is full")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	assert.Equal(t, int64(0), (<-frames).ID)
+	assert.True(t, <-pushed)
+	assert.Equal(t, int64(1), (<-frames).ID)
+
+	// Closing releases a blocked push
+	assert.True(t, sink.push(&EncodedFrame{ID: 2}, nil))
+	go func() {
+		pushed <- sink.push(&EncodedFrame{ID: 3}, nil)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	sink.close()
+	<-pushed
+
+	assert.Equal(t, int64(2), (<-frames).ID)
+	_, ok := <-frames
+	assert.False(t, ok)
+}
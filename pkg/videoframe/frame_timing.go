@@ -0,0 +1,249 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package videoframe
+
+import (
+	"math"
+	"time"
+)
+
+// videoClockRate is the RTP clock rate of video streams.
+const videoClockRate = 90000
+
+// Jitter estimator constants.
+// Reference: libwebrtc modules/video_coding/timing/jitter_estimator.cc
+const (
+	// jitterAlphaCountMax caps the number of samples of the exponential filter.
+	jitterAlphaCountMax = 400
+	// jitterNoiseStdDevs is the number of standard deviations of noise included in the estimate.
+	jitterNoiseStdDevs = 2.33
+	// jitterNoiseStdDevOffset is subtracted from the noise based estimate, in milliseconds.
+	jitterNoiseStdDevOffset = 30.0
+	// jitterMaxDeviationStdDevs limits the impact of a single outlier on the estimate.
+	jitterMaxDeviationStdDevs = 4.0
+	// jitterInitialVarNoise is the initial noise variance in ms^2.
+	jitterInitialVarNoise = 4.0
+	// jitterMinEstimate is the minimum jitter delay in milliseconds.
+	jitterMinEstimate = 1.0
+)
+
+// Timing constants.
+// Reference: libwebrtc modules/video_coding/timing/timing.cc
+const (
+	// defaultRenderDelay is the default time needed to render a frame.
+	defaultRenderDelay = 10 * time.Millisecond
+	// defaultMaxPlayoutDelay is the default upper bound of the target delay.
+	defaultMaxPlayoutDelay = 10 * time.Second
+	// maxDelayChangePerSecond limits how fast the current delay follows the target delay.
+	maxDelayChangePerSecond = 100 * time.Millisecond
+	// extrapolatorDriftDivisor is the fraction of a late arrival applied to the timestamp extrapolation.
+	extrapolatorDriftDivisor = 500
+)
+
+// timestampUnwrapper unwraps 32-bit RTP timestamps to int64.
+type timestampUnwrapper struct {
+	last    int64
+	started bool
+}
+
+func (u *timestampUnwrapper) unwrap(ts uint32) int64 {
+	if !u.started {
+		u.last = int64(ts)
+		u.started = true
+
+		return u.last
+	}
+
+	u.last += int64(int32(ts - uint32(u.last))) //nolint:gosec // G115
+
+	return u.last
+}
+
+// interFrameDelay calculates the variation of the inter-frame delay: the difference
+// between the receive time delta and the RTP timestamp delta of consecutive frames.
+// Reference: libwebrtc modules/video_coding/timing/inter_frame_delay_variation_calculator.cc
+type interFrameDelay struct {
+	prevWallClock time.Time
+	prevTimestamp int64
+	started       bool
+}
+
+// calculate returns the delay variation of the frame in milliseconds.
+// Returns false for frames older than the previous frame.
+func (d *interFrameDelay) calculate(unwrappedTimestamp int64, now time.Time) (float64, bool) {
+	if !d.started {
+		d.prevWallClock = now
+		d.prevTimestamp = unwrappedTimestamp
+		d.started = true
+
+		return 0, true
+	}
+
+	tsDelta := unwrappedTimestamp - d.prevTimestamp
+	if tsDelta < 0 {
+		// Reordered frame
+		return 0, false
+	}
+
+	wallDelta := now.Sub(d.prevWallClock)
+	d.prevWallClock = now
+	d.prevTimestamp = unwrappedTimestamp
+
+	tsDeltaMs := float64(tsDelta) * 1000 / videoClockRate
+
+	return float64(wallDelta)/float64(time.Millisecond) - tsDeltaMs, true
+}
+
+// jitterEstimator estimates the network jitter from inter-frame delay variations.
+// This is a simplified version of libwebrtc's JitterEstimator that only uses the
+// random jitter (noise) part of the estimate, without the frame size Kalman filter.
+// Reference: libwebrtc modules/video_coding/timing/jitter_estimator.cc
+type jitterEstimator struct {
+	avgNoise   float64
+	varNoise   float64
+	alphaCount float64
+}
+
+func newJitterEstimator() *jitterEstimator {
+	return &jitterEstimator{
+		varNoise:   jitterInitialVarNoise,
+		alphaCount: 1,
+	}
+}
+
+// update adds a frame delay variation sample in milliseconds.
+// Reference: libwebrtc jitter_estimator.cc UpdateEstimate() and EstimateRandomJitter()
+func (e *jitterEstimator) update(frameDelayMs float64) {
+	deviation := frameDelayMs - e.avgNoise
+	maxDeviation := jitterMaxDeviationStdDevs * math.Sqrt(e.varNoise)
+	deviation = math.Max(-maxDeviation, math.Min(maxDeviation, deviation))
+
+	alpha := (e.alphaCount - 1) / e.alphaCount
+	e.alphaCount = math.Min(e.alphaCount+1, jitterAlphaCountMax)
+
+	avgNoise := alpha*e.avgNoise + (1-alpha)*deviation
+	varNoise := alpha*e.varNoise + (1-alpha)*(deviation-e.avgNoise)*(deviation-e.avgNoise)
+	e.avgNoise = avgNoise
+	e.varNoise = math.Max(varNoise, 1)
+}
+
+// estimate returns the jitter delay.
+// Reference: libwebrtc jitter_estimator.cc NoiseThreshold() and GetJitterEstimate()
+func (e *jitterEstimator) estimate() time.Duration {
+	noiseThreshold := jitterNoiseStdDevs*math.Sqrt(e.varNoise) - jitterNoiseStdDevOffset
+	jitterMs := math.Max(noiseThreshold, jitterMinEstimate)
+
+	return time.Duration(jitterMs * float64(time.Millisecond))
+}
+
+// frameTiming maps RTP timestamps to local render times.
+// The render time of a frame is the local time corresponding to its RTP timestamp
+// plus the current playout delay, which follows the jitter based target delay.
+// Reference: libwebrtc modules/video_coding/timing/timing.cc (VCMTiming)
+type frameTiming struct {
+	renderDelay     time.Duration
+	minPlayoutDelay time.Duration
+	maxPlayoutDelay time.Duration
+
+	unwrapper    timestampUnwrapper
+	frameDelay   interFrameDelay
+	jitter       *jitterEstimator
+	currentDelay time.Duration
+	lastUpdate   time.Time
+
+	// Timestamp extrapolation: local time = baseTime + (timestamp - baseTimestamp) / clock rate.
+	// The base moves earlier when a frame arrives earlier than predicted.
+	// Reference: libwebrtc modules/video_coding/timing/timestamp_extrapolator.cc
+	baseTime      time.Time
+	baseTimestamp int64
+	started       bool
+}
+
+func newFrameTiming() *frameTiming {
+	return &frameTiming{
+		renderDelay:     defaultRenderDelay,
+		maxPlayoutDelay: defaultMaxPlayoutDelay,
+		jitter:          newJitterEstimator(),
+	}
+}
+
+// onFrameReceived updates the timestamp extrapolation and jitter estimate
+// with a frame received at now.
+func (t *frameTiming) onFrameReceived(timestamp uint32, now time.Time) {
+	unwrapped := t.unwrapper.unwrap(timestamp)
+
+	if !t.started {
+		t.baseTime = now
+		t.baseTimestamp = unwrapped
+		t.started = true
+	} else if predicted := t.localTime(unwrapped); now.Before(predicted) {
+		// Frame arrived earlier than expected, move the base to the fastest path
+		t.baseTime = t.baseTime.Add(now.Sub(predicted))
+	} else {
+		// Follow a slow clock drift between sender and receiver
+		t.baseTime = t.baseTime.Add(now.Sub(predicted) / extrapolatorDriftDivisor)
+	}
+
+	if delay, ok := t.frameDelay.calculate(unwrapped, now); ok {
+		t.jitter.update(delay)
+	}
+}
+
+// localTime returns the local time corresponding to an unwrapped RTP timestamp.
+func (t *frameTiming) localTime(unwrappedTimestamp int64) time.Time {
+	offset := time.Duration((unwrappedTimestamp - t.baseTimestamp) * int64(time.Second) / videoClockRate)
+
+	return t.baseTime.Add(offset)
+}
+
+// targetDelay returns the jitter delay plus render delay, bounded by the playout delay limits.
+// Reference: libwebrtc timing.cc TargetDelayInternal()
+func (t *frameTiming) targetDelay() time.Duration {
+	target := t.jitter.estimate() + t.renderDelay
+	if target < t.minPlayoutDelay {
+		target = t.minPlayoutDelay
+	}
+	if target > t.maxPlayoutDelay {
+		target = t.maxPlayoutDelay
+	}
+
+	return target
+}
+
+// updateCurrentDelay moves the current delay towards the target delay at a limited rate.
+// Reference: libwebrtc timing.cc UpdateCurrentDelay()
+func (t *frameTiming) updateCurrentDelay(now time.Time) {
+	target := t.targetDelay()
+	if t.lastUpdate.IsZero() {
+		t.currentDelay = target
+		t.lastUpdate = now
+
+		return
+	}
+
+	maxChange := time.Duration(float64(maxDelayChangePerSecond) * now.Sub(t.lastUpdate).Seconds())
+	t.lastUpdate = now
+
+	delta := target - t.currentDelay
+	if delta > maxChange {
+		delta = maxChange
+	} else if delta < -maxChange {
+		delta = -maxChange
+	}
+	t.currentDelay += delta
+}
+
+// renderTime returns the render time of a frame with the given RTP timestamp.
+// Reference: libwebrtc timing.cc RenderTime()
+func (t *frameTiming) renderTime(timestamp uint32, now time.Time) time.Time {
+	if !t.started {
+		return now.Add(t.currentDelay)
+	}
+	t.updateCurrentDelay(now)
+
+	// Unwrap relative to the latest timestamp without advancing the unwrapper
+	unwrapped := t.unwrapper.last + int64(int32(timestamp-uint32(t.unwrapper.last))) //nolint:gosec // G115
+
+	return t.localTime(unwrapped).Add(t.currentDelay)
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package videoframe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimestampUnwrapper(t *testing.T) {
+	u := timestampUnwrapper{}
+	assert.Equal(t, int64(0xFFFFFF00), u.unwrap(0xFFFFFF00))
+	assert.Equal(t, int64(0x100000100), u.unwrap(0x100))
+	assert.Equal(t, int64(0xFFFFFFF0), u.unwrap(0xFFFFFFF0), "Reordered timestamp before the wrap")
+}
+
+func TestInterFrameDelay(t *testing.T) {
+	d := interFrameDelay{}
+	start := time.Unix(1000, 0)
+
+	delay, ok := d.calculate(0, start)
+	assert.True(t, ok)
+	assert.Equal(t, 0.0, delay)
+
+	// 33ms of RTP time received 43ms later
+	delay, ok = d.calculate(3000, start.Add(43*time.Millisecond+time.Millisecond/3))
+	assert.True(t, ok)
+	assert.InDelta(t, 10.0, delay, 0.01)
+
+	_, ok = d.calculate(0, start.Add(50*time.Millisecond))
+	assert.False(t, ok, "Reordered frame")
+}
+
+func TestJitterEstimator(t *testing.T) {
+	e := newJitterEstimator()
+	assert.Equal(t, time.Duration(jitterMinEstimate*float64(time.Millisecond)), e.estimate())
+
+	stable := newJitterEstimator()
+	for i := 0; i < 200; i++ {
+		stable.update(0)
+	}
+	assert.Equal(t, time.Duration(jitterMinEstimate*float64(time.Millisecond)), stable.estimate())
+
+	jittery := newJitterEstimator()
+	for i := 0; i < 200; i++ {
+		if i%2 == 0 {
+			jittery.update(40)
+		} else {
+			jittery.update(-40)
+		}
+	}
+	assert.Greater(t, jittery.estimate(), 20*time.Millisecond)
+}
+
+func TestFrameTiming_RenderTime(t *testing.T) {
+	timing := newFrameTiming()
+	timing.renderDelay = 0
+	timing.minPlayoutDelay = 50 * time.Millisecond
+	start := time.Unix(1000, 0)
+
+	timing.onFrameReceived(90000, start)
+	assert.Equal(t, start.Add(50*time.Millisecond), timing.renderTime(90000, start))
+
+	// One second of RTP time later
+	assert.Equal(t, start.Add(1050*time.Millisecond), timing.renderTime(180000, start))
+
+	// A frame arriving earlier than expected moves the extrapolation
+	timing.onFrameReceived(93000, start.Add(20*time.Millisecond))
+	expected := start.Add(20*time.Millisecond + 3000*time.Second/videoClockRate + 50*time.Millisecond)
+	assert.Equal(t, expected, timing.renderTime(96000, start))
+}
+
+func TestFrameTiming_DelayChangeIsLimited(t *testing.T) {
+	timing := newFrameTiming()
+	timing.renderDelay = 0
+	start := time.Unix(1000, 0)
+
+	timing.onFrameReceived(0, start)
+	timing.renderTime(0, start)
+	initial := timing.currentDelay
+
+	timing.minPlayoutDelay = time.Second
+	timing.renderTime(0, start.Add(time.Second))
+	assert.Equal(t, initial+maxDelayChangePerSecond, timing.currentDelay)
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package videoframe
+
+// GenericRefFinder resolves frame references from the Dependency Descriptor
+// RTP header extension.
+//
+// Reference: libwebrtc modules/video_coding/rtp_generic_ref_finder.cc
+//
+// Algorithm:
+//   - Frame ID is the unwrapped frame_number of the Dependency Descriptor
+//   - References are the frame IDs given by the frame dependency template
+//     (or the custom frame diffs) of the descriptor
+//   - Frames with more dependencies than EncodedFrame.References can hold are dropped
+//
+// The chain information of the descriptor is kept in RTPVideoHeaderGeneric
+// so that decodability of a decode target can be judged without waiting
+// for missing frames.
+//
+// Like FrameIdOnlyRefFinder, this finder does not stash frames.
+type GenericRefFinder struct{}
+
+// NewGenericRefFinder creates a new GenericRefFinder.
+func NewGenericRefFinder() *GenericRefFinder {
+	return &GenericRefFinder{}
+}
+
+// ManageFrame sets the frame ID and references from the Dependency Descriptor.
+// Reference: libwebrtc rtp_generic_ref_finder.cc ManageFrame()
+func (f *GenericRefFinder) ManageFrame(frame *EncodedFrame, header *RTPVideoHeader) []*EncodedFrame {
+	if frame == nil || header == nil || header.Generic == nil {
+		return nil
+	}
+
+	generic := header.Generic
+
+	// libwebrtc: if (EncodedFrame::kMaxFrameReferences < descriptor.dependencies.size()) { drop }
+	if len(generic.Dependencies) > len(frame.References) {
+		return nil
+	}
+
+	frame.ID = generic.FrameID
+	frame.SpatialIdx = int8(generic.SpatialIdx) //nolint:gosec // G115
+	frame.NumReferences = len(generic.Dependencies)
+	copy(frame.References[:], generic.Dependencies)
+
+	return []*EncodedFrame{frame}
+}
+
+// ClearTo is a no-op since GenericRefFinder keeps no per-frame state.
+func (f *GenericRefFinder) ClearTo(int64) {}
@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package videoframe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenericRefFinder_ManageFrame(t *testing.T) {
+	finder := NewGenericRefFinder()
+
+	header := &RTPVideoHeader{
+		Generic: &RTPVideoHeaderGeneric{FrameID: 70000, SpatialIdx: 1, Dependencies: []int64{69999, 69998}},
+	}
+	frame := &EncodedFrame{ID: 3}
+
+	result := finder.ManageFrame(frame, header)
+	require.Len(t, result, 1)
+	assert.Equal(t, int64(70000), result[0].ID)
+	assert.Equal(t, int8(1), result[0].SpatialIdx)
+	assert.Equal(t, []int64{69999, 69998}, refsOf(result[0]))
+}
+
+func TestGenericRefFinder_Drops(t *testing.T) {
+	finder := NewGenericRefFinder()
+
+	assert.Empty(t, finder.ManageFrame(&EncodedFrame{}, &RTPVideoHeader{}), "Frame without descriptor")
+
+	header := &RTPVideoHeader{
+		Generic: &RTPVideoHeaderGeneric{FrameID: 10, Dependencies: []int64{9, 8, 7, 6, 5, 4}},
+	}
+	assert.Empty(t, finder.ManageFrame(&EncodedFrame{}, header), "Frame with too many dependencies")
+}
+
+func TestSelectRefFinderType_Generic(t *testing.T) {
+	header := &RTPVideoHeader{
+		PictureID:   5,
+		TemporalIdx: 0,
+		TL0PicIdx:   1,
+		Generic:     &RTPVideoHeaderGeneric{FrameID: 1},
+	}
+	assert.Equal(t, RefFinderGeneric, SelectRefFinderType(header))
+}
@@ -0,0 +1,258 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package videoframe
+
+import (
+	"bytes"
+)
+
+// H.264 NAL unit types.
+// Reference: ITU-T H.264 Table 7-1, libwebrtc common_video/h264/h264_common.h
+const (
+	h264NaluTypeSlice = 1
+	h264NaluTypeIDR   = 5
+	h264NaluTypeSPS   = 7
+	h264NaluTypePPS   = 8
+	h264NaluTypeSTAPA = 24
+	h264NaluTypeFUA   = 28
+)
+
+const (
+	h264NaluTypeMask = 0x1F
+
+	h264NaluHeaderSize  = 1
+	h264FUAHeaderSize   = 2
+	h264STAPAHeaderSize = 1
+	h264LengthFieldSize = 2
+
+	h264FUAStartBit = 0x80
+)
+
+// annexBStartCode is the start code prepended to each NAL unit in Annex-B frames.
+// Reference: libwebrtc h264_sps_pps_tracker.cc start_code_h264
+var annexBStartCode = []byte{0x00, 0x00, 0x00, 0x01}
+
+// depacketizeH264Payload converts an H.264 RTP payload (RFC 6184) into Annex-B bytes.
+// Single NAL unit packets and STAP-A NAL units are prefixed with a start code.
+// For FU-A, the first fragment gets a start code and the reconstructed NAL header,
+// following fragments contribute only their fragment data.
+//
+// The returned header marks IsFirstPacketInFrame for every packet that starts a NAL unit,
+// since H.264 has no frame begin bit. VideoPacketBuffer finds the actual start of the
+// frame by walking back over packets with the same timestamp.
+//
+// Reference: libwebrtc video_rtp_depacketizer_h264.cc
+func depacketizeH264Payload(payload []byte, marker bool) (*RTPVideoHeader, []byte, error) {
+	if len(payload) < h264NaluHeaderSize {
+		return nil, nil, errH264ShortPacket
+	}
+
+	header := &RTPVideoHeader{
+		Codec:               VideoCodecH264,
+		FrameType:           FrameTypeDelta,
+		IsLastPacketInFrame: marker,
+		PictureID:           NoPictureID,
+		TemporalIdx:         NoTemporalIdx,
+		TL0PicIdx:           NoTL0PicIdx,
+		SpatialIdx:          NoSpatialIdx,
+	}
+
+	var out []byte
+	naluType := payload[0] & h264NaluTypeMask
+
+	switch {
+	case naluType >= h264NaluTypeSlice && naluType < h264NaluTypeSTAPA:
+		// Single NAL unit packet
+		// libwebrtc: video_header.is_first_packet_in_frame = true
+		header.IsFirstPacketInFrame = true
+		if naluType == h264NaluTypeIDR {
+			header.FrameType = FrameTypeKey
+		}
+		out = make([]byte, 0, len(annexBStartCode)+len(payload))
+		out = append(out, annexBStartCode...)
+		out = append(out, payload...)
+	case naluType == h264NaluTypeSTAPA:
+		header.IsFirstPacketInFrame = true
+		nalus, err := splitSTAPA(payload)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, nalu := range nalus {
+			if nalu[0]&h264NaluTypeMask == h264NaluTypeIDR {
+				header.FrameType = FrameTypeKey
+			}
+			out = append(out, annexBStartCode...)
+			out = append(out, nalu...)
+		}
+	case naluType == h264NaluTypeFUA:
+		if len(payload) < h264FUAHeaderSize {
+			return nil, nil, errH264ShortPacket
+		}
+		fuIndicator := payload[0]
+		fuHeader := payload[1]
+		originalType := fuHeader & h264NaluTypeMask
+
+		// libwebrtc: video_header.is_first_packet_in_frame = first_fragment
+		header.IsFirstPacketInFrame = fuHeader&h264FUAStartBit != 0
+		if originalType == h264NaluTypeIDR {
+			header.FrameType = FrameTypeKey
+		}
+
+		if header.IsFirstPacketInFrame {
+			// Reconstruct the original NAL header from the FU indicator and FU header
+			naluHeader := (fuIndicator &^ h264NaluTypeMask) | originalType
+			out = make([]byte, 0, len(annexBStartCode)+len(payload)-1)
+			out = append(out, annexBStartCode...)
+			out = append(out, naluHeader)
+		}
+		out = append(out, payload[h264FUAHeaderSize:]...)
+	default:
+		return nil, nil, errH264UnsupportedNaluType
+	}
+
+	return header, out, nil
+}
+
+// splitSTAPA returns the NAL units aggregated in a STAP-A payload.
+// The returned slices reference the payload.
+func splitSTAPA(payload []byte) ([][]byte, error) {
+	var nalus [][]byte
+
+	offset := h264STAPAHeaderSize
+	for offset < len(payload) {
+		if offset+h264LengthFieldSize > len(payload) {
+			return nil, errH264InvalidSTAPALength
+		}
+		naluSize := int(payload[offset])<<8 | int(payload[offset+1])
+		offset += h264LengthFieldSize
+
+		if naluSize == 0 || offset+naluSize > len(payload) {
+			return nil, errH264InvalidSTAPALength
+		}
+		nalus = append(nalus, payload[offset:offset+naluSize])
+		offset += naluSize
+	}
+
+	if len(nalus) == 0 {
+		return nil, errH264ShortPacket
+	}
+
+	return nalus, nil
+}
+
+// splitAnnexB returns the NAL units of an Annex-B byte stream without start codes.
+// The returned slices reference data.
+func splitAnnexB(data []byte) [][]byte {
+	var nalus [][]byte
+
+	start := -1
+	for i := 0; i+3 <= len(data); {
+		if data[i] == 0 && data[i+1] == 0 && data[i+2] == 1 {
+			if start >= 0 {
+				nalus = append(nalus, bytes.TrimRight(data[start:i], "\x00"))
+			}
+			i += 3
+			start = i
+			continue
+		}
+		i++
+	}
+	if start >= 0 && start < len(data) {
+		nalus = append(nalus, data[start:])
+	}
+
+	return nalus
+}
+
+// expGolombReader reads unsigned exp-Golomb codes from an RBSP,
+// skipping emulation prevention bytes.
+// Reference: ITU-T H.264 Section 9.1, libwebrtc rtc_base/bitstream_reader.h
+type expGolombReader struct {
+	data    []byte
+	bytePos int
+	bitPos  uint
+	zeroRun int
+}
+
+func newExpGolombReader(data []byte) *expGolombReader {
+	return &expGolombReader{data: data}
+}
+
+// readBit reads a single bit.
+func (r *expGolombReader) readBit() (uint32, error) {
+	if r.bitPos == 0 {
+		// Skip emulation prevention byte (0x000003)
+		if r.zeroRun >= 2 && r.bytePos < len(r.data) && r.data[r.bytePos] == 0x03 {
+			r.bytePos++
+			r.zeroRun = 0
+		}
+		if r.bytePos >= len(r.data) {
+			return 0, errH264ExpGolombOutOfBounds
+		}
+		if r.data[r.bytePos] == 0 {
+			r.zeroRun++
+		} else {
+			r.zeroRun = 0
+		}
+	}
+
+	bit := uint32(r.data[r.bytePos]>>(7-r.bitPos)) & 1
+	r.bitPos++
+	if r.bitPos == 8 {
+		r.bitPos = 0
+		r.bytePos++
+	}
+
+	return bit, nil
+}
+
+// readBits reads n bits (n <= 32) as an unsigned integer.
+func (r *expGolombReader) readBits(n int) (uint32, error) {
+	var v uint32
+	for i := 0; i < n; i++ {
+		bit, err := r.readBit()
+		if err != nil {
+			return 0, err
+		}
+		v = v<<1 | bit
+	}
+
+	return v, nil
+}
+
+// skipBits skips n bits.
+func (r *expGolombReader) skipBits(n int) error {
+	for i := 0; i < n; i++ {
+		if _, err := r.readBit(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readUE reads an unsigned exp-Golomb code ue(v).
+func (r *expGolombReader) readUE() (uint32, error) {
+	leadingZeros := 0
+	for {
+		bit, err := r.readBit()
+		if err != nil {
+			return 0, err
+		}
+		if bit == 1 {
+			break
+		}
+		leadingZeros++
+		if leadingZeros > 31 {
+			return 0, errH264ExpGolombOutOfBounds
+		}
+	}
+
+	suffix, err := r.readBits(leadingZeros)
+	if err != nil {
+		return 0, err
+	}
+
+	return (1<<leadingZeros - 1) + suffix, nil
+}
@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package videoframe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Minimal H.264 NAL units used across tests.
+// SPS: sps_id=0. PPS: pps_id=0, sps_id=0. IDR slice: pps_id=0.
+var (
+	testH264SPS   = []byte{0x67, 0x42, 0xC0, 0x1F, 0xDA}
+	testH264PPS   = []byte{0x68, 0xCE, 0x3C, 0x80}
+	testH264IDR   = []byte{0x65, 0x88, 0x84, 0x21, 0xA0}
+	testH264Slice = []byte{0x41, 0x9A, 0x02, 0x04}
+)
+
+func annexB(nalus ...[]byte) []byte {
+	var out []byte
+	for _, nalu := range nalus {
+		out = append(out, annexBStartCode...)
+		out = append(out, nalu...)
+	}
+
+	return out
+}
+
+func stapA(nalus ...[]byte) []byte {
+	out := []byte{0x78} // F=0 NRI=3 Type=24
+	for _, nalu := range nalus {
+		out = append(out, byte(len(nalu)>>8), byte(len(nalu)))
+		out = append(out, nalu...)
+	}
+
+	return out
+}
+
+func TestDepacketizeH264_SingleNalu(t *testing.T) {
+	header, payload, err := depacketizeH264Payload(testH264IDR, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, VideoCodecH264, header.Codec)
+	assert.True(t, header.IsFirstPacketInFrame)
+	assert.True(t, header.IsLastPacketInFrame)
+	assert.Equal(t, FrameTypeKey, header.FrameType)
+	assert.Equal(t, NoPictureID, header.PictureID)
+	assert.Equal(t, annexB(testH264IDR), payload)
+
+	header, _, err = depacketizeH264Payload(testH264Slice, false)
+	require.NoError(t, err)
+	assert.Equal(t, FrameTypeDelta, header.FrameType)
+	assert.False(t, header.IsLastPacketInFrame)
+}
+
+func TestDepacketizeH264_STAPA(t *testing.T) {
+	header, payload, err := depacketizeH264Payload(stapA(testH264SPS, testH264PPS, testH264IDR), true)
+	require.NoError(t, err)
+
+	assert.True(t, header.IsFirstPacketInFrame)
+	assert.Equal(t, FrameTypeKey, header.FrameType)
+	assert.Equal(t, annexB(testH264SPS, testH264PPS, testH264IDR), payload)
+}
+
+func TestDepacketizeH264_STAPAInvalidLength(t *testing.T) {
+	payload := []byte{0x78, 0x00, 0x10, 0x67, 0x42}
+	_, _, err := depacketizeH264Payload(payload, false)
+	assert.ErrorIs(t, err, errH264InvalidSTAPALength)
+}
+
+func TestDepacketizeH264_FUA(t *testing.T) {
+	// FU indicator: F=0 NRI=3 Type=28, FU header: S/E + original type 5 (IDR)
+	first := []byte{0x7C, 0x85, 0x88, 0x84}
+	middle := []byte{0x7C, 0x05, 0x21}
+	last := []byte{0x7C, 0x45, 0xA0}
+
+	header, payload, err := depacketizeH264Payload(first, false)
+	require.NoError(t, err)
+	assert.True(t, header.IsFirstPacketInFrame)
+	assert.Equal(t, FrameTypeKey, header.FrameType)
+	assert.Equal(t, []byte{0x00, 0x00, 0x00, 0x01, 0x65, 0x88, 0x84}, payload,
+		"First fragment should get a start code and the reconstructed NAL header")
+
+	header, payload, err = depacketizeH264Payload(middle, false)
+	require.NoError(t, err)
+	assert.False(t, header.IsFirstPacketInFrame)
+	assert.Equal(t, []byte{0x21}, payload)
+
+	header, payload, err = depacketizeH264Payload(last, true)
+	require.NoError(t, err)
+	assert.False(t, header.IsFirstPacketInFrame)
+	assert.True(t, header.IsLastPacketInFrame)
+	assert.Equal(t, []byte{0xA0}, payload)
+}
+
+func TestDepacketizeH264_Unsupported(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload []byte
+		err     error
+	}{
+		{name: "empty", payload: []byte{}, err: errH264ShortPacket},
+		{name: "STAP-B", payload: []byte{0x19, 0x00}, err: errH264UnsupportedNaluType},
+		{name: "FU-B", payload: []byte{0x1D, 0x85}, err: errH264UnsupportedNaluType},
+		{name: "short FU-A", payload: []byte{0x7C}, err: errH264ShortPacket},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := depacketizeH264Payload(tt.payload, false)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestSplitAnnexB(t *testing.T) {
+	data := annexB(testH264SPS, testH264PPS)
+	data = append(data, 0x00, 0x00, 0x01) // 3-byte start code
+	data = append(data, testH264IDR...)
+
+	nalus := splitAnnexB(data)
+	require.Len(t, nalus, 3)
+	assert.Equal(t, testH264SPS, nalus[0])
+	assert.Equal(t, testH264PPS, nalus[1])
+	assert.Equal(t, testH264IDR, nalus[2])
+}
+
+func TestExpGolombReader(t *testing.T) {
+	// Bits: 1 | 010 | 011 | 00100 | 0001000 -> 0, 1, 2, 3, 7
+	r := newExpGolombReader([]byte{0xA6, 0x41, 0x00})
+	for _, want := range []uint32{0, 1, 2, 3, 7} {
+		got, err := r.readUE()
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	_, err := newExpGolombReader([]byte{0x00}).readUE()
+	assert.ErrorIs(t, err, errH264ExpGolombOutOfBounds)
+}
+
+func TestExpGolombReader_EmulationPrevention(t *testing.T) {
+	// 0x00 0x00 0x03 0x01: the 0x03 is an emulation prevention byte.
+	// RBSP bits: 16 zeros followed by 00000001 -> 23 leading zeros then a 1,
+	// which runs out of data for the suffix
+	r := newExpGolombReader([]byte{0x00, 0x00, 0x03, 0x01})
+	_, err := r.readUE()
+	assert.ErrorIs(t, err, errH264ExpGolombOutOfBounds)
+
+	// Without skipping, the 0x03 would be read as bits 00000011
+	r = newExpGolombReader([]byte{0x00, 0x00, 0x03, 0x80})
+	bits, err := r.readBits(24)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0x80), bits)
+}
@@ -0,0 +1,257 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package videoframe
+
+import (
+	"encoding/base64"
+	"strings"
+)
+
+// H264FrameAction is the action to take for an assembled H.264 frame.
+// This is similar to libwebrtc's H264SpsPpsTracker::PacketAction.
+type H264FrameAction int
+
+const (
+	// H264FrameInsert indicates the frame can be passed on for decoding.
+	H264FrameInsert H264FrameAction = iota
+	// H264FrameDrop indicates the frame is malformed and should be dropped.
+	H264FrameDrop
+	// H264FrameRequestKeyFrame indicates the frame references a parameter set
+	// that has not been received. The frame is held back and a keyframe is needed.
+	H264FrameRequestKeyFrame
+)
+
+// h264PpsInfo holds a received PPS and the SPS it refers to.
+type h264PpsInfo struct {
+	spsID uint32
+	data  []byte
+}
+
+// H264SpsPpsTracker tracks H.264 SPS and PPS NAL units and fixes up IDR frames
+// that arrive without their parameter sets.
+// This is similar to libwebrtc's H264SpsPpsTracker
+// (modules/video_coding/h264_sps_pps_tracker.cc), but operates on assembled
+// Annex-B frames instead of individual packets.
+//
+// For each frame:
+// - SPS and PPS NAL units are recorded by their IDs
+// - IDR frames are marked as keyframes
+// - IDR frames without SPS/PPS get the latest matching SPS/PPS prepended
+// - IDR frames referencing an unknown SPS/PPS are reported with H264FrameRequestKeyFrame
+type H264SpsPpsTracker struct {
+	spsData map[uint32][]byte
+	ppsData map[uint32]h264PpsInfo
+}
+
+// NewH264SpsPpsTracker creates a new H264SpsPpsTracker.
+func NewH264SpsPpsTracker() *H264SpsPpsTracker {
+	return &H264SpsPpsTracker{
+		spsData: make(map[uint32][]byte),
+		ppsData: make(map[uint32]h264PpsInfo),
+	}
+}
+
+// InsertSpsPpsNalus records out-of-band parameter sets, such as those from
+// the sprop-parameter-sets SDP attribute. sps and pps are NAL units without start codes.
+// Returns false if either parameter set cannot be parsed.
+// Reference: libwebrtc h264_sps_pps_tracker.cc InsertSpsPpsNalus()
+func (t *H264SpsPpsTracker) InsertSpsPpsNalus(sps, pps []byte) bool {
+	spsID, ok := parseH264SpsID(sps)
+	if !ok {
+		return false
+	}
+	ppsID, ppsSpsID, ok := parseH264PpsIDs(pps)
+	if !ok {
+		return false
+	}
+
+	t.spsData[spsID] = append([]byte(nil), sps...)
+	t.ppsData[ppsID] = h264PpsInfo{spsID: ppsSpsID, data: append([]byte(nil), pps...)}
+
+	return true
+}
+
+// FixFrame inspects an assembled Annex-B frame, records its parameter sets,
+// sets its FrameType and prepends missing SPS/PPS to IDR frames.
+// Reference: libwebrtc h264_sps_pps_tracker.cc CopyAndFixBitstream()
+//
+//nolint:cyclop
+func (t *H264SpsPpsTracker) FixFrame(frame *EncodedFrame) H264FrameAction {
+	if frame == nil {
+		return H264FrameDrop
+	}
+
+	nalus := splitAnnexB(frame.Data)
+	if len(nalus) == 0 {
+		return H264FrameDrop
+	}
+
+	hasSps, hasPps, hasIDR := false, false, false
+	idrPpsID := uint32(0)
+
+	for _, nalu := range nalus {
+		if len(nalu) == 0 {
+			continue
+		}
+
+		switch nalu[0] & h264NaluTypeMask {
+		case h264NaluTypeSPS:
+			spsID, ok := parseH264SpsID(nalu)
+			if !ok {
+				return H264FrameDrop
+			}
+			t.spsData[spsID] = append([]byte(nil), nalu...)
+			hasSps = true
+		case h264NaluTypePPS:
+			ppsID, spsID, ok := parseH264PpsIDs(nalu)
+			if !ok {
+				return H264FrameDrop
+			}
+			t.ppsData[ppsID] = h264PpsInfo{spsID: spsID, data: append([]byte(nil), nalu...)}
+			hasPps = true
+		case h264NaluTypeIDR:
+			if hasIDR {
+				continue
+			}
+			ppsID, ok := parseH264SlicePpsID(nalu)
+			if !ok {
+				return H264FrameDrop
+			}
+			idrPpsID = ppsID
+			hasIDR = true
+		}
+	}
+
+	if !hasIDR {
+		frame.FrameType = FrameTypeDelta
+		return H264FrameInsert
+	}
+	frame.FrameType = FrameTypeKey
+
+	pps, ok := t.ppsData[idrPpsID]
+	if !ok {
+		return H264FrameRequestKeyFrame
+	}
+	sps, ok := t.spsData[pps.spsID]
+	if !ok {
+		return H264FrameRequestKeyFrame
+	}
+
+	if hasSps && hasPps {
+		return H264FrameInsert
+	}
+
+	// Insert the missing parameter sets before the first slice
+	// libwebrtc: if (append_sps_pps) { insert start code + sps + start code + pps }
+	data := make([]byte, 0, len(frame.Data)+2*len(annexBStartCode)+len(sps)+len(pps.data))
+	inserted := false
+	for _, nalu := range nalus {
+		if len(nalu) == 0 {
+			continue
+		}
+		naluType := nalu[0] & h264NaluTypeMask
+		if !inserted && naluType >= h264NaluTypeSlice && naluType <= h264NaluTypeIDR {
+			if !hasSps {
+				data = append(data, annexBStartCode...)
+				data = append(data, sps...)
+			}
+			if !hasPps {
+				data = append(data, annexBStartCode...)
+				data = append(data, pps.data...)
+			}
+			inserted = true
+		}
+		data = append(data, annexBStartCode...)
+		data = append(data, nalu...)
+	}
+	frame.Data = data
+
+	return H264FrameInsert
+}
+
+// parseH264SpsID parses seq_parameter_set_id from an SPS NAL unit.
+// Reference: ITU-T H.264 Section 7.3.2.1.1
+func parseH264SpsID(nalu []byte) (uint32, bool) {
+	// NAL header (1) + profile_idc (1) + constraint flags (1) + level_idc (1)
+	const spsIDOffset = 4
+	if len(nalu) <= spsIDOffset {
+		return 0, false
+	}
+
+	spsID, err := newExpGolombReader(nalu[spsIDOffset:]).readUE()
+	if err != nil {
+		return 0, false
+	}
+
+	return spsID, true
+}
+
+// parseH264PpsIDs parses pic_parameter_set_id and seq_parameter_set_id from a PPS NAL unit.
+// Reference: ITU-T H.264 Section 7.3.2.2
+func parseH264PpsIDs(nalu []byte) (ppsID, spsID uint32, ok bool) {
+	if len(nalu) <= h264NaluHeaderSize {
+		return 0, 0, false
+	}
+
+	r := newExpGolombReader(nalu[h264NaluHeaderSize:])
+	var err error
+	if ppsID, err = r.readUE(); err != nil {
+		return 0, 0, false
+	}
+	if spsID, err = r.readUE(); err != nil {
+		return 0, 0, false
+	}
+
+	return ppsID, spsID, true
+}
+
+// parseH264SlicePpsID parses pic_parameter_set_id from a slice header.
+// Reference: ITU-T H.264 Section 7.3.3
+func parseH264SlicePpsID(nalu []byte) (uint32, bool) {
+	if len(nalu) <= h264NaluHeaderSize {
+		return 0, false
+	}
+
+	r := newExpGolombReader(nalu[h264NaluHeaderSize:])
+	// first_mb_in_slice, slice_type
+	for i := 0; i < 2; i++ {
+		if _, err := r.readUE(); err != nil {
+			return 0, false
+		}
+	}
+	ppsID, err := r.readUE()
+	if err != nil {
+		return 0, false
+	}
+
+	return ppsID, true
+}
+
+// parseSpropParameterSets returns the NAL units listed in the sprop-parameter-sets
+// fmtp parameter (RFC 6184 Section 8.1).
+func parseSpropParameterSets(fmtpLine string) [][]byte {
+	return parseFmtpNalus(fmtpLine, "sprop-parameter-sets")
+}
+
+// parseFmtpNalus returns the base64 encoded, comma separated NAL units of the given
+// fmtp parameter.
+func parseFmtpNalus(fmtpLine, key string) [][]byte {
+	var nalus [][]byte
+
+	for _, param := range strings.Split(fmtpLine, ";") {
+		name, value, found := strings.Cut(strings.TrimSpace(param), "=")
+		if !found || !strings.EqualFold(name, key) {
+			continue
+		}
+		for _, set := range strings.Split(value, ",") {
+			nalu, err := base64.StdEncoding.DecodeString(strings.TrimSpace(set))
+			if err != nil || len(nalu) == 0 {
+				continue
+			}
+			nalus = append(nalus, nalu)
+		}
+	}
+
+	return nalus
+}
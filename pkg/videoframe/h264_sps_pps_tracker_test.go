@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package videoframe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestH264SpsPpsTracker_KeyframeWithParameterSets(t *testing.T) {
+	tracker := NewH264SpsPpsTracker()
+
+	data := annexB(testH264SPS, testH264PPS, testH264IDR)
+	frame := &EncodedFrame{Data: data, FrameType: FrameTypeDelta}
+
+	assert.Equal(t, H264FrameInsert, tracker.FixFrame(frame))
+	assert.Equal(t, FrameTypeKey, frame.FrameType)
+	assert.Equal(t, data, frame.Data, "Complete keyframe should not be modified")
+}
+
+func TestH264SpsPpsTracker_PrependsParameterSets(t *testing.T) {
+	tracker := NewH264SpsPpsTracker()
+
+	// Parameter sets arrive in an earlier keyframe
+	first := &EncodedFrame{Data: annexB(testH264SPS, testH264PPS, testH264IDR)}
+	require.Equal(t, H264FrameInsert, tracker.FixFrame(first))
+
+	// Later IDR arrives without them
+	frame := &EncodedFrame{Data: annexB(testH264IDR)}
+	assert.Equal(t, H264FrameInsert, tracker.FixFrame(frame))
+	assert.Equal(t, FrameTypeKey, frame.FrameType)
+	assert.Equal(t, annexB(testH264SPS, testH264PPS, testH264IDR), frame.Data)
+}
+
+func TestH264SpsPpsTracker_PrependsOnlyMissingPPS(t *testing.T) {
+	tracker := NewH264SpsPpsTracker()
+	require.True(t, tracker.InsertSpsPpsNalus(testH264SPS, testH264PPS))
+
+	frame := &EncodedFrame{Data: annexB(testH264SPS, testH264IDR)}
+	assert.Equal(t, H264FrameInsert, tracker.FixFrame(frame))
+	assert.Equal(t, annexB(testH264SPS, testH264PPS, testH264IDR), frame.Data,
+		"Missing PPS should be inserted after the SPS, before the first slice")
+}
+
+func TestH264SpsPpsTracker_MissingParameterSets(t *testing.T) {
+	tracker := NewH264SpsPpsTracker()
+
+	frame := &EncodedFrame{Data: annexB(testH264IDR)}
+	assert.Equal(t, H264FrameRequestKeyFrame, tracker.FixFrame(frame))
+
+	// IDR references pps_id=1, only pps_id=0 is known
+	require.True(t, tracker.InsertSpsPpsNalus(testH264SPS, testH264PPS))
+	idrPps1 := []byte{0x65, 0x88, 0x40, 0x21}
+	frame = &EncodedFrame{Data: annexB(idrPps1)}
+	assert.Equal(t, H264FrameRequestKeyFrame, tracker.FixFrame(frame))
+
+	// PPS with pps_id=1 referencing an unknown sps_id=1
+	pps1Sps1 := []byte{0x68, 0x48, 0x80}
+	frame = &EncodedFrame{Data: annexB(pps1Sps1, idrPps1)}
+	assert.Equal(t, H264FrameRequestKeyFrame, tracker.FixFrame(frame))
+}
+
+func TestH264SpsPpsTracker_DeltaFrame(t *testing.T) {
+	tracker := NewH264SpsPpsTracker()
+
+	data := annexB(testH264Slice)
+	frame := &EncodedFrame{Data: data, FrameType: FrameTypeKey}
+	assert.Equal(t, H264FrameInsert, tracker.FixFrame(frame))
+	assert.Equal(t, FrameTypeDelta, frame.FrameType)
+	assert.Equal(t, data, frame.Data)
+}
+
+func TestH264SpsPpsTracker_MalformedFrame(t *testing.T) {
+	tracker := NewH264SpsPpsTracker()
+
+	assert.Equal(t, H264FrameDrop, tracker.FixFrame(nil))
+	assert.Equal(t, H264FrameDrop, tracker.FixFrame(&EncodedFrame{Data: []byte{0x65, 0x88}}))
+	assert.Equal(t, H264FrameDrop, tracker.FixFrame(&EncodedFrame{Data: annexB([]byte{0x65})}))
+}
+
+func TestParseSpropParameterSets(t *testing.T) {
+	fmtp := "level-asymmetry-allowed=1;packetization-mode=1;sprop-parameter-sets=Z0LAH9o=,aM48gA=="
+
+	nalus := parseSpropParameterSets(fmtp)
+	require.Len(t, nalus, 2)
+	assert.Equal(t, testH264SPS, nalus[0])
+	assert.Equal(t, testH264PPS, nalus[1])
+
+	assert.Empty(t, parseSpropParameterSets("packetization-mode=1"))
+}
@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package videoframe
+
+// H.265 NAL unit types.
+// Reference: ITU-T H.265 Table 7-1, RFC 7798 Section 4.4, libwebrtc common_video/h265/h265_common.h
+const (
+	h265NaluTypeBLAWLP    = 16
+	h265NaluTypeRsvIRAP23 = 23
+	h265NaluTypeVPS       = 32
+	h265NaluTypeSPS       = 33
+	h265NaluTypePPS       = 34
+	h265NaluTypeAP        = 48
+	h265NaluTypeFU        = 49
+	h265NaluTypePACI      = 50
+
+	// NAL unit types below this value are VCL NAL units (slices).
+	h265NaluTypeFirstNonVCL = 32
+)
+
+const (
+	h265NaluHeaderSize   = 2
+	h265FUHeaderSize     = 1
+	h265LengthFieldSize  = 2
+	h265NaluTypeShift    = 1
+	h265NaluTypeMask     = 0x3F
+	h265FUTypeMask       = 0x3F
+	h265FUStartBit       = 0x80
+	h265NaluTypeByteMask = 0x81 // F bit and layer ID MSB of the first header byte
+)
+
+// h265NaluType returns the NAL unit type from the first byte of an H.265 NAL unit header.
+func h265NaluType(b byte) byte {
+	return (b >> h265NaluTypeShift) & h265NaluTypeMask
+}
+
+// isH265IRAP reports whether the NAL unit type is an intra random access point picture.
+// Reference: ITU-T H.265 Section 3.73, types BLA_W_LP (16) to RSV_IRAP_VCL23 (23)
+func isH265IRAP(naluType byte) bool {
+	return naluType >= h265NaluTypeBLAWLP && naluType <= h265NaluTypeRsvIRAP23
+}
+
+// depacketizeH265Payload converts an H.265 RTP payload (RFC 7798) into Annex-B bytes.
+// Single NAL unit packets and aggregated NAL units are prefixed with a start code.
+// For fragmentation units, the first fragment gets a start code and the reconstructed
+// NAL header, following fragments contribute only their fragment data.
+//
+// As for H.264, the returned header marks IsFirstPacketInFrame for every packet that
+// starts a NAL unit, and VideoPacketBuffer finds the start of the frame by timestamp.
+// Packets containing an IRAP NAL unit are marked as keyframes.
+//
+// DONL fields (sprop-max-don-diff > 0) and PACI packets are not supported.
+//
+// Reference: libwebrtc video_rtp_depacketizer_h265.cc
+func depacketizeH265Payload(payload []byte, marker bool) (*RTPVideoHeader, []byte, error) {
+	if len(payload) < h265NaluHeaderSize {
+		return nil, nil, errH265ShortPacket
+	}
+
+	header := &RTPVideoHeader{
+		Codec:               VideoCodecH265,
+		FrameType:           FrameTypeDelta,
+		IsLastPacketInFrame: marker,
+		PictureID:           NoPictureID,
+		TemporalIdx:         NoTemporalIdx,
+		TL0PicIdx:           NoTL0PicIdx,
+		SpatialIdx:          NoSpatialIdx,
+	}
+
+	var out []byte
+	naluType := h265NaluType(payload[0])
+
+	switch {
+	case naluType < h265NaluTypeAP:
+		// Single NAL unit packet
+		header.IsFirstPacketInFrame = true
+		if isH265IRAP(naluType) {
+			header.FrameType = FrameTypeKey
+		}
+		out = make([]byte, 0, len(annexBStartCode)+len(payload))
+		out = append(out, annexBStartCode...)
+		out = append(out, payload...)
+	case naluType == h265NaluTypeAP:
+		header.IsFirstPacketInFrame = true
+		nalus, err := splitH265AP(payload)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, nalu := range nalus {
+			if isH265IRAP(h265NaluType(nalu[0])) {
+				header.FrameType = FrameTypeKey
+			}
+			out = append(out, annexBStartCode...)
+			out = append(out, nalu...)
+		}
+	case naluType == h265NaluTypeFU:
+		if len(payload) < h265NaluHeaderSize+h265FUHeaderSize {
+			return nil, nil, errH265ShortPacket
+		}
+		fuHeader := payload[h265NaluHeaderSize]
+		originalType := fuHeader & h265FUTypeMask
+
+		header.IsFirstPacketInFrame = fuHeader&h265FUStartBit != 0
+		if isH265IRAP(originalType) {
+			header.FrameType = FrameTypeKey
+		}
+
+		if header.IsFirstPacketInFrame {
+			// Reconstruct the original NAL header: keep F, layer ID and TID, replace the type
+			// Reference: libwebrtc video_rtp_depacketizer_h265.cc ParseFuPacket
+			out = make([]byte, 0, len(annexBStartCode)+len(payload))
+			out = append(out, annexBStartCode...)
+			out = append(out, payload[0]&h265NaluTypeByteMask|originalType<<h265NaluTypeShift, payload[1])
+		}
+		out = append(out, payload[h265NaluHeaderSize+h265FUHeaderSize:]...)
+	default:
+		return nil, nil, errH265UnsupportedNaluType
+	}
+
+	return header, out, nil
+}
+
+// splitH265AP returns the NAL units aggregated in an H.265 aggregation packet.
+// The returned slices reference the payload.
+// Reference: RFC 7798 Section 4.4.2
+func splitH265AP(payload []byte) ([][]byte, error) {
+	var nalus [][]byte
+
+	offset := h265NaluHeaderSize
+	for offset < len(payload) {
+		if offset+h265LengthFieldSize > len(payload) {
+			return nil, errH265InvalidAPLength
+		}
+		naluSize := int(payload[offset])<<8 | int(payload[offset+1])
+		offset += h265LengthFieldSize
+
+		if naluSize < h265NaluHeaderSize || offset+naluSize > len(payload) {
+			return nil, errH265InvalidAPLength
+		}
+		nalus = append(nalus, payload[offset:offset+naluSize])
+		offset += naluSize
+	}
+
+	// An aggregation packet contains at least two aggregation units
+	if len(nalus) < 2 {
+		return nil, errH265InvalidAPLength
+	}
+
+	return nalus, nil
+}
@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package videoframe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Minimal H.265 NAL units used across tests.
+// VPS: vps_id=0. SPS: vps_id=0, sps_id=0. PPS: pps_id=0, sps_id=0.
+// IDR_W_RADL slice: pps_id=0. TRAIL_R slice.
+var (
+	testH265VPS = []byte{0x40, 0x01, 0x0C, 0x01, 0xFF}
+	testH265SPS = []byte{
+		0x42, 0x01, 0x01,
+		0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11,
+		0xA0,
+	}
+	testH265PPS   = []byte{0x44, 0x01, 0xC0}
+	testH265IDR   = []byte{0x26, 0x01, 0xAF, 0x12, 0x34}
+	testH265Trail = []byte{0x02, 0x01, 0xD0, 0x12}
+)
+
+func h265AP(nalus ...[]byte) []byte {
+	out := []byte{0x60, 0x01} // Type=48
+	for _, nalu := range nalus {
+		out = append(out, byte(len(nalu)>>8), byte(len(nalu)))
+		out = append(out, nalu...)
+	}
+
+	return out
+}
+
+func TestDepacketizeH265_SingleNalu(t *testing.T) {
+	header, payload, err := depacketizeH265Payload(testH265IDR, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, VideoCodecH265, header.Codec)
+	assert.True(t, header.IsFirstPacketInFrame)
+	assert.True(t, header.IsLastPacketInFrame)
+	assert.Equal(t, FrameTypeKey, header.FrameType)
+	assert.Equal(t, annexB(testH265IDR), payload)
+
+	header, _, err = depacketizeH265Payload(testH265Trail, false)
+	require.NoError(t, err)
+	assert.Equal(t, FrameTypeDelta, header.FrameType)
+	assert.False(t, header.IsLastPacketInFrame)
+}
+
+func TestDepacketizeH265_AggregationPacket(t *testing.T) {
+	header, payload, err := depacketizeH265Payload(h265AP(testH265VPS, testH265SPS, testH265PPS, testH265IDR), true)
+	require.NoError(t, err)
+
+	assert.True(t, header.IsFirstPacketInFrame)
+	assert.Equal(t, FrameTypeKey, header.FrameType)
+	assert.Equal(t, annexB(testH265VPS, testH265SPS, testH265PPS, testH265IDR), payload)
+
+	_, _, err = depacketizeH265Payload([]byte{0x60, 0x01, 0x00, 0x10, 0x40}, false)
+	assert.ErrorIs(t, err, errH265InvalidAPLength)
+
+	_, _, err = depacketizeH265Payload(h265AP(testH265IDR), false)
+	assert.ErrorIs(t, err, errH265InvalidAPLength, "Aggregation packet needs at least two units")
+}
+
+func TestDepacketizeH265_FragmentationUnit(t *testing.T) {
+	// PayloadHdr: Type=49 TID=1, FU header: S/E + original type 19 (IDR_W_RADL)
+	first := []byte{0x62, 0x01, 0x93, 0xAF, 0x12}
+	middle := []byte{0x62, 0x01, 0x13, 0x34}
+	last := []byte{0x62, 0x01, 0x53, 0x56}
+
+	header, payload, err := depacketizeH265Payload(first, false)
+	require.NoError(t, err)
+	assert.True(t, header.IsFirstPacketInFrame)
+	assert.Equal(t, FrameTypeKey, header.FrameType)
+	assert.Equal(t, []byte{0x00, 0x00, 0x00, 0x01, 0x26, 0x01, 0xAF, 0x12}, payload,
+		"First fragment should get a start code and the reconstructed NAL header")
+
+	header, payload, err = depacketizeH265Payload(middle, false)
+	require.NoError(t, err)
+	assert.False(t, header.IsFirstPacketInFrame)
+	assert.Equal(t, []byte{0x34}, payload)
+
+	header, payload, err = depacketizeH265Payload(last, true)
+	require.NoError(t, err)
+	assert.True(t, header.IsLastPacketInFrame)
+	assert.Equal(t, []byte{0x56}, payload)
+}
+
+func TestDepacketizeH265_Unsupported(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload []byte
+		err     error
+	}{
+		{name: "empty", payload: []byte{}, err: errH265ShortPacket},
+		{name: "short header", payload: []byte{0x26}, err: errH265ShortPacket},
+		{name: "PACI", payload: []byte{0x64, 0x01, 0x00}, err: errH265UnsupportedNaluType},
+		{name: "short FU", payload: []byte{0x62, 0x01}, err: errH265ShortPacket},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := depacketizeH265Payload(tt.payload, false)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,314 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package videoframe
+
+// H265FrameAction is the action to take for an assembled H.265 frame.
+// This is similar to libwebrtc's H265VpsSpsPpsTracker::PacketAction.
+type H265FrameAction int
+
+const (
+	// H265FrameInsert indicates the frame can be passed on for decoding.
+	H265FrameInsert H265FrameAction = iota
+	// H265FrameDrop indicates the frame is malformed and should be dropped.
+	H265FrameDrop
+	// H265FrameRequestKeyFrame indicates the frame references a parameter set
+	// that has not been received. The frame is held back and a keyframe is needed.
+	H265FrameRequestKeyFrame
+)
+
+// h265SpsInfo holds a received SPS and the VPS it refers to.
+type h265SpsInfo struct {
+	vpsID uint32
+	data  []byte
+}
+
+// h265PpsInfo holds a received PPS and the SPS it refers to.
+type h265PpsInfo struct {
+	spsID uint32
+	data  []byte
+}
+
+// H265VpsSpsPpsTracker tracks H.265 VPS, SPS and PPS NAL units and fixes up IRAP
+// frames that arrive without their parameter sets.
+// This is similar to libwebrtc's H265VpsSpsPpsTracker
+// (modules/video_coding/h265_vps_sps_pps_tracker.cc), but operates on assembled
+// Annex-B frames instead of individual packets.
+//
+// For each frame:
+// - VPS, SPS and PPS NAL units are recorded by their IDs
+// - IRAP frames are marked as keyframes
+// - IRAP frames without VPS/SPS/PPS get the latest matching parameter sets prepended
+// - IRAP frames referencing an unknown parameter set are reported with H265FrameRequestKeyFrame
+type H265VpsSpsPpsTracker struct {
+	vpsData map[uint32][]byte
+	spsData map[uint32]h265SpsInfo
+	ppsData map[uint32]h265PpsInfo
+}
+
+// NewH265VpsSpsPpsTracker creates a new H265VpsSpsPpsTracker.
+func NewH265VpsSpsPpsTracker() *H265VpsSpsPpsTracker {
+	return &H265VpsSpsPpsTracker{
+		vpsData: make(map[uint32][]byte),
+		spsData: make(map[uint32]h265SpsInfo),
+		ppsData: make(map[uint32]h265PpsInfo),
+	}
+}
+
+// InsertVpsSpsPpsNalus records out-of-band parameter sets, such as those from
+// the sprop-vps, sprop-sps and sprop-pps SDP attributes. The parameter sets are
+// NAL units without start codes.
+// Returns false if any parameter set cannot be parsed.
+// Reference: libwebrtc h265_vps_sps_pps_tracker.cc InsertVpsSpsPpsNalus()
+func (t *H265VpsSpsPpsTracker) InsertVpsSpsPpsNalus(vps, sps, pps []byte) bool {
+	vpsID, ok := parseH265VpsID(vps)
+	if !ok {
+		return false
+	}
+	spsVpsID, spsID, ok := parseH265SpsIDs(sps)
+	if !ok {
+		return false
+	}
+	ppsID, ppsSpsID, ok := parseH265PpsIDs(pps)
+	if !ok {
+		return false
+	}
+
+	t.vpsData[vpsID] = append([]byte(nil), vps...)
+	t.spsData[spsID] = h265SpsInfo{vpsID: spsVpsID, data: append([]byte(nil), sps...)}
+	t.ppsData[ppsID] = h265PpsInfo{spsID: ppsSpsID, data: append([]byte(nil), pps...)}
+
+	return true
+}
+
+// FixFrame inspects an assembled Annex-B frame, records its parameter sets,
+// sets its FrameType and prepends missing VPS/SPS/PPS to IRAP frames.
+// Reference: libwebrtc h265_vps_sps_pps_tracker.cc CopyAndFixBitstream()
+//
+//nolint:cyclop,gocognit
+func (t *H265VpsSpsPpsTracker) FixFrame(frame *EncodedFrame) H265FrameAction {
+	if frame == nil {
+		return H265FrameDrop
+	}
+
+	nalus := splitAnnexB(frame.Data)
+	if len(nalus) == 0 {
+		return H265FrameDrop
+	}
+
+	hasVps, hasSps, hasPps, hasIRAP := false, false, false, false
+	irapPpsID := uint32(0)
+
+	for _, nalu := range nalus {
+		if len(nalu) < h265NaluHeaderSize {
+			continue
+		}
+
+		naluType := h265NaluType(nalu[0])
+		switch {
+		case naluType == h265NaluTypeVPS:
+			vpsID, ok := parseH265VpsID(nalu)
+			if !ok {
+				return H265FrameDrop
+			}
+			t.vpsData[vpsID] = append([]byte(nil), nalu...)
+			hasVps = true
+		case naluType == h265NaluTypeSPS:
+			vpsID, spsID, ok := parseH265SpsIDs(nalu)
+			if !ok {
+				return H265FrameDrop
+			}
+			t.spsData[spsID] = h265SpsInfo{vpsID: vpsID, data: append([]byte(nil), nalu...)}
+			hasSps = true
+		case naluType == h265NaluTypePPS:
+			ppsID, spsID, ok := parseH265PpsIDs(nalu)
+			if !ok {
+				return H265FrameDrop
+			}
+			t.ppsData[ppsID] = h265PpsInfo{spsID: spsID, data: append([]byte(nil), nalu...)}
+			hasPps = true
+		case isH265IRAP(naluType):
+			if hasIRAP {
+				continue
+			}
+			ppsID, ok := parseH265SlicePpsID(nalu)
+			if !ok {
+				return H265FrameDrop
+			}
+			irapPpsID = ppsID
+			hasIRAP = true
+		}
+	}
+
+	if !hasIRAP {
+		frame.FrameType = FrameTypeDelta
+		return H265FrameInsert
+	}
+	frame.FrameType = FrameTypeKey
+
+	pps, ok := t.ppsData[irapPpsID]
+	if !ok {
+		return H265FrameRequestKeyFrame
+	}
+	sps, ok := t.spsData[pps.spsID]
+	if !ok {
+		return H265FrameRequestKeyFrame
+	}
+	vps, ok := t.vpsData[sps.vpsID]
+	if !ok {
+		return H265FrameRequestKeyFrame
+	}
+
+	if hasVps && hasSps && hasPps {
+		return H265FrameInsert
+	}
+
+	// Insert the missing parameter sets before the first slice
+	data := make([]byte, 0, len(frame.Data)+3*len(annexBStartCode)+len(vps)+len(sps.data)+len(pps.data))
+	inserted := false
+	for _, nalu := range nalus {
+		if len(nalu) == 0 {
+			continue
+		}
+		if !inserted && h265NaluType(nalu[0]) < h265NaluTypeFirstNonVCL {
+			for _, ps := range []struct {
+				present bool
+				data    []byte
+			}{{hasVps, vps}, {hasSps, sps.data}, {hasPps, pps.data}} {
+				if !ps.present {
+					data = append(data, annexBStartCode...)
+					data = append(data, ps.data...)
+				}
+			}
+			inserted = true
+		}
+		data = append(data, annexBStartCode...)
+		data = append(data, nalu...)
+	}
+	frame.Data = data
+
+	return H265FrameInsert
+}
+
+// parseH265VpsID parses vps_video_parameter_set_id from a VPS NAL unit.
+// Reference: ITU-T H.265 Section 7.3.2.1
+func parseH265VpsID(nalu []byte) (uint32, bool) {
+	if len(nalu) <= h265NaluHeaderSize {
+		return 0, false
+	}
+
+	vpsID, err := newExpGolombReader(nalu[h265NaluHeaderSize:]).readBits(4)
+	if err != nil {
+		return 0, false
+	}
+
+	return vpsID, true
+}
+
+// parseH265SpsIDs parses sps_video_parameter_set_id and sps_seq_parameter_set_id
+// from an SPS NAL unit.
+// Reference: ITU-T H.265 Section 7.3.2.2, libwebrtc common_video/h265/h265_sps_parser.cc
+func parseH265SpsIDs(nalu []byte) (vpsID, spsID uint32, ok bool) {
+	// general_profile_space .. general_level_idc of profile_tier_level()
+	const generalProfileTierLevelBits = 96
+	// sub_layer_profile_space .. sub_layer_level_idc are split in profile and level parts
+	const subLayerProfileBits = 88
+	const subLayerLevelBits = 8
+
+	if len(nalu) <= h265NaluHeaderSize {
+		return 0, 0, false
+	}
+
+	r := newExpGolombReader(nalu[h265NaluHeaderSize:])
+	var err error
+	if vpsID, err = r.readBits(4); err != nil {
+		return 0, 0, false
+	}
+	maxSubLayersMinus1, err := r.readBits(3)
+	if err != nil {
+		return 0, 0, false
+	}
+	// sps_temporal_id_nesting_flag
+	if _, err = r.readBits(1); err != nil {
+		return 0, 0, false
+	}
+
+	// profile_tier_level(1, sps_max_sub_layers_minus1)
+	if err = r.skipBits(generalProfileTierLevelBits); err != nil {
+		return 0, 0, false
+	}
+	profilePresent := make([]bool, maxSubLayersMinus1)
+	levelPresent := make([]bool, maxSubLayersMinus1)
+	for i := range profilePresent {
+		flags, err := r.readBits(2)
+		if err != nil {
+			return 0, 0, false
+		}
+		profilePresent[i] = flags&0x2 != 0
+		levelPresent[i] = flags&0x1 != 0
+	}
+	if maxSubLayersMinus1 > 0 {
+		// reserved_zero_2bits for the remaining of 8 sub-layers
+		if err = r.skipBits(2 * int(8-maxSubLayersMinus1)); err != nil {
+			return 0, 0, false
+		}
+	}
+	for i := range profilePresent {
+		if profilePresent[i] {
+			if err = r.skipBits(subLayerProfileBits); err != nil {
+				return 0, 0, false
+			}
+		}
+		if levelPresent[i] {
+			if err = r.skipBits(subLayerLevelBits); err != nil {
+				return 0, 0, false
+			}
+		}
+	}
+
+	if spsID, err = r.readUE(); err != nil {
+		return 0, 0, false
+	}
+
+	return vpsID, spsID, true
+}
+
+// parseH265PpsIDs parses pps_pic_parameter_set_id and pps_seq_parameter_set_id from a PPS NAL unit.
+// Reference: ITU-T H.265 Section 7.3.2.3
+func parseH265PpsIDs(nalu []byte) (ppsID, spsID uint32, ok bool) {
+	if len(nalu) <= h265NaluHeaderSize {
+		return 0, 0, false
+	}
+
+	r := newExpGolombReader(nalu[h265NaluHeaderSize:])
+	var err error
+	if ppsID, err = r.readUE(); err != nil {
+		return 0, 0, false
+	}
+	if spsID, err = r.readUE(); err != nil {
+		return 0, 0, false
+	}
+
+	return ppsID, spsID, true
+}
+
+// parseH265SlicePpsID parses slice_pic_parameter_set_id from the slice segment header
+// of an IRAP NAL unit.
+// Reference: ITU-T H.265 Section 7.3.6.1
+func parseH265SlicePpsID(nalu []byte) (uint32, bool) {
+	if len(nalu) <= h265NaluHeaderSize {
+		return 0, false
+	}
+
+	r := newExpGolombReader(nalu[h265NaluHeaderSize:])
+	// first_slice_segment_in_pic_flag, and no_output_of_prior_pics_flag for IRAP pictures
+	if err := r.skipBits(2); err != nil {
+		return 0, false
+	}
+	ppsID, err := r.readUE()
+	if err != nil {
+		return 0, false
+	}
+
+	return ppsID, true
+}
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package videoframe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestH265VpsSpsPpsTracker_KeyframeWithParameterSets(t *testing.T) {
+	tracker := NewH265VpsSpsPpsTracker()
+
+	data := annexB(testH265VPS, testH265SPS, testH265PPS, testH265IDR)
+	frame := &EncodedFrame{Data: data, FrameType: FrameTypeDelta}
+
+	assert.Equal(t, H265FrameInsert, tracker.FixFrame(frame))
+	assert.Equal(t, FrameTypeKey, frame.FrameType)
+	assert.Equal(t, data, frame.Data, "Complete keyframe should not be modified")
+}
+
+func TestH265VpsSpsPpsTracker_PrependsParameterSets(t *testing.T) {
+	tracker := NewH265VpsSpsPpsTracker()
+	require.True(t, tracker.InsertVpsSpsPpsNalus(testH265VPS, testH265SPS, testH265PPS))
+
+	frame := &EncodedFrame{Data: annexB(testH265IDR)}
+	assert.Equal(t, H265FrameInsert, tracker.FixFrame(frame))
+	assert.Equal(t, FrameTypeKey, frame.FrameType)
+	assert.Equal(t, annexB(testH265VPS, testH265SPS, testH265PPS, testH265IDR), frame.Data)
+
+	frame = &EncodedFrame{Data: annexB(testH265VPS, testH265SPS, testH265IDR)}
+	assert.Equal(t, H265FrameInsert, tracker.FixFrame(frame))
+	assert.Equal(t, annexB(testH265VPS, testH265SPS, testH265PPS, testH265IDR), frame.Data,
+		"Only the missing PPS should be inserted before the first slice")
+}
+
+func TestH265VpsSpsPpsTracker_MissingParameterSets(t *testing.T) {
+	tracker := NewH265VpsSpsPpsTracker()
+
+	frame := &EncodedFrame{Data: annexB(testH265IDR)}
+	assert.Equal(t, H265FrameRequestKeyFrame, tracker.FixFrame(frame))
+
+	// PPS and SPS are known, but the VPS is not
+	frame = &EncodedFrame{Data: annexB(testH265SPS, testH265PPS, testH265IDR)}
+	assert.Equal(t, H265FrameRequestKeyFrame, tracker.FixFrame(frame))
+
+	frame = &EncodedFrame{Data: annexB(testH265VPS, testH265IDR)}
+	assert.Equal(t, H265FrameInsert, tracker.FixFrame(frame))
+}
+
+func TestH265VpsSpsPpsTracker_DeltaFrame(t *testing.T) {
+	tracker := NewH265VpsSpsPpsTracker()
+
+	data := annexB(testH265Trail)
+	frame := &EncodedFrame{Data: data, FrameType: FrameTypeKey}
+	assert.Equal(t, H265FrameInsert, tracker.FixFrame(frame))
+	assert.Equal(t, FrameTypeDelta, frame.FrameType)
+	assert.Equal(t, data, frame.Data)
+
+	assert.Equal(t, H265FrameDrop, tracker.FixFrame(nil))
+	assert.Equal(t, H265FrameDrop, tracker.FixFrame(&EncodedFrame{Data: annexB([]byte{0x26, 0x01})}))
+}
+
+func TestParseH265SpsIDs_SubLayers(t *testing.T) {
+	// sps_max_sub_layers_minus1=1 with sub_layer_profile_present_flag=1 and
+	// sub_layer_level_present_flag=1, sps_seq_parameter_set_id=2
+	sps := []byte{0x42, 0x01, 0x03}
+	for i := 0; i < 12; i++ {
+		sps = append(sps, 0x11) // general profile_tier_level
+	}
+	sps = append(sps, 0xC0) // flags 11 + 7 x reserved 00 in 16 bits
+	sps = append(sps, 0x00)
+	for i := 0; i < 12; i++ {
+		sps = append(sps, 0x22) // sub-layer profile (88 bits) and level (8 bits)
+	}
+	sps = append(sps, 0x60) // ue(2) = 011
+
+	vpsID, spsID, ok := parseH265SpsIDs(sps)
+	require.True(t, ok)
+	assert.Equal(t, uint32(0), vpsID)
+	assert.Equal(t, uint32(2), spsID)
+}
+
+func TestParseFmtpNalus_H265(t *testing.T) {
+	fmtp := "profile-id=1;sprop-vps=QAEMAf8=;sprop-sps=QgEBERERERERERERERERoA==;sprop-pps=RAHA"
+
+	assert.Equal(t, [][]byte{testH265VPS}, parseFmtpNalus(fmtp, "sprop-vps"))
+	assert.Equal(t, [][]byte{testH265SPS}, parseFmtpNalus(fmtp, "sprop-sps"))
+	assert.Equal(t, [][]byte{testH265PPS}, parseFmtpNalus(fmtp, "sprop-pps"))
+}
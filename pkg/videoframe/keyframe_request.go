@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package videoframe
+
+import (
+	"time"
+
+	"github.com/pion/interceptor/internal/ntp"
+	"github.com/pion/rtcp"
+)
+
+// KeyFrameRequestMethod is the RTCP message used to request a keyframe.
+type KeyFrameRequestMethod int
+
+const (
+	// KeyFrameRequestPLI requests keyframes with a Picture Loss Indication (RFC 4585 Section 6.3.1).
+	KeyFrameRequestPLI KeyFrameRequestMethod = iota
+	// KeyFrameRequestFIR requests keyframes with a Full Intra Request (RFC 5104 Section 4.3.1).
+	KeyFrameRequestFIR
+)
+
+const (
+	// defaultUndecodableTimeout is how long frames may stay undecodable before a keyframe is requested.
+	// Reference: libwebrtc video_receive_stream2.cc kMaxWaitForKeyFrameMs
+	defaultUndecodableTimeout = 200 * time.Millisecond
+	// defaultMinKeyFrameRequestInterval is the minimum time between two keyframe requests of a stream.
+	defaultMinKeyFrameRequestInterval = 100 * time.Millisecond
+	// defaultKeyFrameRequestRTT is the round trip time assumed until it is measured.
+	// Reference: libwebrtc modules/video_coding/nack_requester.cc kDefaultRttMs
+	defaultKeyFrameRequestRTT = 100 * time.Millisecond
+)
+
+// keyFrameRequestConfig configures automatic keyframe requests.
+type keyFrameRequestConfig struct {
+	method             KeyFrameRequestMethod
+	undecodableTimeout time.Duration
+	minInterval        time.Duration
+}
+
+func newKeyFrameRequestConfig() *keyFrameRequestConfig {
+	return &keyFrameRequestConfig{
+		method:             KeyFrameRequestPLI,
+		undecodableTimeout: defaultUndecodableTimeout,
+		minInterval:        defaultMinKeyFrameRequestInterval,
+	}
+}
+
+// keyFrameRequester decides when to request a keyframe for a stream.
+// A keyframe is requested when frames stay undecodable for longer than the undecodable
+// timeout, and whenever the receive pipeline signals that decoding cannot continue.
+// Requests are not repeated within a round trip time, since the keyframe cannot arrive sooner.
+// Reference: libwebrtc video/rtp_video_stream_receiver2.cc RequestKeyFrame and
+// video/video_receive_stream2.cc HandleKeyFrameGeneration
+type keyFrameRequester struct {
+	config *keyFrameRequestConfig
+	ssrc   uint32
+
+	// firSeqNum is the sequence number of the last FIR, incremented for each new request.
+	firSeqNum uint8
+
+	lastRequest      time.Time
+	undecodableSince time.Time
+}
+
+func newKeyFrameRequester(config *keyFrameRequestConfig, ssrc uint32) *keyFrameRequester {
+	return &keyFrameRequester{config: config, ssrc: ssrc}
+}
+
+// onFrameUndecodable records an assembled frame whose references could not be resolved.
+func (k *keyFrameRequester) onFrameUndecodable(now time.Time) {
+	if k.undecodableSince.IsZero() {
+		k.undecodableSince = now
+	}
+}
+
+// onFramesDecodable records that frames became decodable.
+func (k *keyFrameRequester) onFramesDecodable() {
+	k.undecodableSince = time.Time{}
+}
+
+// undecodableTimedOut reports whether frames stayed undecodable for too long.
+// The timeout is restarted, so that the request is repeated while frames stay undecodable.
+func (k *keyFrameRequester) undecodableTimedOut(now time.Time) bool {
+	if k.undecodableSince.IsZero() || now.Sub(k.undecodableSince) < k.config.undecodableTimeout {
+		return false
+	}
+	k.undecodableSince = now
+
+	return true
+}
+
+// request returns the RTCP packet requesting a keyframe, or nil if a request was
+// sent less than a round trip time ago.
+func (k *keyFrameRequester) request(now time.Time, rtt time.Duration) rtcp.Packet {
+	if !k.lastRequest.IsZero() && now.Sub(k.lastRequest) < max(k.config.minInterval, rtt) {
+		return nil
+	}
+	k.lastRequest = now
+
+	if k.config.method == KeyFrameRequestFIR {
+		// Each new request increments the sequence number (RFC 5104 Section 4.3.1.1)
+		k.firSeqNum++
+
+		// The media source SSRC of the common header is unused and set to 0, the target
+		// is carried in the FCI entry only (RFC 5104 Section 4.3.1.2)
+		return &rtcp.FullIntraRequest{
+			MediaSSRC: 0,
+			FIR:       []rtcp.FIREntry{{SSRC: k.ssrc, SequenceNumber: k.firSeqNum}},
+		}
+	}
+
+	return &rtcp.PictureLossIndication{MediaSSRC: k.ssrc}
+}
+
+// rttFromReceptionReport returns the round trip time from a reception report about a
+// local stream received at now (RFC 3550 Section 6.4.1).
+func rttFromReceptionReport(report rtcp.ReceptionReport, now time.Time) (time.Duration, bool) {
+	return rttFromDelay(report.LastSenderReport, report.Delay, now)
+}
+
+// rttFromDelay computes the round trip time from the middle 32 bits of the NTP timestamp
+// of a local report and the delay since its reception, both in 1/65536 seconds.
+func rttFromDelay(lastReport, delay uint32, now time.Time) (time.Duration, bool) {
+	if lastReport == 0 {
+		return 0, false
+	}
+
+	rtt := int32(ntp.ToNTP32(now) - lastReport - delay) //nolint:gosec // G115
+	if rtt < 0 {
+		return 0, false
+	}
+
+	return time.Duration(int64(rtt) * int64(time.Second) >> 16), true
+}
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package videoframe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/interceptor/internal/ntp"
+	"github.com/pion/rtcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyFrameRequester_RateLimit(t *testing.T) {
+	requester := newKeyFrameRequester(newKeyFrameRequestConfig(), 1234)
+	now := time.Unix(1000, 0)
+
+	pkt := requester.request(now, 50*time.Millisecond)
+	assert.Equal(t, &rtcp.PictureLossIndication{MediaSSRC: 1234}, pkt)
+
+	// Limited by the minimum interval
+	assert.Nil(t, requester.request(now.Add(99*time.Millisecond), 50*time.Millisecond))
+	assert.NotNil(t, requester.request(now.Add(100*time.Millisecond), 50*time.Millisecond))
+
+	// Limited by the round trip time
+	now = now.Add(100 * time.Millisecond)
+	assert.Nil(t, requester.request(now.Add(299*time.Millisecond), 300*time.Millisecond))
+	assert.NotNil(t, requester.request(now.Add(300*time.Millisecond), 300*time.Millisecond))
+}
+
+func TestKeyFrameRequester_FIR(t *testing.T) {
+	config := newKeyFrameRequestConfig()
+	require.NoError(t, WithKeyFrameRequestMethod(KeyFrameRequestFIR)(config))
+	requester := newKeyFrameRequester(config, 1234)
+	now := time.Unix(1000, 0)
+
+	for i := 1; i <= 3; i++ {
+		pkt := requester.request(now, 0)
+		fir, ok := pkt.(*rtcp.FullIntraRequest)
+		require.True(t, ok)
+		assert.Equal(t, uint32(0), fir.MediaSSRC)
+		assert.Equal(t, []rtcp.FIREntry{{SSRC: 1234, SequenceNumber: uint8(i)}}, fir.FIR) //nolint:gosec // G115
+
+		// Rate limited requests don't use a sequence number
+		assert.Nil(t, requester.request(now, 0))
+		now = now.Add(time.Second)
+	}
+}
+
+func TestKeyFrameRequester_UndecodableTimeout(t *testing.T) {
+	requester := newKeyFrameRequester(newKeyFrameRequestConfig(), 1234)
+	now := time.Unix(1000, 0)
+
+	assert.False(t, requester.undecodableTimedOut(now.Add(time.Hour)))
+
+	requester.onFrameUndecodable(now)
+	requester.onFrameUndecodable(now.Add(100 * time.Millisecond))
+	assert.False(t, requester.undecodableTimedOut(now.Add(199*time.Millisecond)))
+	assert.True(t, requester.undecodableTimedOut(now.Add(200*time.Millisecond)))
+
+	// The timeout restarts while frames stay undecodable
+	assert.False(t, requester.undecodableTimedOut(now.Add(300*time.Millisecond)))
+	assert.True(t, requester.undecodableTimedOut(now.Add(400*time.Millisecond)))
+
+	requester.onFramesDecodable()
+	assert.False(t, requester.undecodableTimedOut(now.Add(time.Hour)))
+}
+
+func TestRTTFromDelay(t *testing.T) {
+	now := time.Unix(1000, 0)
+	lastReport := ntp.ToNTP32(now.Add(-300 * time.Millisecond))
+	delay := uint32(65536 / 10) // 100ms
+
+	rtt, ok := rttFromDelay(lastReport, delay, now)
+	require.True(t, ok)
+	assert.InDelta(t, float64(200*time.Millisecond), float64(rtt), float64(time.Millisecond))
+
+	_, ok = rttFromDelay(0, delay, now)
+	assert.False(t, ok, "No report received yet")
+
+	_, ok = rttFromDelay(lastReport, 65536, now)
+	assert.False(t, ok, "Delay longer than the elapsed time")
+}
+
+func TestKeyFrameRequestOptions(t *testing.T) {
+	config := newKeyFrameRequestConfig()
+	assert.ErrorIs(t, WithKeyFrameRequestMethod(KeyFrameRequestMethod(5))(config), errInvalidKeyFrameRequestMethod)
+	assert.ErrorIs(t, WithUndecodableTimeout(0)(config), errInvalidUndecodableTimeout)
+	assert.ErrorIs(t, WithMinKeyFrameRequestInterval(-time.Second)(config), errInvalidKeyFrameRequestInterval)
+}
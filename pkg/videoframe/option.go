@@ -4,6 +4,8 @@
 package videoframe
 
 import (
+	"time"
+
 	"github.com/pion/logging"
 )
 
@@ -35,3 +37,133 @@ func WithLoggerFactory(loggerFactory logging.LoggerFactory) ReceiverInterceptorO
 		return nil
 	}
 }
+
+// WithFrameBuffer enables a FrameBuffer for each stream.
+// Frames are then also available in decoding order through ReceiverInterceptor.NextFrame.
+func WithFrameBuffer(opts ...FrameBufferOption) ReceiverInterceptorOption {
+	return func(r *ReceiverInterceptor) error {
+		r.frameBufferEnabled = true
+		r.frameBufferOpts = opts
+		return nil
+	}
+}
+
+// FrameBufferOption can be used to configure FrameBuffer.
+type FrameBufferOption func(b *FrameBuffer) error
+
+// WithFrameBufferMaxFrames sets the maximum number of buffered frames.
+// Default is 800.
+func WithFrameBufferMaxFrames(n int) FrameBufferOption {
+	return func(b *FrameBuffer) error {
+		if n <= 0 {
+			return errInvalidFrameBufferSize
+		}
+		b.maxFrames = n
+		return nil
+	}
+}
+
+// WithRenderDelay sets the time needed to render a frame, which is added to the target delay.
+// Default is 10ms.
+func WithRenderDelay(delay time.Duration) FrameBufferOption {
+	return func(b *FrameBuffer) error {
+		b.timing.renderDelay = delay
+		return nil
+	}
+}
+
+// WithPlayoutDelay bounds the target delay, as signaled by the playout-delay header extension.
+// Default is 0 to 10s.
+func WithPlayoutDelay(minDelay, maxDelay time.Duration) FrameBufferOption {
+	return func(b *FrameBuffer) error {
+		if minDelay < 0 || maxDelay < minDelay {
+			return errInvalidPlayoutDelay
+		}
+		b.timing.minPlayoutDelay = minDelay
+		b.timing.maxPlayoutDelay = maxDelay
+		return nil
+	}
+}
+
+// WithKeyFrameRequests enables sending keyframe requests over RTCP when frames of a stream
+// stay undecodable, when the packet buffer is cleared, or when parameter sets are missing.
+// With keyframe requests, a full packet buffer is cleared instead of overwriting the packets
+// of incomplete frames, and packets of frames older than a delivered frame are dropped.
+func WithKeyFrameRequests(opts ...KeyFrameRequestOption) ReceiverInterceptorOption {
+	return func(r *ReceiverInterceptor) error {
+		config := newKeyFrameRequestConfig()
+		for _, opt := range opts {
+			if err := opt(config); err != nil {
+				return err
+			}
+		}
+		r.keyFrameRequests = config
+		return nil
+	}
+}
+
+// KeyFrameRequestOption can be used to configure keyframe requests.
+type KeyFrameRequestOption func(c *keyFrameRequestConfig) error
+
+// WithKeyFrameRequestMethod sets the RTCP message used to request keyframes.
+// Default is KeyFrameRequestPLI.
+func WithKeyFrameRequestMethod(method KeyFrameRequestMethod) KeyFrameRequestOption {
+	return func(c *keyFrameRequestConfig) error {
+		if method != KeyFrameRequestPLI && method != KeyFrameRequestFIR {
+			return errInvalidKeyFrameRequestMethod
+		}
+		c.method = method
+		return nil
+	}
+}
+
+// WithUndecodableTimeout sets how long frames may stay undecodable before a keyframe is requested.
+// Default is 200ms.
+func WithUndecodableTimeout(timeout time.Duration) KeyFrameRequestOption {
+	return func(c *keyFrameRequestConfig) error {
+		if timeout <= 0 {
+			return errInvalidUndecodableTimeout
+		}
+		c.undecodableTimeout = timeout
+		return nil
+	}
+}
+
+// WithMinKeyFrameRequestInterval sets the minimum time between two keyframe requests of a stream.
+// Requests are also not repeated within the round trip time. Default is 100ms.
+func WithMinKeyFrameRequestInterval(interval time.Duration) KeyFrameRequestOption {
+	return func(c *keyFrameRequestConfig) error {
+		if interval < 0 {
+			return errInvalidKeyFrameRequestInterval
+		}
+		c.minInterval = interval
+		return nil
+	}
+}
+
+// WithFrameQueueSize sets the capacity of the channels returned by ReceiverInterceptor.Frames.
+// Default is 64.
+func WithFrameQueueSize(size int) ReceiverInterceptorOption {
+	return func(r *ReceiverInterceptor) error {
+		if size <= 0 {
+			return errInvalidFrameQueueSize
+		}
+		r.frameQueueSize = size
+		return nil
+	}
+}
+
+// WithFrameQueuePolicy sets what happens when a frame is delivered to a full channel
+// returned by ReceiverInterceptor.Frames. Dropped frames trigger a keyframe request,
+// see KeyFrameRequestKey. Default is FrameQueueDropOldest.
+func WithFrameQueuePolicy(policy FrameQueuePolicy) ReceiverInterceptorOption {
+	return func(r *ReceiverInterceptor) error {
+		switch policy {
+		case FrameQueueDropOldest, FrameQueueDropNewest, FrameQueueBlock:
+		default:
+			return errInvalidFrameQueuePolicy
+		}
+		r.frameQueuePolicy = policy
+		return nil
+	}
+}
@@ -36,6 +36,11 @@ type InsertResult struct {
 	// Empty if no frame was completed.
 	// Multiple frames may be returned when packet loss recovery completes multiple frames.
 	Frames [][]*BufferedPacket
+
+	// BufferCleared indicates the buffer was full and has been cleared.
+	// The inserted packet was dropped and a keyframe is needed to continue decoding.
+	// Only set if the buffer is cleared when full, see clearWhenFull.
+	BufferCleared bool
 }
 
 // VideoPacketBuffer buffers video RTP packets and detects complete frames.
@@ -49,6 +54,18 @@ type InsertResult struct {
 type VideoPacketBuffer struct {
 	buffer []*BufferedPacket
 	size   uint16
+
+	// frameEnds holds the sequence numbers of the last packets of extracted frames.
+	// Used to detect H.264, H.265 and AV1 frame boundaries after the previous frame has been removed.
+	frameEnds map[int64]struct{}
+
+	// clearedTo is the last sequence number cleared by ClearTo. Older packets are dropped.
+	clearedTo  int64
+	hasCleared bool
+
+	// clearWhenFull clears the buffer when a packet needs a slot that is still used, instead
+	// of replacing the older packet. Set when keyframes can be requested to recover.
+	clearWhenFull bool
 }
 
 // NewVideoPacketBuffer creates a new VideoPacketBuffer with the specified size.
@@ -64,8 +81,9 @@ func NewVideoPacketBuffer(size uint16) (*VideoPacketBuffer, error) {
 	}
 
 	return &VideoPacketBuffer{
-		buffer: make([]*BufferedPacket, size),
-		size:   size,
+		buffer:    make([]*BufferedPacket, size),
+		size:      size,
+		frameEnds: make(map[int64]struct{}),
 	}, nil
 }
 
@@ -77,11 +95,25 @@ func (b *VideoPacketBuffer) InsertPacket(pkt *BufferedPacket) InsertResult {
 	seqNum := pkt.SequenceNumber
 	index := b.seqNumToIndex(seqNum)
 
+	// Packets of frames that were already decoded are not needed anymore
+	if b.hasCleared && seqNum <= b.clearedTo {
+		return result
+	}
+
 	// Check for duplicate packet
 	if b.buffer[index] != nil && b.buffer[index].SequenceNumber == seqNum {
 		return result // Duplicate, ignore
 	}
 
+	// The slot is still used by a packet of an incomplete frame, so the buffer is full.
+	// Reference: libwebrtc packet_buffer.cc InsertPacket (ClearInternal and buffer_cleared)
+	if b.buffer[index] != nil && b.clearWhenFull {
+		b.Clear()
+		result.BufferCleared = true
+
+		return result
+	}
+
 	// Clear slot if it contains an old packet
 	if b.buffer[index] != nil {
 		b.buffer[index] = nil
@@ -91,12 +123,47 @@ func (b *VideoPacketBuffer) InsertPacket(pkt *BufferedPacket) InsertResult {
 	pkt.Continuous = false
 	b.buffer[index] = pkt
 
+	// Forget frame ends that are outside the buffer window
+	for end := range b.frameEnds {
+		if end < seqNum-int64(b.size) || end > seqNum+int64(b.size) {
+			delete(b.frameEnds, end)
+		}
+	}
+
 	// Try to find completed frames
 	result.Frames = b.findFrames(seqNum)
 
 	return result
 }
 
+// ClearTo removes all packets up to and including seqNum, typically the last packet
+// of a decoded frame. Packets up to seqNum inserted afterwards are dropped.
+// Reference: libwebrtc PacketBuffer::ClearTo (packet_buffer.cc)
+func (b *VideoPacketBuffer) ClearTo(seqNum int64) {
+	if b.hasCleared && seqNum <= b.clearedTo {
+		return
+	}
+
+	for i, pkt := range b.buffer {
+		if pkt != nil && pkt.SequenceNumber <= seqNum {
+			b.buffer[i] = nil
+		}
+	}
+
+	b.clearedTo = seqNum
+	b.hasCleared = true
+}
+
+// Clear removes all packets from the buffer.
+// Reference: libwebrtc PacketBuffer::ClearInternal (packet_buffer.cc)
+func (b *VideoPacketBuffer) Clear() {
+	for i := range b.buffer {
+		b.buffer[i] = nil
+	}
+	b.frameEnds = make(map[int64]struct{})
+	b.hasCleared = false
+}
+
 // seqNumToIndex converts a sequence number to a buffer index.
 func (b *VideoPacketBuffer) seqNumToIndex(seqNum int64) int {
 	// Handle negative sequence numbers for wrap-around
@@ -142,6 +209,7 @@ func (b *VideoPacketBuffer) findFrames(seqNum int64) [][]*BufferedPacket {
 					idx := b.seqNumToIndex(pkt.SequenceNumber)
 					b.buffer[idx] = nil
 				}
+				b.frameEnds[currentSeq] = struct{}{}
 			}
 		}
 	}
@@ -192,6 +260,11 @@ func (b *VideoPacketBuffer) potentialNewFrame(seqNum int64) bool {
 // extractFrame extracts a complete frame ending at endSeqNum.
 // Returns packets in sequence order, or empty if frame is incomplete.
 func (b *VideoPacketBuffer) extractFrame(endSeqNum int64) []*BufferedPacket {
+	endPkt := b.buffer[b.seqNumToIndex(endSeqNum)]
+	if endPkt != nil && !hasFrameBeginBit(endPkt.VideoHeader) {
+		return b.extractFrameByTimestamp(endSeqNum)
+	}
+
 	// Find the start of the frame by walking backwards
 	startSeqNum := endSeqNum
 
@@ -234,3 +307,75 @@ func (b *VideoPacketBuffer) extractFrame(endSeqNum int64) []*BufferedPacket {
 
 	return packets
 }
+
+// hasFrameBeginBit reports whether IsFirstPacketInFrame reliably marks the start of a frame.
+// H.264, H.265 and AV1 without a Dependency Descriptor only mark the start of a NAL unit or OBU.
+func hasFrameBeginBit(header *RTPVideoHeader) bool {
+	if header == nil {
+		return true
+	}
+
+	switch header.Codec {
+	case VideoCodecH264, VideoCodecH265:
+		return false
+	case VideoCodecAV1:
+		return header.Generic != nil
+	default:
+		return true
+	}
+}
+
+// extractFrameByTimestamp extracts a complete frame ending at endSeqNum for codecs
+// without a frame begin bit (H.264, H.265, AV1 without Dependency Descriptor).
+// IsFirstPacketInFrame only marks the start of a NAL unit or OBU, so the start of
+// the frame is found by walking backwards as long as the previous packet is present
+// and has the same timestamp.
+//
+// Since a missing packet at the start of the frame cannot be detected this way,
+// delta frames are only returned when the packet preceding the frame is present
+// or ended a previously extracted frame. Keyframes (frames containing an IDR or IRAP
+// picture, or starting a new AV1 coded video sequence) are returned regardless.
+//
+// Reference: libwebrtc PacketBuffer::FindFrames (packet_buffer.cc, is_h264 handling)
+func (b *VideoPacketBuffer) extractFrameByTimestamp(endSeqNum int64) []*BufferedPacket {
+	endPkt := b.buffer[b.seqNumToIndex(endSeqNum)]
+	timestamp := endPkt.Timestamp
+
+	startSeqNum := endSeqNum
+	for endSeqNum-startSeqNum < int64(b.size)-1 {
+		prevPkt := b.buffer[b.seqNumToIndex(startSeqNum-1)]
+		if prevPkt == nil || prevPkt.SequenceNumber != startSeqNum-1 || prevPkt.Timestamp != timestamp {
+			break
+		}
+		startSeqNum--
+	}
+
+	firstPkt := b.buffer[b.seqNumToIndex(startSeqNum)]
+	if firstPkt.VideoHeader == nil || !firstPkt.VideoHeader.IsFirstPacketInFrame {
+		return nil // Frame does not start at a NAL unit or OBU boundary
+	}
+
+	var packets []*BufferedPacket
+	isKeyframe := false
+	for seq := startSeqNum; seq <= endSeqNum; seq++ {
+		pkt := b.buffer[b.seqNumToIndex(seq)]
+		if !pkt.Continuous {
+			return nil // Not continuous
+		}
+		if pkt.VideoHeader != nil && pkt.VideoHeader.FrameType == FrameTypeKey {
+			isKeyframe = true
+		}
+		packets = append(packets, pkt)
+	}
+
+	if !isKeyframe {
+		// The packet before the frame must be known to know the frame is complete
+		prevPkt := b.buffer[b.seqNumToIndex(startSeqNum-1)]
+		_, prevFrameEnd := b.frameEnds[startSeqNum-1]
+		if !prevFrameEnd && (prevPkt == nil || prevPkt.SequenceNumber != startSeqNum-1) {
+			return nil
+		}
+	}
+
+	return packets
+}
@@ -365,3 +365,159 @@ func TestVideoPacketBuffer_InvalidSize(t *testing.T) {
 	_, err = NewVideoPacketBuffer(2048) // Max valid
 	assert.NoError(t, err)
 }
+
+func h264BufferedPacket(seq int64, ts uint32, naluStart, last, key bool) *BufferedPacket {
+	frameType := FrameTypeDelta
+	if key {
+		frameType = FrameTypeKey
+	}
+
+	return &BufferedPacket{
+		SequenceNumber: seq,
+		Timestamp:      ts,
+		Payload:        []byte{byte(seq)},
+		MarkerBit:      last,
+		VideoHeader: &RTPVideoHeader{
+			Codec:                VideoCodecH264,
+			FrameType:            frameType,
+			IsFirstPacketInFrame: naluStart,
+			IsLastPacketInFrame:  last,
+		},
+	}
+}
+
+func TestVideoPacketBuffer_H264FrameStartByTimestamp(t *testing.T) {
+	// H.264 marks the start of every NAL unit as first packet.
+	// The frame must span all packets with the same timestamp.
+	buffer, err := NewVideoPacketBuffer(512)
+	require.NoError(t, err)
+
+	// SPS, PPS, IDR (each a single NAL unit packet) with the same timestamp
+	result := buffer.InsertPacket(h264BufferedPacket(100, 3000, true, false, false))
+	assert.Empty(t, result.Frames)
+	result = buffer.InsertPacket(h264BufferedPacket(101, 3000, true, false, false))
+	assert.Empty(t, result.Frames)
+	result = buffer.InsertPacket(h264BufferedPacket(102, 3000, true, true, true))
+	require.Len(t, result.Frames, 1)
+	require.Len(t, result.Frames[0], 3, "Frame should include all packets with the same timestamp")
+	assert.Equal(t, int64(100), result.Frames[0][0].SequenceNumber)
+
+	// Next delta frame follows the extracted keyframe
+	result = buffer.InsertPacket(h264BufferedPacket(103, 6000, true, true, false))
+	require.Len(t, result.Frames, 1)
+	assert.Equal(t, int64(103), result.Frames[0][0].SequenceNumber)
+}
+
+func TestVideoPacketBuffer_H264DeltaFrameWaitsForPredecessor(t *testing.T) {
+	// A delta frame whose preceding packet is missing may be incomplete
+	buffer, err := NewVideoPacketBuffer(512)
+	require.NoError(t, err)
+
+	result := buffer.InsertPacket(h264BufferedPacket(100, 3000, true, true, true))
+	require.Len(t, result.Frames, 1)
+
+	// Packet 101 (first NAL unit of the next frame) is missing
+	result = buffer.InsertPacket(h264BufferedPacket(102, 6000, true, true, false))
+	assert.Empty(t, result.Frames, "Delta frame should be held until the gap is resolved")
+
+	result = buffer.InsertPacket(h264BufferedPacket(101, 6000, true, false, false))
+	require.Len(t, result.Frames, 1)
+	require.Len(t, result.Frames[0], 2)
+	assert.Equal(t, int64(101), result.Frames[0][0].SequenceNumber)
+	assert.Equal(t, int64(102), result.Frames[0][1].SequenceNumber)
+}
+
+func TestVideoPacketBuffer_H264FragmentedNalu(t *testing.T) {
+	// FU-A fragments: only the first fragment starts a NAL unit
+	buffer, err := NewVideoPacketBuffer(512)
+	require.NoError(t, err)
+
+	result := buffer.InsertPacket(h264BufferedPacket(200, 3000, true, false, true))
+	assert.Empty(t, result.Frames)
+	result = buffer.InsertPacket(h264BufferedPacket(202, 3000, false, true, true))
+	assert.Empty(t, result.Frames, "Frame with missing middle fragment should not complete")
+	result = buffer.InsertPacket(h264BufferedPacket(201, 3000, false, false, true))
+	require.Len(t, result.Frames, 1)
+	assert.Len(t, result.Frames[0], 3)
+}
+
+func TestVideoPacketBuffer_SlotReplaced(t *testing.T) {
+	buffer, err := NewVideoPacketBuffer(64)
+	require.NoError(t, err)
+
+	// First packet of a frame that never completes
+	buffer.InsertPacket(&BufferedPacket{
+		SequenceNumber: 0,
+		Timestamp:      1000,
+		VideoHeader:    &RTPVideoHeader{IsFirstPacketInFrame: true},
+	})
+
+	// The packet 64 later replaces it
+	result := buffer.InsertPacket(&BufferedPacket{
+		SequenceNumber: 64,
+		Timestamp:      2000,
+		VideoHeader:    &RTPVideoHeader{IsFirstPacketInFrame: true, IsLastPacketInFrame: true},
+	})
+	assert.False(t, result.BufferCleared)
+	assert.Len(t, result.Frames, 1)
+}
+
+func TestVideoPacketBuffer_BufferCleared(t *testing.T) {
+	buffer, err := NewVideoPacketBuffer(64)
+	require.NoError(t, err)
+	buffer.clearWhenFull = true
+
+	// First packet of a frame that never completes
+	result := buffer.InsertPacket(&BufferedPacket{
+		SequenceNumber: 0,
+		Timestamp:      1000,
+		VideoHeader:    &RTPVideoHeader{IsFirstPacketInFrame: true},
+	})
+	assert.False(t, result.BufferCleared)
+
+	// The packet 64 later needs the same slot
+	result = buffer.InsertPacket(&BufferedPacket{
+		SequenceNumber: 64,
+		Timestamp:      2000,
+		VideoHeader:    &RTPVideoHeader{IsFirstPacketInFrame: true, IsLastPacketInFrame: true},
+	})
+	assert.True(t, result.BufferCleared)
+	assert.Empty(t, result.Frames, "The packet is dropped")
+
+	result = buffer.InsertPacket(&BufferedPacket{
+		SequenceNumber: 65,
+		Timestamp:      3000,
+		VideoHeader:    &RTPVideoHeader{IsFirstPacketInFrame: true, IsLastPacketInFrame: true},
+	})
+	assert.False(t, result.BufferCleared)
+	assert.Len(t, result.Frames, 1)
+}
+
+func TestVideoPacketBuffer_ClearTo(t *testing.T) {
+	buffer, err := NewVideoPacketBuffer(64)
+	require.NoError(t, err)
+
+	buffer.InsertPacket(&BufferedPacket{
+		SequenceNumber: 0,
+		Timestamp:      1000,
+		VideoHeader:    &RTPVideoHeader{IsFirstPacketInFrame: true},
+	})
+	buffer.ClearTo(10)
+
+	// The slot was freed, no overflow
+	result := buffer.InsertPacket(&BufferedPacket{
+		SequenceNumber: 64,
+		Timestamp:      2000,
+		VideoHeader:    &RTPVideoHeader{IsFirstPacketInFrame: true, IsLastPacketInFrame: true},
+	})
+	assert.False(t, result.BufferCleared)
+	assert.Len(t, result.Frames, 1)
+
+	// Packets up to the cleared sequence number are dropped
+	result = buffer.InsertPacket(&BufferedPacket{
+		SequenceNumber: 5,
+		Timestamp:      1500,
+		VideoHeader:    &RTPVideoHeader{IsFirstPacketInFrame: true, IsLastPacketInFrame: true},
+	})
+	assert.Empty(t, result.Frames)
+}
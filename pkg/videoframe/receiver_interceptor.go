@@ -4,11 +4,14 @@
 package videoframe
 
 import (
+	"context"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/pion/interceptor"
 	"github.com/pion/logging"
+	"github.com/pion/rtcp"
 	"github.com/pion/rtp"
 	"github.com/pion/rtp/codecs"
 )
@@ -24,13 +27,24 @@ const EncodedFramesKey = "videoframe.EncodedFrames"
 // When a frame is completed, it will be available via attrs.Get(EncodedFrameKey).
 const EncodedFrameKey = "videoframe.EncodedFrame"
 
+// KeyFrameRequestKey is the Attributes key signaling that the stream needs a keyframe.
+// The value is true when a frame could not be decoded without a new keyframe,
+// for example an H.264 IDR frame referencing an SPS/PPS that was never received,
+// when the packet buffer was cleared, or when a frame was dropped from a full Frames channel.
+const KeyFrameRequestKey = "videoframe.KeyFrameRequest"
+
 // defaultPacketBufferSize is the default packet buffer size.
 // Reference: libwebrtc kPacketBufferStartSize = 512
 const defaultPacketBufferSize = 512
 
+// NewPeerConnectionCallback receives the ReceiverInterceptor created for the
+// PeerConnection with id.
+type NewPeerConnectionCallback func(id string, receiver *ReceiverInterceptor)
+
 // ReceiverInterceptorFactory is a interceptor.Factory for ReceiverInterceptor.
 type ReceiverInterceptorFactory struct {
-	opts []ReceiverInterceptorOption
+	opts              []ReceiverInterceptorOption
+	addPeerConnection NewPeerConnectionCallback
 }
 
 // NewReceiverInterceptor returns a new ReceiverInterceptorFactory.
@@ -38,11 +52,21 @@ func NewReceiverInterceptor(opts ...ReceiverInterceptorOption) (*ReceiverInterce
 	return &ReceiverInterceptorFactory{opts: opts}, nil
 }
 
+// OnNewPeerConnection sets a callback that is called when a new ReceiverInterceptor
+// is created. It can be used to pull frames with ReceiverInterceptor.NextFrame.
+func (f *ReceiverInterceptorFactory) OnNewPeerConnection(cb NewPeerConnectionCallback) {
+	f.addPeerConnection = cb
+}
+
 // NewInterceptor constructs a new ReceiverInterceptor.
-func (f *ReceiverInterceptorFactory) NewInterceptor(_ string) (interceptor.Interceptor, error) {
+func (f *ReceiverInterceptorFactory) NewInterceptor(id string) (interceptor.Interceptor, error) {
 	r := &ReceiverInterceptor{
 		streams:          make(map[uint32]*streamState),
 		packetBufferSize: defaultPacketBufferSize,
+		frameSinks:       make(map[uint32]*frameSink),
+		frameQueueSize:   defaultFrameQueueSize,
+		rtt:              defaultKeyFrameRequestRTT,
+		now:              time.Now,
 	}
 
 	for _, opt := range f.opts {
@@ -58,6 +82,10 @@ func (f *ReceiverInterceptorFactory) NewInterceptor(_ string) (interceptor.Inter
 		r.log = r.loggerFactory.NewLogger("videoframe")
 	}
 
+	if f.addPeerConnection != nil {
+		f.addPeerConnection(id, r)
+	}
+
 	return r, nil
 }
 
@@ -72,6 +100,25 @@ type streamState struct {
 	seqNumOnlyRefFinder  *SeqNumOnlyRefFinder
 	frameIdOnlyRefFinder *FrameIdOnlyRefFinder
 	vp8RefFinder         *VP8RefFinder
+	vp9RefFinder         *VP9RefFinder
+
+	// h264Tracker tracks SPS/PPS for H.264 streams. nil for other codecs.
+	h264Tracker *H264SpsPpsTracker
+
+	// h265Tracker tracks VPS/SPS/PPS for H.265 streams. nil for other codecs.
+	h265Tracker *H265VpsSpsPpsTracker
+
+	// isAV1 indicates the frame payload must be reassembled from AV1 OBU elements.
+	isAV1 bool
+
+	// genericRefFinder resolves references from the Dependency Descriptor.
+	genericRefFinder *GenericRefFinder
+
+	// frameBuffer orders resolved frames for decoding. nil unless WithFrameBuffer is used.
+	frameBuffer *FrameBuffer
+
+	// keyFrameRequester decides when to request keyframes. nil unless WithKeyFrameRequests is used.
+	keyFrameRequester *keyFrameRequester
 }
 
 // sequenceUnwrapper unwraps 16-bit sequence numbers to int64.
@@ -111,12 +158,23 @@ func (u *sequenceUnwrapper) unwrap(seq uint16) int64 {
 //	    }
 //	}
 //
+// Resolved frames of a stream can also be received with Frames or OnFrame, which do not
+// depend on the packet being read.
+//
+// With WithFrameBuffer, resolved frames are also inserted into a per-stream FrameBuffer
+// and can be pulled in decoding order with NextFrame.
+//
+// With WithKeyFrameRequests, a PLI or FIR is sent when a keyframe is needed, see KeyFrameRequestKey,
+// or when frames stay undecodable. The round trip time used to rate limit the requests is
+// measured from the reception reports about local streams.
+//
 // This interceptor:
-// 1. Parses VP8 RTP payloads to detect frame boundaries
+// 1. Parses VP8, VP9, H.264, H.265 and AV1 RTP payloads to detect frame boundaries
 // 2. Buffers packets until a complete frame is available
 // 3. Assembles complete frames and adds them to Attributes
 //    - EncodedFramesKey: []*EncodedFrame (all completed frames)
 //    - EncodedFrameKey: *EncodedFrame (first frame only, for backward compatibility)
+//    - KeyFrameRequestKey: bool (set when a keyframe is needed to continue decoding)
 //
 // Reference: libwebrtc video/rtp_video_stream_receiver2.cc
 type ReceiverInterceptor struct {
@@ -127,6 +185,91 @@ type ReceiverInterceptor struct {
 	packetBufferSize uint16
 	log              logging.LeveledLogger
 	loggerFactory    logging.LoggerFactory
+
+	frameBufferEnabled bool
+	frameBufferOpts    []FrameBufferOption
+
+	frameSinks       map[uint32]*frameSink
+	frameQueueSize   int
+	frameQueuePolicy FrameQueuePolicy
+
+	keyFrameRequests *keyFrameRequestConfig
+	rtcpWriter       interceptor.RTCPWriter
+	rtt              time.Duration
+	now              func() time.Time
+}
+
+// BindRTCPWriter lets you modify any outgoing RTCP packets. It is called once per PeerConnection.
+// The writer is used to send keyframe requests.
+func (r *ReceiverInterceptor) BindRTCPWriter(writer interceptor.RTCPWriter) interceptor.RTCPWriter {
+	r.streamsMu.Lock()
+	defer r.streamsMu.Unlock()
+	r.rtcpWriter = writer
+
+	return writer
+}
+
+// BindRTCPReader lets you modify any incoming RTCP packets. It is called once per sender/receiver.
+// Reception reports are used to measure the round trip time when keyframe requests are enabled.
+func (r *ReceiverInterceptor) BindRTCPReader(reader interceptor.RTCPReader) interceptor.RTCPReader {
+	if r.keyFrameRequests == nil {
+		return reader
+	}
+
+	return interceptor.RTCPReaderFunc(func(b []byte, a interceptor.Attributes) (int, interceptor.Attributes, error) {
+		n, attrs, err := reader.Read(b, a)
+		if err != nil {
+			return n, attrs, err
+		}
+
+		if attrs == nil {
+			attrs = make(interceptor.Attributes)
+		}
+		pkts, err := attrs.GetRTCPPackets(b[:n])
+		if err != nil {
+			return n, attrs, nil //nolint:nilerr // Pass through on parse error
+		}
+
+		r.updateRTT(pkts)
+
+		return n, attrs, nil
+	})
+}
+
+// updateRTT updates the round trip time from reception reports and DLRR report blocks.
+// Reference: libwebrtc modules/rtp_rtcp/source/rtcp_receiver.cc HandleReportBlock and HandleXrDlrrReportBlock
+func (r *ReceiverInterceptor) updateRTT(pkts []rtcp.Packet) {
+	now := r.now()
+
+	var reports []rtcp.ReceptionReport
+	var dlrrs []rtcp.DLRRReport
+	for _, pkt := range pkts {
+		switch pkt := pkt.(type) {
+		case *rtcp.ReceiverReport:
+			reports = append(reports, pkt.Reports...)
+		case *rtcp.SenderReport:
+			reports = append(reports, pkt.Reports...)
+		case *rtcp.ExtendedReport:
+			for _, block := range pkt.Reports {
+				if dlrr, ok := block.(*rtcp.DLRRReportBlock); ok {
+					dlrrs = append(dlrrs, dlrr.Reports...)
+				}
+			}
+		}
+	}
+
+	r.streamsMu.Lock()
+	defer r.streamsMu.Unlock()
+	for _, report := range reports {
+		if rtt, ok := rttFromReceptionReport(report, now); ok {
+			r.rtt = rtt
+		}
+	}
+	for _, dlrr := range dlrrs {
+		if rtt, ok := rttFromDelay(dlrr.LastRR, dlrr.DLRR, now); ok {
+			r.rtt = rtt
+		}
+	}
 }
 
 // BindRemoteStream lets you modify any incoming RTP packets.
@@ -135,8 +278,9 @@ func (r *ReceiverInterceptor) BindRemoteStream(
 	info *interceptor.StreamInfo,
 	reader interceptor.RTPReader,
 ) interceptor.RTPReader {
-	// Only process VP8 streams
-	if !isVP8Stream(info) {
+	// Only process VP8, VP9, H.264, H.265 and AV1 streams
+	depacketize := videoDepacketizerForStream(info)
+	if depacketize == nil {
 		return reader
 	}
 
@@ -144,7 +288,7 @@ func (r *ReceiverInterceptor) BindRemoteStream(
 
 	// Initialize stream state
 	r.streamsMu.Lock()
-	state, err := r.getOrCreateStreamState(ssrc)
+	state, err := r.getOrCreateStreamState(info)
 	r.streamsMu.Unlock()
 
 	if err != nil {
@@ -164,28 +308,25 @@ func (r *ReceiverInterceptor) BindRemoteStream(
 			return n, attrs, nil // Pass through on parse error
 		}
 
-		// Parse VP8 payload
-		vp8 := &codecs.VP8Packet{}
-		if _, err := vp8.Unmarshal(pkt.Payload); err != nil {
-			return n, attrs, nil // Pass through on VP8 parse error
+		// Parse codec payload descriptor
+		videoHeader, videoPayload, ok := depacketize(pkt)
+		if !ok {
+			return n, attrs, nil // Pass through on payload parse error
 		}
 
-		// Create video header from VP8 packet
-		videoHeader := NewRTPVideoHeaderFromVP8(vp8, pkt.Marker)
-
 		// Unwrap sequence number
 		unwrappedSeq := state.seqUnwrapper.unwrap(pkt.SequenceNumber)
 
 		// Create buffered packet
-		// Use vp8.Payload (depacketized video payload without VP8 descriptor)
-		// instead of pkt.Payload (raw RTP payload with VP8 descriptor)
+		// Use the depacketized video payload (without codec payload descriptor)
+		// instead of pkt.Payload (raw RTP payload with descriptor)
 		// Reference: libwebrtc's depacketizer extracts video_payload from RTP payload
 		//
-		// IMPORTANT: Copy the payload because vp8.Payload references the Read buffer b,
+		// IMPORTANT: Copy the payload because it references the Read buffer b,
 		// which will be overwritten on the next Read call.
 		// Reference: libwebrtc video_rtp_depacketizer copies video_payload
-		payloadCopy := make([]byte, len(vp8.Payload))
-		copy(payloadCopy, vp8.Payload)
+		payloadCopy := make([]byte, len(videoPayload))
+		copy(payloadCopy, videoPayload)
 
 		bufferedPkt := &BufferedPacket{
 			SequenceNumber: unwrappedSeq,
@@ -200,6 +341,15 @@ func (r *ReceiverInterceptor) BindRemoteStream(
 		result := state.packetBuffer.InsertPacket(bufferedPkt)
 		r.streamsMu.Unlock()
 
+		now := r.now()
+
+		// A cleared packet buffer loses packets of incomplete frames
+		// Reference: libwebrtc rtp_video_stream_receiver2.cc OnInsertedPacket (buffer_cleared)
+		keyFrameRequested := result.BufferCleared
+		if result.BufferCleared {
+			r.log.Warnf("Packet buffer full for SSRC %d, cleared it", ssrc)
+		}
+
 		// Check for completed frames
 		if len(result.Frames) > 0 {
 			var resolvedFrames []*EncodedFrame
@@ -209,6 +359,55 @@ func (r *ReceiverInterceptor) BindRemoteStream(
 					continue
 				}
 
+				// AV1 payloads are kept whole until the OBU elements of all packets are known
+				// Reference: libwebrtc video_rtp_depacketizer_av1.cc AssembleFrame
+				if state.isAV1 {
+					payloads := make([][]byte, len(framePackets))
+					for i, pkt := range framePackets {
+						payloads[i] = pkt.Payload
+					}
+					data, err := assembleAV1Frame(payloads)
+					if err != nil {
+						r.log.Debugf("Dropping AV1 frame for SSRC %d: %v", ssrc, err)
+						continue
+					}
+					frame.Data = data
+				}
+
+				// Insert missing SPS/PPS into H.264 keyframes
+				// Reference: libwebrtc rtp_video_stream_receiver2.cc tracker_.CopyAndFixBitstream
+				if state.h264Tracker != nil {
+					r.streamsMu.Lock()
+					action := state.h264Tracker.FixFrame(frame)
+					r.streamsMu.Unlock()
+
+					switch action {
+					case H264FrameRequestKeyFrame:
+						keyFrameRequested = true
+						continue
+					case H264FrameDrop:
+						continue
+					default:
+					}
+				}
+
+				// Insert missing VPS/SPS/PPS into H.265 keyframes
+				// Reference: libwebrtc rtp_video_stream_receiver2.cc h265_tracker_.CopyAndFixBitstream
+				if state.h265Tracker != nil {
+					r.streamsMu.Lock()
+					action := state.h265Tracker.FixFrame(frame)
+					r.streamsMu.Unlock()
+
+					switch action {
+					case H265FrameRequestKeyFrame:
+						keyFrameRequested = true
+						continue
+					case H265FrameDrop:
+						continue
+					default:
+					}
+				}
+
 				// Get video header from first packet for reference finder selection
 				var firstHeader *RTPVideoHeader
 				if len(framePackets) > 0 && framePackets[0].VideoHeader != nil {
@@ -222,6 +421,30 @@ func (r *ReceiverInterceptor) BindRemoteStream(
 				// Resolve frame references
 				resolved := refFinder.ManageFrame(frame, firstHeader)
 				resolvedFrames = append(resolvedFrames, resolved...)
+				if state.keyFrameRequester != nil {
+					if len(resolved) == 0 {
+						state.keyFrameRequester.onFrameUndecodable(now)
+					} else {
+						state.keyFrameRequester.onFramesDecodable()
+					}
+				}
+				r.streamsMu.Unlock()
+			}
+
+			if state.frameBuffer != nil {
+				for _, frame := range resolvedFrames {
+					state.frameBuffer.InsertFrame(frame)
+				}
+			} else if len(resolvedFrames) > 0 && state.keyFrameRequester != nil {
+				// Frames delivered through Attributes are decoded right away, packets of
+				// older incomplete frames are not needed anymore and would fill the buffer
+				// Reference: libwebrtc rtp_video_stream_receiver2.cc FrameDecoded
+				lastSeqNum := resolvedFrames[0].LastSeqNumUnwrapped
+				for _, frame := range resolvedFrames[1:] {
+					lastSeqNum = max(lastSeqNum, frame.LastSeqNumUnwrapped)
+				}
+				r.streamsMu.Lock()
+				state.packetBuffer.ClearTo(lastSeqNum)
 				r.streamsMu.Unlock()
 			}
 
@@ -232,6 +455,33 @@ func (r *ReceiverInterceptor) BindRemoteStream(
 				// Set both keys for compatibility
 				attrs.Set(EncodedFramesKey, resolvedFrames)
 				attrs.Set(EncodedFrameKey, resolvedFrames[0]) // First frame for backward compatibility
+
+				if !r.deliverFrames(ssrc, resolvedFrames) {
+					// The dropped frame is missing as a reference for later frames
+					keyFrameRequested = true
+				}
+			}
+		}
+
+		if state.keyFrameRequester != nil {
+			r.streamsMu.Lock()
+			if state.keyFrameRequester.undecodableTimedOut(now) {
+				r.log.Debugf("Frames undecodable for SSRC %d, requesting keyframe", ssrc)
+				keyFrameRequested = true
+			}
+			r.streamsMu.Unlock()
+		}
+
+		if keyFrameRequested {
+			if attrs == nil {
+				attrs = make(interceptor.Attributes)
+			}
+			attrs.Set(KeyFrameRequestKey, true)
+
+			if state.keyFrameRequester != nil {
+				if err := r.sendKeyFrameRequest(state, now); err != nil {
+					r.log.Warnf("Failed to send keyframe request for SSRC %d: %v", ssrc, err)
+				}
 			}
 		}
 
@@ -243,19 +493,167 @@ func (r *ReceiverInterceptor) BindRemoteStream(
 func (r *ReceiverInterceptor) UnbindRemoteStream(info *interceptor.StreamInfo) {
 	r.streamsMu.Lock()
 	defer r.streamsMu.Unlock()
+	if state, ok := r.streams[info.SSRC]; ok && state.frameBuffer != nil {
+		_ = state.frameBuffer.Close()
+	}
 	delete(r.streams, info.SSRC)
+	if sink, ok := r.frameSinks[info.SSRC]; ok {
+		sink.close()
+	}
+	delete(r.frameSinks, info.SSRC)
 }
 
 // Close closes the interceptor.
 func (r *ReceiverInterceptor) Close() error {
 	r.streamsMu.Lock()
 	defer r.streamsMu.Unlock()
+	for _, state := range r.streams {
+		if state.frameBuffer != nil {
+			_ = state.frameBuffer.Close()
+		}
+	}
 	r.streams = make(map[uint32]*streamState)
+	for _, sink := range r.frameSinks {
+		sink.close()
+	}
+	r.frameSinks = make(map[uint32]*frameSink)
 	return nil
 }
 
-// getOrCreateStreamState gets or creates the stream state for the given SSRC.
-func (r *ReceiverInterceptor) getOrCreateStreamState(ssrc uint32) (*streamState, error) {
+// Frames returns a channel receiving the resolved frames of the stream with the given SSRC,
+// in the order they are set in EncodedFramesKey. It may be called before the stream is bound
+// and returns the same channel until the stream is unbound.
+// The channel holds up to WithFrameQueueSize frames. When it is full, frames are dropped
+// or the RTP Read is blocked depending on WithFrameQueuePolicy. The channel is closed
+// when the stream is unbound or the interceptor is closed.
+func (r *ReceiverInterceptor) Frames(ssrc uint32) <-chan *EncodedFrame {
+	r.streamsMu.Lock()
+	defer r.streamsMu.Unlock()
+
+	return r.getOrCreateFrameSink(ssrc).channel()
+}
+
+// OnFrame sets a callback receiving the resolved frames of the stream with the given SSRC,
+// in the order they are set in EncodedFramesKey. It may be called before the stream is bound
+// and is removed when the stream is unbound. A nil callback removes the callback.
+// The callback is called from the RTP Read of the stream, which is blocked until it returns.
+func (r *ReceiverInterceptor) OnFrame(ssrc uint32, callback func(frame *EncodedFrame)) {
+	r.streamsMu.Lock()
+	defer r.streamsMu.Unlock()
+
+	r.getOrCreateFrameSink(ssrc).callback = callback
+}
+
+// getOrCreateFrameSink gets or creates the frame sink for the given SSRC.
+// This method should be called with streamsMu held.
+func (r *ReceiverInterceptor) getOrCreateFrameSink(ssrc uint32) *frameSink {
+	sink, ok := r.frameSinks[ssrc]
+	if !ok {
+		sink = newFrameSink(r.frameQueueSize, r.frameQueuePolicy)
+		r.frameSinks[ssrc] = sink
+	}
+
+	return sink
+}
+
+// deliverFrames delivers resolved frames to the OnFrame callback and the Frames channel
+// of the stream. It returns false if a frame was dropped.
+func (r *ReceiverInterceptor) deliverFrames(ssrc uint32, frames []*EncodedFrame) bool {
+	r.streamsMu.Lock()
+	sink, ok := r.frameSinks[ssrc]
+	var callback func(*EncodedFrame)
+	if ok {
+		callback = sink.callback
+	}
+	r.streamsMu.Unlock()
+
+	if !ok {
+		return true
+	}
+
+	delivered := true
+	for _, frame := range frames {
+		if !sink.push(frame, callback) {
+			r.log.Debugf("Frame queue full for SSRC %d, dropped a frame", ssrc)
+			delivered = false
+		}
+	}
+
+	return delivered
+}
+
+// NextFrame blocks until the next frame of the stream with the given SSRC is due
+// for decoding. Frames are returned in decoding order, with all references released
+// before, and with RenderTime set.
+// Requires WithFrameBuffer. Returns an error if the stream is unbound while waiting.
+// Reference: libwebrtc video/video_receive_stream2.cc OnEncodedFrame
+func (r *ReceiverInterceptor) NextFrame(ctx context.Context, ssrc uint32) (*EncodedFrame, error) {
+	if !r.frameBufferEnabled {
+		return nil, errFrameBufferDisabled
+	}
+
+	r.streamsMu.Lock()
+	state, ok := r.streams[ssrc]
+	r.streamsMu.Unlock()
+	if !ok || state.frameBuffer == nil {
+		return nil, errUnknownStream
+	}
+
+	frame, err := state.frameBuffer.NextFrame(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Packets of older incomplete frames are not needed once the frame is decoded
+	// Reference: libwebrtc rtp_video_stream_receiver2.cc FrameDecoded
+	r.streamsMu.Lock()
+	state.packetBuffer.ClearTo(frame.LastSeqNumUnwrapped)
+	r.streamsMu.Unlock()
+
+	return frame, nil
+}
+
+// RequestKeyFrame sends a keyframe request for the stream with the given SSRC,
+// for example after a decoding error. Requires WithKeyFrameRequests.
+// Requests within a round trip time of the previous request are not sent.
+// Reference: libwebrtc video/rtp_video_stream_receiver2.cc RequestKeyFrame
+func (r *ReceiverInterceptor) RequestKeyFrame(ssrc uint32) error {
+	if r.keyFrameRequests == nil {
+		return errKeyFrameRequestsDisabled
+	}
+
+	r.streamsMu.Lock()
+	state, ok := r.streams[ssrc]
+	r.streamsMu.Unlock()
+	if !ok {
+		return errUnknownStream
+	}
+
+	return r.sendKeyFrameRequest(state, r.now())
+}
+
+// sendKeyFrameRequest sends a PLI or FIR for the stream, unless rate limited.
+func (r *ReceiverInterceptor) sendKeyFrameRequest(state *streamState, now time.Time) error {
+	r.streamsMu.Lock()
+	writer := r.rtcpWriter
+	var pkt rtcp.Packet
+	if writer != nil {
+		pkt = state.keyFrameRequester.request(now, r.rtt)
+	}
+	r.streamsMu.Unlock()
+
+	if pkt == nil {
+		return nil
+	}
+
+	_, err := writer.Write([]rtcp.Packet{pkt}, interceptor.Attributes{})
+
+	return err
+}
+
+// getOrCreateStreamState gets or creates the stream state for the given stream.
+func (r *ReceiverInterceptor) getOrCreateStreamState(info *interceptor.StreamInfo) (*streamState, error) {
+	ssrc := info.SSRC
 	if state, ok := r.streams[ssrc]; ok {
 		return state, nil
 	}
@@ -265,10 +663,55 @@ func (r *ReceiverInterceptor) getOrCreateStreamState(ssrc uint32) (*streamState,
 		return nil, err
 	}
 
+	packetBuffer.clearWhenFull = r.keyFrameRequests != nil
+
 	state := &streamState{
 		packetBuffer:   packetBuffer,
 		frameAssembler: NewVideoFrameAssembler(),
 		seqUnwrapper:   &sequenceUnwrapper{},
+		isAV1:          isAV1Stream(info),
+	}
+
+	if r.frameBufferEnabled {
+		if state.frameBuffer, err = NewFrameBuffer(r.frameBufferOpts...); err != nil {
+			return nil, err
+		}
+	}
+
+	if r.keyFrameRequests != nil {
+		state.keyFrameRequester = newKeyFrameRequester(r.keyFrameRequests, ssrc)
+	}
+
+	if isH264Stream(info) {
+		state.h264Tracker = NewH264SpsPpsTracker()
+
+		// Out-of-band parameter sets from sprop-parameter-sets
+		// Reference: libwebrtc rtp_video_stream_receiver2.cc AddReceiveCodec (sprop-parameter-sets)
+		var sps, pps []byte
+		for _, nalu := range parseSpropParameterSets(info.SDPFmtpLine) {
+			switch nalu[0] & h264NaluTypeMask {
+			case h264NaluTypeSPS:
+				sps = nalu
+			case h264NaluTypePPS:
+				pps = nalu
+			}
+		}
+		if sps != nil && pps != nil && !state.h264Tracker.InsertSpsPpsNalus(sps, pps) {
+			r.log.Warnf("Failed to parse sprop-parameter-sets for SSRC %d", ssrc)
+		}
+	}
+
+	if isH265Stream(info) {
+		state.h265Tracker = NewH265VpsSpsPpsTracker()
+
+		// Out-of-band parameter sets from sprop-vps, sprop-sps and sprop-pps (RFC 7798 Section 7.1)
+		vps := parseFmtpNalus(info.SDPFmtpLine, "sprop-vps")
+		sps := parseFmtpNalus(info.SDPFmtpLine, "sprop-sps")
+		pps := parseFmtpNalus(info.SDPFmtpLine, "sprop-pps")
+		if len(vps) > 0 && len(sps) > 0 && len(pps) > 0 &&
+			!state.h265Tracker.InsertVpsSpsPpsNalus(vps[0], sps[0], pps[0]) {
+			r.log.Warnf("Failed to parse sprop-vps/sps/pps for SSRC %d", ssrc)
+		}
 	}
 
 	r.streams[ssrc] = state
@@ -280,9 +723,11 @@ func (r *ReceiverInterceptor) getOrCreateStreamState(ssrc uint32) (*streamState,
 // This method should be called with streamsMu held.
 //
 // Reference finder selection (based on libwebrtc rtp_frame_reference_finder.cc):
-// 1. If temporal layer info is available (TID, TL0PICIDX, PictureID all present) -> VP8RefFinder
-// 2. If only picture ID is available -> FrameIdOnlyRefFinder
-// 3. Otherwise -> SeqNumOnlyRefFinder
+// 1. If a Dependency Descriptor is present -> GenericRefFinder
+// 2. If VP9 reference info is available (PictureID with P_DIFF or layer info) -> VP9RefFinder
+// 3. If temporal layer info is available (TID, TL0PICIDX, PictureID all present) -> VP8RefFinder
+// 4. If only picture ID is available -> FrameIdOnlyRefFinder
+// 5. Otherwise -> SeqNumOnlyRefFinder
 //
 // Each ref finder is created lazily and kept for the stream lifetime.
 // This allows frame-by-frame selection based on actual available info,
@@ -292,6 +737,16 @@ func (r *ReceiverInterceptor) selectRefFinderForFrame(state *streamState, header
 	refType := SelectRefFinderType(header)
 
 	switch refType {
+	case RefFinderGeneric:
+		if state.genericRefFinder == nil {
+			state.genericRefFinder = NewGenericRefFinder()
+		}
+		return state.genericRefFinder
+	case RefFinderVP9:
+		if state.vp9RefFinder == nil {
+			state.vp9RefFinder = NewVP9RefFinder()
+		}
+		return state.vp9RefFinder
 	case RefFinderVP8:
 		if state.vp8RefFinder == nil {
 			state.vp8RefFinder = NewVP8RefFinder()
@@ -310,6 +765,111 @@ func (r *ReceiverInterceptor) selectRefFinderForFrame(state *streamState, header
 	}
 }
 
+// videoDepacketizer parses the codec payload descriptor of an RTP packet.
+// It returns the video header, the depacketized payload and whether parsing succeeded.
+// Reference: libwebrtc modules/rtp_rtcp/source/video_rtp_depacketizer.h
+type videoDepacketizer func(pkt *rtp.Packet) (*RTPVideoHeader, []byte, bool)
+
+// videoDepacketizerForStream returns the depacketizer for the stream's codec,
+// or nil if the codec is not supported.
+// The returned depacketizer may keep per-stream state and must only be used for this stream.
+func videoDepacketizerForStream(info *interceptor.StreamInfo) videoDepacketizer {
+	switch {
+	case isAV1Stream(info):
+		return newAV1Depacketizer(info)
+	case isVP8Stream(info):
+		return depacketizeVP8
+	case isVP9Stream(info):
+		return depacketizeVP9
+	case isH264Stream(info):
+		return depacketizeH264
+	case isH265Stream(info):
+		return depacketizeH265
+	default:
+		return nil
+	}
+}
+
+// depacketizeVP8 parses a VP8 RTP payload.
+// Reference: libwebrtc video_rtp_depacketizer_vp8.cc
+func depacketizeVP8(pkt *rtp.Packet) (*RTPVideoHeader, []byte, bool) {
+	vp8 := &codecs.VP8Packet{}
+	if _, err := vp8.Unmarshal(pkt.Payload); err != nil {
+		return nil, nil, false
+	}
+
+	return NewRTPVideoHeaderFromVP8(vp8, pkt.Marker), vp8.Payload, true
+}
+
+// depacketizeVP9 parses a VP9 RTP payload.
+// Reference: libwebrtc video_rtp_depacketizer_vp9.cc
+func depacketizeVP9(pkt *rtp.Packet) (*RTPVideoHeader, []byte, bool) {
+	vp9 := &codecs.VP9Packet{}
+	if _, err := vp9.Unmarshal(pkt.Payload); err != nil {
+		return nil, nil, false
+	}
+
+	header := NewRTPVideoHeaderFromVP9(vp9)
+	if vp9.I {
+		header.VP9.MaxPictureID = vp9MaxPictureID(pkt.Payload)
+	}
+
+	return header, vp9.Payload, true
+}
+
+// depacketizeH264 parses an H.264 RTP payload into Annex-B NAL units.
+// Reference: libwebrtc video_rtp_depacketizer_h264.cc
+func depacketizeH264(pkt *rtp.Packet) (*RTPVideoHeader, []byte, bool) {
+	header, payload, err := depacketizeH264Payload(pkt.Payload, pkt.Marker)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	return header, payload, true
+}
+
+// newAV1Depacketizer returns a depacketizer for an AV1 stream.
+// When the Dependency Descriptor header extension is negotiated, frame boundaries,
+// frame type and frame dependencies are taken from it.
+// Reference: libwebrtc rtp_video_stream_receiver2.cc ParseGenericDependenciesExtension
+func newAV1Depacketizer(info *interceptor.StreamInfo) videoDepacketizer {
+	var ddExtID uint8
+	for _, e := range info.RTPHeaderExtensions {
+		if e.URI == dependencyDescriptorURI {
+			ddExtID = uint8(e.ID) //nolint:gosec // G115
+
+			break
+		}
+	}
+	ddReceiver := &dependencyDescriptorReceiver{}
+
+	return func(pkt *rtp.Packet) (*RTPVideoHeader, []byte, bool) {
+		header, payload, err := depacketizeAV1Payload(pkt.Payload, pkt.Marker)
+		if err != nil {
+			return nil, nil, false
+		}
+
+		if ddExtID != 0 {
+			if ext := pkt.GetExtension(ddExtID); ext != nil && !ddReceiver.applyToHeader(header, ext) {
+				return nil, nil, false
+			}
+		}
+
+		return header, payload, true
+	}
+}
+
+// depacketizeH265 parses an H.265 RTP payload into Annex-B NAL units.
+// Reference: libwebrtc video_rtp_depacketizer_h265.cc
+func depacketizeH265(pkt *rtp.Packet) (*RTPVideoHeader, []byte, bool) {
+	header, payload, err := depacketizeH265Payload(pkt.Payload, pkt.Marker)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	return header, payload, true
+}
+
 // isVP8Stream checks if the stream is a VP8 video stream.
 func isVP8Stream(info *interceptor.StreamInfo) bool {
 	if info == nil {
@@ -317,3 +877,35 @@ func isVP8Stream(info *interceptor.StreamInfo) bool {
 	}
 	return strings.EqualFold(info.MimeType, "video/VP8")
 }
+
+// isVP9Stream checks if the stream is a VP9 video stream.
+func isVP9Stream(info *interceptor.StreamInfo) bool {
+	if info == nil {
+		return false
+	}
+	return strings.EqualFold(info.MimeType, "video/VP9")
+}
+
+// isH264Stream checks if the stream is an H.264 video stream.
+func isH264Stream(info *interceptor.StreamInfo) bool {
+	if info == nil {
+		return false
+	}
+	return strings.EqualFold(info.MimeType, "video/H264")
+}
+
+// isAV1Stream checks if the stream is an AV1 video stream.
+func isAV1Stream(info *interceptor.StreamInfo) bool {
+	if info == nil {
+		return false
+	}
+	return strings.EqualFold(info.MimeType, "video/AV1")
+}
+
+// isH265Stream checks if the stream is an H.265 video stream.
+func isH265Stream(info *interceptor.StreamInfo) bool {
+	if info == nil {
+		return false
+	}
+	return strings.EqualFold(info.MimeType, "video/H265")
+}
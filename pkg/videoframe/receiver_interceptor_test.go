@@ -4,9 +4,13 @@
 package videoframe
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/pion/interceptor"
+	"github.com/pion/interceptor/internal/ntp"
+	"github.com/pion/rtcp"
 	"github.com/pion/rtp"
 	"github.com/pion/rtp/codecs"
 	"github.com/stretchr/testify/assert"
@@ -537,3 +541,707 @@ func TestReceiverInterceptor_VP8MultiPacketDescriptorRemoved(t *testing.T) {
 	assert.Equal(t, len(expectedData), len(frame.Data),
 		"Frame size should match expected VP8 bitstream size (without descriptors)")
 }
+
+// TestReceiverInterceptor_VP9SpatialLayers verifies that each VP9 spatial layer is
+// assembled as a separate frame with the VP9 payload descriptor removed, and that
+// references are resolved from the scalability structure and inter-layer dependency.
+func TestReceiverInterceptor_VP9SpatialLayers(t *testing.T) {
+	factory, err := NewReceiverInterceptor()
+	require.NoError(t, err)
+
+	i, err := factory.NewInterceptor("")
+	require.NoError(t, err)
+	defer func() { _ = i.Close() }()
+
+	info := &interceptor.StreamInfo{
+		SSRC:        123456,
+		ClockRate:   90000,
+		MimeType:    "video/VP9",
+		PayloadType: 98,
+	}
+
+	// Picture 0, SID=0 keyframe: I=1 L=1 B=1 E=1 V=1, TID=0 SID=0, TL0PICIDX=0,
+	// SS: N_S=1 Y=0 G=1, N_G=1 (T=0 U=0 R=1 P_DIFF=1)
+	base := []byte{0xAE, 0x00, 0x00, 0x00, 0x28, 0x01, 0x04, 0x01, 0x11, 0x22}
+	// Picture 0, SID=1 keyframe with inter-layer dependency: I=1 L=1 B=1 E=1, SID=1 D=1
+	upper := []byte{0xAC, 0x00, 0x03, 0x00, 0x33, 0x44}
+	// Picture 1, SID=0 delta: I=1 P=1 L=1 B=1 E=1, TL0PICIDX=1
+	delta := []byte{0xEC, 0x01, 0x00, 0x01, 0x55}
+
+	packets := []*rtp.Packet{
+		{
+			Header:  rtp.Header{Version: 2, PayloadType: 98, SequenceNumber: 1000, Timestamp: 90000, SSRC: 123456},
+			Payload: base,
+		},
+		{
+			Header:  rtp.Header{Version: 2, PayloadType: 98, SequenceNumber: 1001, Timestamp: 90000, SSRC: 123456, Marker: true},
+			Payload: upper,
+		},
+		{
+			Header:  rtp.Header{Version: 2, PayloadType: 98, SequenceNumber: 1002, Timestamp: 93000, SSRC: 123456, Marker: true},
+			Payload: delta,
+		},
+	}
+
+	packetIdx := 0
+	reader := i.BindRemoteStream(info, interceptor.RTPReaderFunc(
+		func(b []byte, attrs interceptor.Attributes) (int, interceptor.Attributes, error) {
+			pkt := packets[packetIdx]
+			packetIdx++
+			data, _ := pkt.Marshal()
+			copy(b, data)
+			return len(data), attrs, nil
+		},
+	))
+
+	var frames []*EncodedFrame
+	buf := make([]byte, 1500)
+	for range packets {
+		_, attrs, err := reader.Read(buf, interceptor.Attributes{})
+		require.NoError(t, err)
+		if f, ok := attrs.Get(EncodedFramesKey).([]*EncodedFrame); ok {
+			frames = append(frames, f...)
+		}
+	}
+
+	require.Len(t, frames, 3)
+
+	assert.Equal(t, FrameTypeKey, frames[0].FrameType)
+	assert.Equal(t, int8(0), frames[0].SpatialIdx)
+	assert.Equal(t, int64(0), frames[0].ID)
+	assert.Equal(t, 0, frames[0].NumReferences)
+	assert.Equal(t, []byte{0x11, 0x22}, frames[0].Data)
+
+	assert.Equal(t, int8(1), frames[1].SpatialIdx)
+	assert.Equal(t, int64(1), frames[1].ID)
+	require.Equal(t, 1, frames[1].NumReferences)
+	assert.Equal(t, int64(0), frames[1].References[0], "Upper layer should reference base layer")
+	assert.Equal(t, []byte{0x33, 0x44}, frames[1].Data)
+
+	assert.Equal(t, FrameTypeDelta, frames[2].FrameType)
+	assert.Equal(t, int64(maxVP9SpatialLayers), frames[2].ID)
+	require.Equal(t, 1, frames[2].NumReferences)
+	assert.Equal(t, int64(0), frames[2].References[0])
+	assert.Equal(t, []byte{0x55}, frames[2].Data)
+}
+
+// readStreamPackets binds a stream and reads the given packets,
+// returning all frames and whether a keyframe request was signaled.
+func readStreamPackets(t *testing.T, info *interceptor.StreamInfo, packets []*rtp.Packet) ([]*EncodedFrame, bool) {
+	t.Helper()
+
+	factory, err := NewReceiverInterceptor()
+	require.NoError(t, err)
+
+	i, err := factory.NewInterceptor("")
+	require.NoError(t, err)
+	defer func() { _ = i.Close() }()
+
+	packetIdx := 0
+	reader := i.BindRemoteStream(info, interceptor.RTPReaderFunc(
+		func(b []byte, attrs interceptor.Attributes) (int, interceptor.Attributes, error) {
+			pkt := packets[packetIdx]
+			packetIdx++
+			data, _ := pkt.Marshal()
+			copy(b, data)
+			return len(data), attrs, nil
+		},
+	))
+
+	var frames []*EncodedFrame
+	keyFrameRequested := false
+	buf := make([]byte, 1500)
+	for range packets {
+		_, attrs, err := reader.Read(buf, interceptor.Attributes{})
+		require.NoError(t, err)
+		if f, ok := attrs.Get(EncodedFramesKey).([]*EncodedFrame); ok {
+			frames = append(frames, f...)
+		}
+		if requested, ok := attrs.Get(KeyFrameRequestKey).(bool); ok && requested {
+			keyFrameRequested = true
+		}
+	}
+
+	return frames, keyFrameRequested
+}
+
+func TestReceiverInterceptor_H264(t *testing.T) {
+	info := &interceptor.StreamInfo{
+		SSRC:        123456,
+		ClockRate:   90000,
+		MimeType:    "video/H264",
+		PayloadType: 102,
+	}
+
+	packets := []*rtp.Packet{
+		// Keyframe: STAP-A with SPS/PPS, then IDR as FU-A
+		{
+			Header:  rtp.Header{Version: 2, PayloadType: 102, SequenceNumber: 1000, Timestamp: 3000, SSRC: 123456},
+			Payload: stapA(testH264SPS, testH264PPS),
+		},
+		{
+			Header:  rtp.Header{Version: 2, PayloadType: 102, SequenceNumber: 1001, Timestamp: 3000, SSRC: 123456},
+			Payload: []byte{0x7C, 0x85, 0x88, 0x84},
+		},
+		{
+			Header:  rtp.Header{Version: 2, PayloadType: 102, SequenceNumber: 1002, Timestamp: 3000, SSRC: 123456, Marker: true},
+			Payload: []byte{0x7C, 0x45, 0x21, 0xA0},
+		},
+		// Delta frame: single NAL unit
+		{
+			Header:  rtp.Header{Version: 2, PayloadType: 102, SequenceNumber: 1003, Timestamp: 6000, SSRC: 123456, Marker: true},
+			Payload: testH264Slice,
+		},
+		// IDR without SPS/PPS: latest parameter sets are prepended
+		{
+			Header:  rtp.Header{Version: 2, PayloadType: 102, SequenceNumber: 1004, Timestamp: 9000, SSRC: 123456, Marker: true},
+			Payload: testH264IDR,
+		},
+	}
+
+	frames, keyFrameRequested := readStreamPackets(t, info, packets)
+	assert.False(t, keyFrameRequested)
+	require.Len(t, frames, 3)
+
+	assert.Equal(t, FrameTypeKey, frames[0].FrameType)
+	assert.Equal(t, annexB(testH264SPS, testH264PPS, testH264IDR), frames[0].Data)
+	assert.Equal(t, uint16(1000), frames[0].FirstSeqNum)
+	assert.Equal(t, uint16(1002), frames[0].LastSeqNum)
+
+	assert.Equal(t, FrameTypeDelta, frames[1].FrameType)
+	assert.Equal(t, annexB(testH264Slice), frames[1].Data)
+	require.Equal(t, 1, frames[1].NumReferences)
+	assert.Equal(t, frames[0].ID, frames[1].References[0])
+
+	assert.Equal(t, FrameTypeKey, frames[2].FrameType)
+	assert.Equal(t, annexB(testH264SPS, testH264PPS, testH264IDR), frames[2].Data)
+}
+
+func TestReceiverInterceptor_H264MissingParameterSets(t *testing.T) {
+	info := &interceptor.StreamInfo{
+		SSRC:        123456,
+		ClockRate:   90000,
+		MimeType:    "video/H264",
+		PayloadType: 102,
+	}
+
+	packets := []*rtp.Packet{
+		{
+			Header:  rtp.Header{Version: 2, PayloadType: 102, SequenceNumber: 1000, Timestamp: 3000, SSRC: 123456, Marker: true},
+			Payload: testH264IDR,
+		},
+	}
+
+	frames, keyFrameRequested := readStreamPackets(t, info, packets)
+	assert.Empty(t, frames, "IDR without known SPS/PPS should be held back")
+	assert.True(t, keyFrameRequested, "Keyframe should be requested")
+
+	// With sprop-parameter-sets, the same IDR can be decoded
+	info.SDPFmtpLine = "packetization-mode=1;sprop-parameter-sets=Z0LAH9o=,aM48gA=="
+	frames, keyFrameRequested = readStreamPackets(t, info, packets)
+	assert.False(t, keyFrameRequested)
+	require.Len(t, frames, 1)
+	assert.Equal(t, annexB(testH264SPS, testH264PPS, testH264IDR), frames[0].Data)
+}
+
+func TestReceiverInterceptor_AV1DependencyDescriptor(t *testing.T) {
+	const ddExtID = 5
+	info := &interceptor.StreamInfo{
+		SSRC:        123456,
+		ClockRate:   90000,
+		MimeType:    "video/AV1",
+		PayloadType: 45,
+		RTPHeaderExtensions: []interceptor.RTPHeaderExtension{
+			{URI: dependencyDescriptorURI, ID: ddExtID},
+		},
+	}
+
+	newPacket := func(seq uint16, ts uint32, marker bool, dd, payload []byte) *rtp.Packet {
+		pkt := &rtp.Packet{
+			Header: rtp.Header{
+				Version: 2, PayloadType: 45, SequenceNumber: seq, Timestamp: ts, SSRC: 123456, Marker: marker,
+			},
+			Payload: payload,
+		}
+		require.NoError(t, pkt.SetExtension(ddExtID, dd))
+
+		return pkt
+	}
+
+	// Keyframe split over two packets, the OBU is fragmented between them
+	keyFirst := testDDKeyframe(10)
+	keyFirst[0] &^= 0x40 // end_of_frame=0
+	keyLastDD := &ddBitWriter{}
+	keyLastDD.writeMandatory(false, true, 0, 10)
+
+	first := []byte{0x68, byte(len(testAV1SequenceHeader))} // Y=1 W=2 N=1
+	first = append(first, testAV1SequenceHeader...)
+	first = append(first, testAV1Frame[:3]...)
+	last := append([]byte{0x90}, testAV1Frame[3:]...) // Z=1 W=1
+
+	packets := []*rtp.Packet{
+		newPacket(2000, 90000, false, keyFirst, first),
+		newPacket(2001, 90000, true, keyLastDD.data, last),
+		newPacket(2002, 93000, true, testDDDelta(2, 11), append([]byte{0x10}, testAV1Frame...)),
+		newPacket(2003, 96000, true, testDDDelta(1, 12), append([]byte{0x10}, testAV1Frame...)),
+	}
+
+	frames, _ := readStreamPackets(t, info, packets)
+	require.Len(t, frames, 3)
+
+	assert.Equal(t, FrameTypeKey, frames[0].FrameType)
+	assert.Equal(t, int64(10), frames[0].ID)
+	assert.Equal(t, 0, frames[0].NumReferences)
+	assert.Equal(t, uint32(640), frames[0].Width)
+	assert.Equal(t, uint32(360), frames[0].Height)
+	assert.Equal(t, append(withOBUSize(testAV1SequenceHeader, 1), withOBUSize(testAV1Frame, 1)...), frames[0].Data)
+
+	assert.Equal(t, FrameTypeDelta, frames[1].FrameType)
+	assert.Equal(t, int64(11), frames[1].ID)
+	assert.Equal(t, []int64{10}, refsOf(frames[1]))
+	assert.Equal(t, withOBUSize(testAV1Frame, 1), frames[1].Data)
+
+	assert.Equal(t, int64(12), frames[2].ID)
+	assert.Equal(t, []int64{10}, refsOf(frames[2]), "T0 frame should reference the previous T0 frame")
+}
+
+func TestReceiverInterceptor_AV1WithoutDependencyDescriptor(t *testing.T) {
+	info := &interceptor.StreamInfo{
+		SSRC:        123456,
+		ClockRate:   90000,
+		MimeType:    "video/AV1",
+		PayloadType: 45,
+	}
+
+	// Keyframe with two packets, each starting a new OBU
+	packets := []*rtp.Packet{
+		{
+			Header:  rtp.Header{Version: 2, PayloadType: 45, SequenceNumber: 100, Timestamp: 90000, SSRC: 123456},
+			Payload: append([]byte{0x18}, testAV1SequenceHeader...), // W=1 N=1
+		},
+		{
+			Header:  rtp.Header{Version: 2, PayloadType: 45, SequenceNumber: 101, Timestamp: 90000, SSRC: 123456, Marker: true},
+			Payload: append([]byte{0x10}, testAV1Frame...),
+		},
+		{
+			Header:  rtp.Header{Version: 2, PayloadType: 45, SequenceNumber: 102, Timestamp: 93000, SSRC: 123456, Marker: true},
+			Payload: append([]byte{0x10}, testAV1Frame...),
+		},
+	}
+
+	frames, _ := readStreamPackets(t, info, packets)
+	require.Len(t, frames, 2)
+
+	assert.Equal(t, FrameTypeKey, frames[0].FrameType)
+	assert.Equal(t, int64(100), frames[0].FirstSeqNumUnwrapped, "Frame should start at the first packet with the same timestamp")
+	assert.Equal(t, append(withOBUSize(testAV1SequenceHeader, 1), withOBUSize(testAV1Frame, 1)...), frames[0].Data)
+
+	assert.Equal(t, FrameTypeDelta, frames[1].FrameType)
+	assert.Equal(t, withOBUSize(testAV1Frame, 1), frames[1].Data)
+}
+
+func TestReceiverInterceptor_H265(t *testing.T) {
+	info := &interceptor.StreamInfo{
+		SSRC:        123456,
+		ClockRate:   90000,
+		MimeType:    "video/H265",
+		PayloadType: 49,
+	}
+
+	packets := []*rtp.Packet{
+		// Keyframe: AP with VPS/SPS/PPS, then IDR as FU
+		{
+			Header:  rtp.Header{Version: 2, PayloadType: 49, SequenceNumber: 65534, Timestamp: 3000, SSRC: 123456},
+			Payload: h265AP(testH265VPS, testH265SPS, testH265PPS),
+		},
+		{
+			Header:  rtp.Header{Version: 2, PayloadType: 49, SequenceNumber: 65535, Timestamp: 3000, SSRC: 123456},
+			Payload: []byte{0x62, 0x01, 0x93, 0xAF, 0x12},
+		},
+		{
+			Header:  rtp.Header{Version: 2, PayloadType: 49, SequenceNumber: 0, Timestamp: 3000, SSRC: 123456, Marker: true},
+			Payload: []byte{0x62, 0x01, 0x53, 0x34},
+		},
+		// Delta frame: single NAL unit
+		{
+			Header:  rtp.Header{Version: 2, PayloadType: 49, SequenceNumber: 1, Timestamp: 6000, SSRC: 123456, Marker: true},
+			Payload: testH265Trail,
+		},
+		// IRAP without parameter sets: latest parameter sets are prepended
+		{
+			Header:  rtp.Header{Version: 2, PayloadType: 49, SequenceNumber: 2, Timestamp: 9000, SSRC: 123456, Marker: true},
+			Payload: testH265IDR,
+		},
+	}
+
+	frames, keyFrameRequested := readStreamPackets(t, info, packets)
+	assert.False(t, keyFrameRequested)
+	require.Len(t, frames, 3)
+
+	assert.Equal(t, FrameTypeKey, frames[0].FrameType)
+	assert.Equal(t, annexB(testH265VPS, testH265SPS, testH265PPS, []byte{0x26, 0x01, 0xAF, 0x12, 0x34}), frames[0].Data)
+	assert.Equal(t, uint16(65534), frames[0].FirstSeqNum)
+	assert.Equal(t, uint16(0), frames[0].LastSeqNum)
+
+	assert.Equal(t, FrameTypeDelta, frames[1].FrameType)
+	assert.Equal(t, annexB(testH265Trail), frames[1].Data)
+	require.Equal(t, 1, frames[1].NumReferences)
+	assert.Equal(t, frames[0].ID, frames[1].References[0])
+
+	assert.Equal(t, FrameTypeKey, frames[2].FrameType)
+	assert.Equal(t, annexB(testH265VPS, testH265SPS, testH265PPS, testH265IDR), frames[2].Data)
+}
+
+func TestReceiverInterceptor_H265MissingParameterSets(t *testing.T) {
+	info := &interceptor.StreamInfo{
+		SSRC:        123456,
+		ClockRate:   90000,
+		MimeType:    "video/H265",
+		PayloadType: 49,
+	}
+
+	packets := []*rtp.Packet{
+		{
+			Header:  rtp.Header{Version: 2, PayloadType: 49, SequenceNumber: 1000, Timestamp: 3000, SSRC: 123456, Marker: true},
+			Payload: testH265IDR,
+		},
+	}
+
+	frames, keyFrameRequested := readStreamPackets(t, info, packets)
+	assert.Empty(t, frames, "IRAP without known VPS/SPS/PPS should be held back")
+	assert.True(t, keyFrameRequested, "Keyframe should be requested")
+
+	// With sprop-vps/sps/pps, the same IRAP can be decoded
+	info.SDPFmtpLine = "sprop-vps=QAEMAf8=;sprop-sps=QgEBERERERERERERERERoA==;sprop-pps=RAHA"
+	frames, keyFrameRequested = readStreamPackets(t, info, packets)
+	assert.False(t, keyFrameRequested)
+	require.Len(t, frames, 1)
+	assert.Equal(t, annexB(testH265VPS, testH265SPS, testH265PPS, testH265IDR), frames[0].Data)
+}
+
+func TestReceiverInterceptor_NextFrame(t *testing.T) {
+	factory, err := NewReceiverInterceptor(WithFrameBuffer(WithRenderDelay(0)))
+	require.NoError(t, err)
+
+	var receiver *ReceiverInterceptor
+	factory.OnNewPeerConnection(func(_ string, r *ReceiverInterceptor) {
+		receiver = r
+	})
+
+	i, err := factory.NewInterceptor("")
+	require.NoError(t, err)
+	defer func() { _ = i.Close() }()
+	require.Same(t, i, receiver)
+
+	info := &interceptor.StreamInfo{
+		SSRC:        123456,
+		ClockRate:   90000,
+		MimeType:    "video/H264",
+		PayloadType: 102,
+	}
+
+	// The delta frame completes before the keyframe it references
+	packets := []*rtp.Packet{
+		{
+			Header:  rtp.Header{Version: 2, PayloadType: 102, SequenceNumber: 1000, Timestamp: 3000, SSRC: 123456},
+			Payload: stapA(testH264SPS, testH264PPS),
+		},
+		{
+			Header:  rtp.Header{Version: 2, PayloadType: 102, SequenceNumber: 1002, Timestamp: 6000, SSRC: 123456, Marker: true},
+			Payload: testH264Slice,
+		},
+		{
+			Header:  rtp.Header{Version: 2, PayloadType: 102, SequenceNumber: 1001, Timestamp: 3000, SSRC: 123456, Marker: true},
+			Payload: testH264IDR,
+		},
+	}
+
+	packetIdx := 0
+	reader := i.BindRemoteStream(info, interceptor.RTPReaderFunc(
+		func(b []byte, attrs interceptor.Attributes) (int, interceptor.Attributes, error) {
+			data, _ := packets[packetIdx].Marshal()
+			packetIdx++
+			copy(b, data)
+			return len(data), attrs, nil
+		},
+	))
+
+	buf := make([]byte, 1500)
+	for range packets {
+		_, _, err = reader.Read(buf, interceptor.Attributes{})
+		require.NoError(t, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	keyFrame, err := receiver.NextFrame(ctx, info.SSRC)
+	require.NoError(t, err)
+	assert.Equal(t, FrameTypeKey, keyFrame.FrameType)
+	assert.False(t, keyFrame.RenderTime.IsZero())
+
+	deltaFrame, err := receiver.NextFrame(ctx, info.SSRC)
+	require.NoError(t, err)
+	assert.Equal(t, FrameTypeDelta, deltaFrame.FrameType)
+	assert.Equal(t, keyFrame.ID, deltaFrame.References[0])
+
+	_, err = receiver.NextFrame(ctx, 654321)
+	assert.ErrorIs(t, err, errUnknownStream)
+
+	// Unbinding the stream unblocks a pending NextFrame
+	done := make(chan error)
+	go func() {
+		_, err := receiver.NextFrame(ctx, info.SSRC)
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	i.UnbindRemoteStream(info)
+	assert.ErrorIs(t, <-done, errFrameBufferClosed)
+}
+
+func TestReceiverInterceptor_NextFrameDisabled(t *testing.T) {
+	factory, err := NewReceiverInterceptor()
+	require.NoError(t, err)
+
+	i, err := factory.NewInterceptor("")
+	require.NoError(t, err)
+	defer func() { _ = i.Close() }()
+
+	receiver, ok := i.(*ReceiverInterceptor)
+	require.True(t, ok)
+
+	_, err = receiver.NextFrame(context.Background(), 123456)
+	assert.ErrorIs(t, err, errFrameBufferDisabled)
+}
+
+func TestReceiverInterceptor_KeyFrameRequests(t *testing.T) {
+	factory, err := NewReceiverInterceptor(WithKeyFrameRequests(WithKeyFrameRequestMethod(KeyFrameRequestFIR)))
+	require.NoError(t, err)
+
+	var receiver *ReceiverInterceptor
+	factory.OnNewPeerConnection(func(_ string, r *ReceiverInterceptor) {
+		receiver = r
+	})
+
+	i, err := factory.NewInterceptor("")
+	require.NoError(t, err)
+	defer func() { _ = i.Close() }()
+
+	now := time.Unix(1000, 0)
+	receiver.now = func() time.Time { return now }
+
+	var written []rtcp.Packet
+	i.BindRTCPWriter(interceptor.RTCPWriterFunc(func(pkts []rtcp.Packet, _ interceptor.Attributes) (int, error) {
+		written = append(written, pkts...)
+		return 0, nil
+	}))
+
+	info := &interceptor.StreamInfo{SSRC: 123456, ClockRate: 90000, MimeType: "video/VP8"}
+	var seq uint16
+	reader := i.BindRemoteStream(info, interceptor.RTPReaderFunc(
+		func(b []byte, attrs interceptor.Attributes) (int, interceptor.Attributes, error) {
+			// VP8 delta frames without a preceding keyframe
+			pkt := &rtp.Packet{
+				Header:  rtp.Header{Version: 2, SequenceNumber: seq, Timestamp: uint32(seq) * 3000, SSRC: 123456, Marker: true},
+				Payload: []byte{0x10, 0x01, 0x00, 0x00},
+			}
+			seq++
+			data, _ := pkt.Marshal()
+			copy(b, data)
+			return len(data), attrs, nil
+		},
+	))
+
+	read := func() bool {
+		_, attrs, err := reader.Read(make([]byte, 1500), interceptor.Attributes{})
+		require.NoError(t, err)
+		requested, _ := attrs.Get(KeyFrameRequestKey).(bool)
+		return requested
+	}
+
+	// Frames stay undecodable for 200ms
+	for j := 0; j < 4; j++ {
+		assert.False(t, read())
+		now = now.Add(50 * time.Millisecond)
+	}
+	assert.True(t, read())
+	require.Len(t, written, 1)
+	assert.Equal(t, &rtcp.FullIntraRequest{
+		MediaSSRC: 0,
+		FIR:       []rtcp.FIREntry{{SSRC: 123456, SequenceNumber: 1}},
+	}, written[0])
+
+	// A measured round trip time of 500ms delays the next request
+	rtcpReader := i.BindRTCPReader(interceptor.RTCPReaderFunc(
+		func(b []byte, attrs interceptor.Attributes) (int, interceptor.Attributes, error) {
+			rr := &rtcp.ReceiverReport{Reports: []rtcp.ReceptionReport{{
+				SSRC:             1,
+				LastSenderReport: ntp.ToNTP32(now.Add(-500 * time.Millisecond)),
+			}}}
+			data, _ := rr.Marshal()
+			return copy(b, data), attrs, nil
+		},
+	))
+	_, _, err = rtcpReader.Read(make([]byte, 1500), interceptor.Attributes{})
+	require.NoError(t, err)
+
+	now = now.Add(200 * time.Millisecond)
+	assert.True(t, read())
+	assert.Len(t, written, 1)
+
+	now = now.Add(300 * time.Millisecond)
+	require.NoError(t, receiver.RequestKeyFrame(123456))
+	require.Len(t, written, 2)
+	fir, ok := written[1].(*rtcp.FullIntraRequest)
+	require.True(t, ok)
+	assert.Equal(t, uint8(2), fir.FIR[0].SequenceNumber)
+
+	assert.ErrorIs(t, receiver.RequestKeyFrame(654321), errUnknownStream)
+}
+
+func TestReceiverInterceptor_VP8BufferWrapWithoutKeyFrameRequests(t *testing.T) {
+	factory, err := NewReceiverInterceptor(WithPacketBufferSize(64))
+	require.NoError(t, err)
+
+	i, err := factory.NewInterceptor("")
+	require.NoError(t, err)
+	defer func() { _ = i.Close() }()
+
+	receiver, ok := i.(*ReceiverInterceptor)
+	require.True(t, ok)
+
+	// The first packet of an incomplete frame, and a frame 64 packets later in the same slot
+	packets := []*rtp.Packet{
+		{
+			Header:  rtp.Header{Version: 2, SequenceNumber: 0, Timestamp: 3000, SSRC: 123456},
+			Payload: createVP8Payload(true, 1),
+		},
+		{
+			Header:  rtp.Header{Version: 2, SequenceNumber: 64, Timestamp: 6000, SSRC: 123456, Marker: true},
+			Payload: createVP8Payload(true, 2),
+		},
+	}
+	info := &interceptor.StreamInfo{SSRC: 123456, ClockRate: 90000, MimeType: "video/VP8"}
+	reader := i.BindRemoteStream(info, interceptor.RTPReaderFunc(
+		func(b []byte, attrs interceptor.Attributes) (int, interceptor.Attributes, error) {
+			data, _ := packets[0].Marshal()
+			packets = packets[1:]
+
+			return copy(b, data), attrs, nil
+		},
+	))
+
+	for j := 0; j < 2; j++ {
+		_, attrs, err := reader.Read(make([]byte, 1500), interceptor.Attributes{})
+		require.NoError(t, err)
+		assert.Nil(t, attrs.Get(KeyFrameRequestKey))
+
+		frames, _ := attrs.Get(EncodedFramesKey).([]*EncodedFrame)
+		if j == 0 {
+			assert.Empty(t, frames)
+		} else {
+			// The colliding packet replaces the older one, and its frame is delivered
+			require.Len(t, frames, 1)
+			assert.Equal(t, uint32(6000), frames[0].Timestamp)
+		}
+	}
+
+	// Delivered frames do not clear the packet buffer
+	receiver.streamsMu.Lock()
+	assert.False(t, receiver.streams[123456].packetBuffer.hasCleared)
+	receiver.streamsMu.Unlock()
+}
+
+func TestReceiverInterceptor_KeyFrameRequestsDisabled(t *testing.T) {
+	factory, err := NewReceiverInterceptor()
+	require.NoError(t, err)
+
+	i, err := factory.NewInterceptor("")
+	require.NoError(t, err)
+	defer func() { _ = i.Close() }()
+
+	receiver, ok := i.(*ReceiverInterceptor)
+	require.True(t, ok)
+
+	assert.ErrorIs(t, receiver.RequestKeyFrame(123456), errKeyFrameRequestsDisabled)
+}
+
+func TestReceiverInterceptor_Frames(t *testing.T) {
+	factory, err := NewReceiverInterceptor(WithFrameQueueSize(1), WithFrameQueuePolicy(FrameQueueDropNewest))
+	require.NoError(t, err)
+
+	i, err := factory.NewInterceptor("")
+	require.NoError(t, err)
+	defer func() { _ = i.Close() }()
+
+	receiver, ok := i.(*ReceiverInterceptor)
+	require.True(t, ok)
+
+	// Subscribe before the stream is bound
+	frames := receiver.Frames(123456)
+	assert.Equal(t, frames, receiver.Frames(123456))
+
+	var callbackFrames []*EncodedFrame
+	receiver.OnFrame(123456, func(frame *EncodedFrame) {
+		callbackFrames = append(callbackFrames, frame)
+	})
+	otherFrames := receiver.Frames(654321)
+
+	info := &interceptor.StreamInfo{SSRC: 123456, ClockRate: 90000, MimeType: "video/VP8"}
+	var seq uint16
+	reader := i.BindRemoteStream(info, interceptor.RTPReaderFunc(
+		func(b []byte, attrs interceptor.Attributes) (int, interceptor.Attributes, error) {
+			pkt := &rtp.Packet{
+				Header:  rtp.Header{Version: 2, SequenceNumber: seq, Timestamp: uint32(seq) * 3000, SSRC: 123456, Marker: true},
+				Payload: createVP8Payload(true, int(seq)),
+			}
+			seq++
+			data, _ := pkt.Marshal()
+			copy(b, data)
+			return len(data), attrs, nil
+		},
+	))
+
+	buf := make([]byte, 1500)
+	_, attrs, err := reader.Read(buf, interceptor.Attributes{})
+	require.NoError(t, err)
+	assert.Nil(t, attrs.Get(KeyFrameRequestKey))
+
+	frame := <-frames
+	assert.Equal(t, uint16(0), frame.FirstSeqNum)
+	assert.Equal(t, []*EncodedFrame{frame}, callbackFrames)
+	assert.Equal(t, []*EncodedFrame{frame}, attrs.Get(EncodedFramesKey))
+	assert.Empty(t, otherFrames)
+
+	// The second frame fills the queue, the third one is dropped
+	_, attrs, err = reader.Read(buf, interceptor.Attributes{})
+	require.NoError(t, err)
+	assert.Nil(t, attrs.Get(KeyFrameRequestKey))
+	_, attrs, err = reader.Read(buf, interceptor.Attributes{})
+	require.NoError(t, err)
+	assert.Equal(t, true, attrs.Get(KeyFrameRequestKey))
+	assert.Len(t, callbackFrames, 3)
+
+	frame = <-frames
+	assert.Equal(t, uint16(1), frame.FirstSeqNum)
+
+	i.UnbindRemoteStream(info)
+	_, ok = <-frames
+	assert.False(t, ok, "Unbinding the stream closes the channel")
+
+	require.NoError(t, i.Close())
+	_, ok = <-otherFrames
+	assert.False(t, ok, "Closing the interceptor closes all channels")
+}
+
+func TestReceiverInterceptor_FrameQueueOptions(t *testing.T) {
+	factory, err := NewReceiverInterceptor(WithFrameQueueSize(0))
+	require.NoError(t, err)
+	_, err = factory.NewInterceptor("")
+	assert.ErrorIs(t, err, errInvalidFrameQueueSize)
+
+	factory, err = NewReceiverInterceptor(WithFrameQueuePolicy(FrameQueuePolicy(-1)))
+	require.NoError(t, err)
+	_, err = factory.NewInterceptor("")
+	assert.ErrorIs(t, err, errInvalidFrameQueuePolicy)
+}
@@ -30,6 +30,8 @@ type FrameReferenceFinder interface {
 	// - SeqNumOnlyRefFinder: unwrapped sequence number (FirstSeqNumUnwrapped)
 	// - FrameIdOnlyRefFinder: unwrapped picture ID
 	// - VP8RefFinder: unwrapped picture ID
+	// - VP9RefFinder: unwrapped sequence number (FirstSeqNumUnwrapped)
+	// - GenericRefFinder: no state to clear
 	ClearTo(id int64)
 }
 
@@ -51,15 +53,28 @@ const (
 	// Used when VP8 temporal layer information (TID, TL0PICIDX, PictureID) is all available.
 	// Reference: libwebrtc rtp_vp8_ref_finder.cc
 	RefFinderVP8
+
+	// RefFinderVP9 uses the VP9 payload descriptor to determine references.
+	// Used when the VP9 picture ID is available together with flexible mode
+	// reference indices or non-flexible mode layer information.
+	// Reference: libwebrtc rtp_vp9_ref_finder.cc
+	RefFinderVP9
+
+	// RefFinderGeneric uses the frame dependencies signaled in the Dependency Descriptor.
+	// Used when the Dependency Descriptor RTP header extension is present.
+	// Reference: libwebrtc rtp_generic_ref_finder.cc
+	RefFinderGeneric
 )
 
 // SelectRefFinderType determines which reference finder type to use based on
 // the available information in the RTPVideoHeader.
 //
 // Selection logic (based on libwebrtc rtp_frame_reference_finder.cc:56-114):
-// 1. If temporal layer info (TemporalIdx, TL0PicIdx, PictureID all present) -> VP8RefFinder
-// 2. If only PictureID is available -> FrameIdOnlyRefFinder
-// 3. Otherwise -> SeqNumOnlyRefFinder
+// 1. If a Dependency Descriptor is present -> GenericRefFinder
+// 2. If VP9 info with PictureID and either flexible mode or TemporalIdx is present -> VP9RefFinder
+// 3. If temporal layer info (TemporalIdx, TL0PicIdx, PictureID all present) -> VP8RefFinder
+// 4. If only PictureID is available -> FrameIdOnlyRefFinder
+// 5. Otherwise -> SeqNumOnlyRefFinder
 //
 // Note: VP8RefFinder requires PictureID for frame ID assignment. Without PictureID,
 // the frame ID space would be inconsistent with other ref finders.
@@ -68,6 +83,19 @@ func SelectRefFinderType(header *RTPVideoHeader) RefFinderType {
 		return RefFinderSeqNumOnly
 	}
 
+	// The Dependency Descriptor takes precedence over codec-specific information
+	// Reference: libwebrtc rtp_frame_reference_finder.cc (video_header.generic)
+	if header.Generic != nil {
+		return RefFinderGeneric
+	}
+
+	// VP9 frames carry their own reference structure (P_DIFF or GOF)
+	// Reference: libwebrtc rtp_frame_reference_finder.cc kVideoCodecVP9 case
+	if header.VP9 != nil && header.PictureID != NoPictureID &&
+		(header.VP9.FlexibleMode || header.TemporalIdx != NoTemporalIdx) {
+		return RefFinderVP9
+	}
+
 	// Check if full temporal layer information is available (including PictureID)
 	// Reference: libwebrtc rtp_frame_reference_finder.cc:66-82
 	// VP8RefFinder requires PictureID for proper frame ID assignment
@@ -20,6 +20,25 @@ const (
 	FrameTypeDelta
 )
 
+// VideoCodecType identifies the codec of a video packet.
+// This is similar to libwebrtc's VideoCodecType.
+type VideoCodecType int
+
+const (
+	// VideoCodecGeneric indicates that the codec is unknown or not codec-specific.
+	VideoCodecGeneric VideoCodecType = iota
+	// VideoCodecVP8 indicates a VP8 packet.
+	VideoCodecVP8
+	// VideoCodecVP9 indicates a VP9 packet.
+	VideoCodecVP9
+	// VideoCodecH264 indicates an H.264 packet.
+	VideoCodecH264
+	// VideoCodecAV1 indicates an AV1 packet.
+	VideoCodecAV1
+	// VideoCodecH265 indicates an H.265 packet.
+	VideoCodecH265
+)
+
 // NoPictureID indicates that PictureID is not present.
 const NoPictureID int32 = -1
 
@@ -29,15 +48,23 @@ const NoTemporalIdx int8 = -1
 // NoTL0PicIdx indicates that TL0PicIdx is not present.
 const NoTL0PicIdx int16 = -1
 
+// NoSpatialIdx indicates that SpatialIdx is not present.
+const NoSpatialIdx int8 = -1
+
 // RTPVideoHeader contains video-specific metadata extracted from RTP packets.
 // This structure is similar to libwebrtc's RTPVideoHeader.
 type RTPVideoHeader struct {
+	// Codec is the codec of the packet.
+	Codec VideoCodecType
+
 	// FrameType indicates whether this is a key frame or delta frame.
 	FrameType FrameType
 
 	// IsFirstPacketInFrame indicates if this packet is the first packet of a frame.
 	// For VP8: S=1 && PID=0
 	// For VP9: B=1
+	// For H.264 and H.265: first packet of a NAL unit (not necessarily of the frame)
+	// For AV1: start_of_frame of the Dependency Descriptor, or Z=0 without it
 	IsFirstPacketInFrame bool
 
 	// IsLastPacketInFrame indicates if this packet is the last packet of a frame.
@@ -52,6 +79,22 @@ type RTPVideoHeader struct {
 
 	// TL0PicIdx is the temporal layer 0 picture index. -1 if not present.
 	TL0PicIdx int16
+
+	// SpatialIdx is the spatial layer index. -1 if not present.
+	SpatialIdx int8
+
+	// Width is the frame width in pixels. 0 if not signaled in the payload descriptor.
+	Width uint32
+
+	// Height is the frame height in pixels. 0 if not signaled in the payload descriptor.
+	Height uint32
+
+	// VP9 contains VP9-specific metadata. nil for other codecs.
+	VP9 *RTPVideoHeaderVP9
+
+	// Generic contains the frame dependencies from the Dependency Descriptor
+	// RTP header extension. nil if the extension is not present.
+	Generic *RTPVideoHeaderGeneric
 }
 
 // NewRTPVideoHeaderFromVP8 creates an RTPVideoHeader from a VP8 packet.
@@ -59,9 +102,11 @@ type RTPVideoHeader struct {
 // Reference: libwebrtc video_rtp_depacketizer_vp8.cc:175-176
 func NewRTPVideoHeaderFromVP8(pkt *codecs.VP8Packet, marker bool) *RTPVideoHeader {
 	header := &RTPVideoHeader{
+		Codec:       VideoCodecVP8,
 		PictureID:   NoPictureID,
 		TemporalIdx: NoTemporalIdx,
 		TL0PicIdx:   NoTL0PicIdx,
+		SpatialIdx:  NoSpatialIdx,
 	}
 
 	// RFC 7741: First packet of frame has S=1 (start of partition) and PID=0 (partition index 0)
@@ -0,0 +1,511 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package videoframe
+
+// VP9RefFinder resolves frame references using VP9 payload descriptor information.
+// This is used when the VP9 picture ID is available together with either
+// flexible mode reference indices (P_DIFF) or non-flexible mode layer information.
+//
+// Reference: libwebrtc modules/video_coding/rtp_vp9_ref_finder.cc
+//
+// VP9 reference modes:
+//   - Flexible mode (F=1): each frame lists its references as P_DIFF values
+//     relative to its own picture ID.
+//   - Non-flexible mode (F=0): references are derived from the group of frames (GOF)
+//     described in the scalability structure (SS), indexed by TL0PICIDX.
+//
+// ID Scheme: frame.ID = unwrappedPictureID * maxVP9SpatialLayers + spatialIdx.
+// Each spatial layer of a picture is a separate frame, and a frame that is
+// inter-layer predicted (D=1) additionally references frame.ID - 1.
+type VP9RefFinder struct {
+	// unwrapper unwraps 15-bit picture IDs when flattening frame IDs and references
+	unwrapper *pictureIDUnwrapper
+
+	// lastPictureID is the newest picture ID in the 15-bit space, 7-bit picture IDs are
+	// extended relative to it
+	lastPictureID    uint16
+	hasLastPictureID bool
+
+	// tl0Unwrapper handles TL0PICIDX wrap-around (8-bit)
+	tl0Unwrapper *tl0PicIdxUnwrapper
+
+	// gofInfo maps unwrapped TL0PICIDX to the GOF state in effect for that base layer frame
+	gofInfo map[int64]*vp9GOFState
+
+	// missingFramesForLayer holds picture IDs that are known to be missing, per temporal layer
+	missingFramesForLayer [maxVP9TemporalLayers]map[uint16]struct{}
+
+	// upSwitch maps picture IDs of temporal up switch frames to their temporal index
+	upSwitch map[uint16]uint8
+
+	// stashedFrames holds frames waiting for their dependencies
+	stashedFrames []*stashedVP9Frame
+}
+
+// vp9ScalabilityStructure is a received GOF together with the picture ID it started at.
+type vp9ScalabilityStructure struct {
+	gof      *VP9GOFInfo
+	pidStart uint16
+}
+
+// vp9GOFState tracks the last picture ID received for a given TL0PICIDX.
+// Reference: libwebrtc RtpVp9RefFinder::GofInfo
+type vp9GOFState struct {
+	ss            *vp9ScalabilityStructure
+	lastPictureID uint16
+}
+
+// stashedVP9Frame holds a VP9 frame along with its metadata for later processing.
+// Note: unwrappedTL0 is computed at stash time to avoid re-unwrapping
+// which would corrupt the unwrapper state.
+type stashedVP9Frame struct {
+	frame        *EncodedFrame
+	header       *RTPVideoHeader
+	unwrappedTL0 int64
+	pictureID    uint16
+}
+
+// vp9FrameDecision is the outcome of processing a single VP9 frame.
+// Reference: libwebrtc RtpVp9RefFinder::FrameDecision
+type vp9FrameDecision int
+
+const (
+	vp9FrameStash vp9FrameDecision = iota
+	vp9FrameHandOff
+	vp9FrameDrop
+)
+
+// maxVP9TemporalLayers is the maximum number of temporal layers supported by VP9RefFinder.
+// Reference: libwebrtc rtp_vp9_ref_finder.h kMaxTemporalLayers
+const maxVP9TemporalLayers = 5
+
+// maxVP9GOFSaved is the number of base layer GOF entries to keep.
+// Reference: libwebrtc rtp_vp9_ref_finder.h kMaxGofSaved
+const maxVP9GOFSaved = 50
+
+// maxVP9UpSwitchAge is the picture ID distance after which up switch and
+// missing frame information is discarded.
+// Reference: libwebrtc rtp_vp9_ref_finder.cc (Subtract<kFrameIdLength>(frame->Id(), 50))
+const maxVP9UpSwitchAge = 50
+
+// NewVP9RefFinder creates a new VP9RefFinder.
+func NewVP9RefFinder() *VP9RefFinder {
+	f := &VP9RefFinder{
+		unwrapper:     &pictureIDUnwrapper{},
+		tl0Unwrapper:  &tl0PicIdxUnwrapper{},
+		gofInfo:       make(map[int64]*vp9GOFState),
+		upSwitch:      make(map[uint16]uint8),
+		stashedFrames: make([]*stashedVP9Frame, 0),
+	}
+	for i := range f.missingFramesForLayer {
+		f.missingFramesForLayer[i] = make(map[uint16]struct{})
+	}
+
+	return f
+}
+
+// ManageFrame processes a frame and resolves its references.
+// Reference: libwebrtc rtp_vp9_ref_finder.cc ManageFrame()
+func (f *VP9RefFinder) ManageFrame(frame *EncodedFrame, header *RTPVideoHeader) []*EncodedFrame {
+	if frame == nil || header == nil || header.VP9 == nil || header.PictureID == NoPictureID {
+		return nil
+	}
+
+	// Unwrap TL0PICIDX once; stashed frames keep the unwrapped value
+	unwrappedTL0 := int64(-1)
+	if !header.VP9.FlexibleMode && header.TL0PicIdx != NoTL0PicIdx {
+		unwrappedTL0 = f.tl0Unwrapper.Unwrap(header.TL0PicIdx)
+	}
+
+	// Extend the picture ID once as well, 7-bit picture IDs depend on the last one
+	pictureID := f.extendPictureID(header)
+
+	switch f.manageFrameInternal(frame, header, unwrappedTL0, pictureID) {
+	case vp9FrameStash:
+		if len(f.stashedFrames) >= maxStashedFrames {
+			f.stashedFrames = f.stashedFrames[1:]
+		}
+		f.stashedFrames = append(f.stashedFrames, &stashedVP9Frame{
+			frame:        frame,
+			header:       header,
+			unwrappedTL0: unwrappedTL0,
+			pictureID:    pictureID,
+		})
+		return nil
+	case vp9FrameHandOff:
+		result := []*EncodedFrame{frame}
+		result = append(result, f.retryStashedFrames()...)
+		return result
+	default:
+		return nil
+	}
+}
+
+// extendPictureID returns the picture ID of a frame in the 15-bit picture ID space.
+// 7-bit picture IDs are extended by their distance to the last picture ID, so that they
+// continue in the 15-bit space when they wrap from 127 to 0.
+func (f *VP9RefFinder) extendPictureID(header *RTPVideoHeader) uint16 {
+	pictureID := uint16(header.PictureID) & (pictureIDModulus - 1)
+	if header.VP9.MaxPictureID == maxOneBytePictureID && f.hasLastPictureID {
+		const oneByteModulus = maxOneBytePictureID + 1
+		diff := (int(pictureID) - int(f.lastPictureID)) & maxOneBytePictureID
+		if diff >= oneByteModulus/2 {
+			diff -= oneByteModulus
+		}
+		pictureID = picIDAdd(f.lastPictureID, diff)
+	}
+
+	if !f.hasLastPictureID || picIDAheadOf(pictureID, f.lastPictureID) {
+		f.lastPictureID = pictureID
+		f.hasLastPictureID = true
+	}
+
+	return pictureID
+}
+
+// manageFrameInternal decides whether a frame can be handed off, must be stashed or dropped.
+// On hand off, frame.ID and frame.References are set.
+// Reference: libwebrtc rtp_vp9_ref_finder.cc ManageFrameInternal()
+//
+//nolint:cyclop
+func (f *VP9RefFinder) manageFrameInternal(
+	frame *EncodedFrame,
+	header *RTPVideoHeader,
+	unwrappedTL0 int64,
+	pictureID uint16,
+) vp9FrameDecision {
+	vp9 := header.VP9
+
+	// Protect against corrupted packets with arbitrary large layer indices
+	if header.TemporalIdx >= maxVP9TemporalLayers || header.SpatialIdx >= maxVP9SpatialLayers {
+		return vp9FrameDrop
+	}
+
+	if vp9.FlexibleMode {
+		if len(vp9.PDiff) > len(frame.References) {
+			return vp9FrameDrop
+		}
+		refs := make([]uint16, 0, len(vp9.PDiff))
+		if vp9.InterPicPredicted {
+			for _, pdiff := range vp9.PDiff {
+				refs = append(refs, picIDSub(pictureID, int(pdiff)))
+			}
+		}
+		f.flattenFrameIDAndRefs(frame, header, pictureID, refs)
+		return vp9FrameHandOff
+	}
+
+	if unwrappedTL0 < 0 {
+		// TL0PICIDX is expected to be present in non-flexible mode
+		return vp9FrameDrop
+	}
+
+	var info *vp9GOFState
+	switch {
+	case vp9.SSDataAvailable:
+		if header.TemporalIdx <= 0 {
+			// Scalability structure on a non base layer frame is ignored
+			gof := vp9.GOF
+			if gof == nil || gof.NumFramesInGOF == 0 {
+				// Assume that the stream has only one temporal layer
+				gof = newVP9GOFInfoMode1()
+			}
+			if !validVP9GOF(gof) {
+				return vp9FrameDrop
+			}
+			f.gofInfo[unwrappedTL0] = &vp9GOFState{
+				ss:            &vp9ScalabilityStructure{gof: gof, pidStart: pictureID},
+				lastPictureID: pictureID,
+			}
+		}
+
+		var ok bool
+		info, ok = f.gofInfo[unwrappedTL0]
+		if !ok {
+			return vp9FrameStash
+		}
+		if frame.FrameType == FrameTypeKey {
+			f.frameReceived(pictureID, info)
+			f.flattenFrameIDAndRefs(frame, header, pictureID, nil)
+			return vp9FrameHandOff
+		}
+	case frame.FrameType == FrameTypeKey:
+		if header.SpatialIdx <= 0 {
+			// Keyframe on the base spatial layer without scalability structure
+			return vp9FrameDrop
+		}
+
+		var ok bool
+		info, ok = f.gofInfo[unwrappedTL0]
+		if !ok {
+			return vp9FrameStash
+		}
+		f.frameReceived(pictureID, info)
+		f.flattenFrameIDAndRefs(frame, header, pictureID, nil)
+		return vp9FrameHandOff
+	default:
+		tl0 := unwrappedTL0
+		if header.TemporalIdx <= 0 {
+			tl0--
+		}
+		prev, ok := f.gofInfo[tl0]
+		if !ok {
+			// GOF info for this frame is not available yet
+			return vp9FrameStash
+		}
+		if header.TemporalIdx <= 0 {
+			info = &vp9GOFState{ss: prev.ss, lastPictureID: pictureID}
+			f.gofInfo[unwrappedTL0] = info
+		} else {
+			info = prev
+		}
+	}
+
+	// Clean up info for base layers that are too old
+	for tl0 := range f.gofInfo {
+		if tl0 < unwrappedTL0-maxVP9GOFSaved {
+			delete(f.gofInfo, tl0)
+		}
+	}
+
+	f.frameReceived(pictureID, info)
+
+	// Make sure we don't miss any frame that could potentially have the up switch flag set
+	if f.missingRequiredFrame(pictureID, info) {
+		return vp9FrameStash
+	}
+
+	temporalIdx := uint8(0)
+	if header.TemporalIdx > 0 {
+		temporalIdx = uint8(header.TemporalIdx)
+	}
+	if vp9.TemporalUpSwitch {
+		f.upSwitch[pictureID] = temporalIdx
+	}
+
+	// Clean out old info about up switch and missing frames
+	oldPictureID := picIDSub(pictureID, maxVP9UpSwitchAge)
+	for pid := range f.upSwitch {
+		if !picIDAheadOf(pid, oldPictureID) {
+			delete(f.upSwitch, pid)
+		}
+	}
+	for _, missing := range f.missingFramesForLayer {
+		for pid := range missing {
+			if !picIDAheadOf(pid, oldPictureID) {
+				delete(missing, pid)
+			}
+		}
+	}
+
+	gof := info.ss.gof
+	gofIdx := picIDForwardDiff(info.ss.pidStart, pictureID) % gof.NumFramesInGOF
+	if len(gof.PDiff[gofIdx]) > len(frame.References) {
+		return vp9FrameDrop
+	}
+
+	// Populate references according to the scalability structure
+	var refs []uint16
+	if vp9.InterPicPredicted {
+		for _, pdiff := range gof.PDiff[gofIdx] {
+			ref := picIDSub(pictureID, int(pdiff))
+			// Ignore references to frames earlier than the last up switch point
+			if f.upSwitchInInterval(pictureID, temporalIdx, ref) {
+				continue
+			}
+			refs = append(refs, ref)
+		}
+	}
+
+	f.flattenFrameIDAndRefs(frame, header, pictureID, refs)
+	return vp9FrameHandOff
+}
+
+// validVP9GOF reports whether the GOF is within the supported limits.
+func validVP9GOF(gof *VP9GOFInfo) bool {
+	if gof.NumFramesInGOF > maxVP9FramesInGOF ||
+		len(gof.TemporalIdx) < gof.NumFramesInGOF ||
+		len(gof.PDiff) < gof.NumFramesInGOF {
+		return false
+	}
+	for i := 0; i < gof.NumFramesInGOF; i++ {
+		if len(gof.PDiff[i]) > maxVP9RefPics {
+			return false
+		}
+	}
+
+	return true
+}
+
+// frameReceived records the reception of a picture and tracks missing pictures per temporal layer.
+// Reference: libwebrtc rtp_vp9_ref_finder.cc FrameReceivedVp9()
+func (f *VP9RefFinder) frameReceived(pictureID uint16, info *vp9GOFState) {
+	gof := info.ss.gof
+	gofSize := gof.NumFramesInGOF
+	if gofSize > maxVP9FramesInGOF {
+		gofSize = maxVP9FramesInGOF
+	}
+
+	// If there is a gap, find which temporal layer the missing frames belong to
+	// and add the frame as missing for that temporal layer.
+	// Otherwise, remove this frame from the set of missing frames.
+	if picIDAheadOf(pictureID, info.lastPictureID) {
+		gofIdx := picIDForwardDiff(info.ss.pidStart, info.lastPictureID) % gofSize
+
+		lastPictureID := picIDAdd(info.lastPictureID, 1)
+		for lastPictureID != pictureID {
+			gofIdx = (gofIdx + 1) % gofSize
+			temporalIdx := gof.TemporalIdx[gofIdx]
+			if temporalIdx >= maxVP9TemporalLayers {
+				return
+			}
+			f.missingFramesForLayer[temporalIdx][lastPictureID] = struct{}{}
+			lastPictureID = picIDAdd(lastPictureID, 1)
+		}
+		info.lastPictureID = lastPictureID
+
+		return
+	}
+
+	gofIdx := picIDForwardDiff(info.ss.pidStart, pictureID) % gofSize
+	temporalIdx := gof.TemporalIdx[gofIdx]
+	if temporalIdx >= maxVP9TemporalLayers {
+		return
+	}
+	delete(f.missingFramesForLayer[temporalIdx], pictureID)
+}
+
+// missingRequiredFrame reports whether a frame in a lower temporal layer is missing
+// between any of the frame's references and the frame itself.
+// Reference: libwebrtc rtp_vp9_ref_finder.cc MissingRequiredFrameVp9()
+func (f *VP9RefFinder) missingRequiredFrame(pictureID uint16, info *vp9GOFState) bool {
+	gof := info.ss.gof
+	gofIdx := picIDForwardDiff(info.ss.pidStart, pictureID) % gof.NumFramesInGOF
+	temporalIdx := gof.TemporalIdx[gofIdx]
+	if temporalIdx >= maxVP9TemporalLayers {
+		return false
+	}
+
+	for _, pdiff := range gof.PDiff[gofIdx] {
+		refPID := picIDSub(pictureID, int(pdiff))
+		for l := uint8(0); l < temporalIdx; l++ {
+			for missing := range f.missingFramesForLayer[l] {
+				if picIDAheadOf(missing, refPID) && picIDAheadOf(pictureID, missing) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// upSwitchInInterval reports whether an up switch to a lower temporal layer
+// happened between the reference and the frame.
+// Reference: libwebrtc rtp_vp9_ref_finder.cc UpSwitchInIntervalVp9()
+func (f *VP9RefFinder) upSwitchInInterval(pictureID uint16, temporalIdx uint8, refPID uint16) bool {
+	for pid, upSwitchTemporalIdx := range f.upSwitch {
+		if picIDAheadOf(pid, refPID) && picIDAheadOf(pictureID, pid) && upSwitchTemporalIdx < temporalIdx {
+			return true
+		}
+	}
+
+	return false
+}
+
+// flattenFrameIDAndRefs converts 15-bit picture IDs to the flattened frame ID space
+// that combines the unwrapped picture ID and the spatial index.
+// Reference: libwebrtc rtp_vp9_ref_finder.cc FlattenFrameIdAndRefs()
+func (f *VP9RefFinder) flattenFrameIDAndRefs(
+	frame *EncodedFrame,
+	header *RTPVideoHeader,
+	pictureID uint16,
+	refs []uint16,
+) {
+	spatialIdx := int64(0)
+	if header.SpatialIdx > 0 {
+		spatialIdx = int64(header.SpatialIdx)
+	}
+
+	frame.NumReferences = 0
+	for _, ref := range refs {
+		frame.References[frame.NumReferences] = f.unwrapper.Unwrap(int32(ref))*maxVP9SpatialLayers + spatialIdx
+		frame.NumReferences++
+	}
+	frame.ID = f.unwrapper.Unwrap(int32(pictureID))*maxVP9SpatialLayers + spatialIdx
+
+	if header.VP9.InterLayerPredicted && frame.NumReferences < len(frame.References) {
+		frame.References[frame.NumReferences] = frame.ID - 1
+		frame.NumReferences++
+	}
+}
+
+// retryStashedFrames attempts to resolve stashed frames until no more progress is made.
+// Reference: libwebrtc rtp_vp9_ref_finder.cc RetryStashedFrames()
+func (f *VP9RefFinder) retryStashedFrames() []*EncodedFrame {
+	var result []*EncodedFrame
+
+	for {
+		completeFrame := false
+
+		remaining := f.stashedFrames[:0]
+		for _, stashed := range f.stashedFrames {
+			switch f.manageFrameInternal(stashed.frame, stashed.header, stashed.unwrappedTL0, stashed.pictureID) {
+			case vp9FrameStash:
+				remaining = append(remaining, stashed)
+			case vp9FrameHandOff:
+				completeFrame = true
+				result = append(result, stashed.frame)
+			default:
+			}
+		}
+		f.stashedFrames = remaining
+
+		if !completeFrame {
+			break
+		}
+	}
+
+	return result
+}
+
+// ClearTo clears stashed frames with unwrapped first sequence number less than the given value.
+// For VP9RefFinder, the ID is FirstSeqNumUnwrapped, as in libwebrtc's RtpVp9RefFinder::ClearTo.
+func (f *VP9RefFinder) ClearTo(seqNum int64) {
+	var remaining []*stashedVP9Frame
+	for _, stashed := range f.stashedFrames {
+		if stashed.frame.FirstSeqNumUnwrapped >= seqNum {
+			remaining = append(remaining, stashed)
+		}
+	}
+	f.stashedFrames = remaining
+}
+
+// picIDAdd returns a + b in the 15-bit picture ID space.
+func picIDAdd(a uint16, b int) uint16 {
+	return uint16((int(a) + b) & (pictureIDModulus - 1))
+}
+
+// picIDSub returns a - b in the 15-bit picture ID space.
+func picIDSub(a uint16, b int) uint16 {
+	return uint16((int(a) - b) & (pictureIDModulus - 1))
+}
+
+// picIDForwardDiff returns the distance from a forward to b in the 15-bit picture ID space.
+func picIDForwardDiff(a, b uint16) int {
+	return (int(b) - int(a)) & (pictureIDModulus - 1)
+}
+
+// picIDAheadOf reports whether a is newer than b in the 15-bit picture ID space.
+func picIDAheadOf(a, b uint16) bool {
+	if a == b {
+		return false
+	}
+	diff := picIDForwardDiff(b, a)
+	if diff == pictureIDModulus/2 {
+		return b < a
+	}
+
+	return diff < pictureIDModulus/2
+}
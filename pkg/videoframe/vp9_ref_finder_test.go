@@ -0,0 +1,251 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package videoframe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// vp9FlexHeader creates a flexible mode VP9 header for ref finder tests.
+func vp9FlexHeader(pictureID int32, spatialIdx int8, interLayer bool, pdiff ...uint8) *RTPVideoHeader {
+	return &RTPVideoHeader{
+		PictureID:   pictureID,
+		TemporalIdx: 0,
+		TL0PicIdx:   NoTL0PicIdx,
+		SpatialIdx:  spatialIdx,
+		VP9: &RTPVideoHeaderVP9{
+			FlexibleMode:        true,
+			InterPicPredicted:   len(pdiff) > 0,
+			InterLayerPredicted: interLayer,
+			PDiff:               pdiff,
+		},
+	}
+}
+
+// vp9NonFlexHeader creates a non-flexible mode VP9 header for ref finder tests.
+func vp9NonFlexHeader(pictureID int32, tid int8, tl0 int16, gof *VP9GOFInfo) *RTPVideoHeader {
+	return &RTPVideoHeader{
+		PictureID:   pictureID,
+		TemporalIdx: tid,
+		TL0PicIdx:   tl0,
+		SpatialIdx:  0,
+		VP9: &RTPVideoHeaderVP9{
+			InterPicPredicted: gof == nil,
+			SSDataAvailable:   gof != nil,
+			GOF:               gof,
+		},
+	}
+}
+
+func refsOf(frame *EncodedFrame) []int64 {
+	return append([]int64{}, frame.References[:frame.NumReferences]...)
+}
+
+func TestVP9RefFinder_FlexibleMode(t *testing.T) {
+	finder := NewVP9RefFinder()
+
+	key := &EncodedFrame{FrameType: FrameTypeKey}
+	result := finder.ManageFrame(key, vp9FlexHeader(10, 0, false))
+	require.Len(t, result, 1)
+	assert.Equal(t, int64(10*maxVP9SpatialLayers), result[0].ID)
+	assert.Equal(t, 0, result[0].NumReferences)
+
+	delta := &EncodedFrame{FrameType: FrameTypeDelta}
+	result = finder.ManageFrame(delta, vp9FlexHeader(12, 0, false, 1, 2))
+	require.Len(t, result, 1)
+	assert.Equal(t, int64(12*maxVP9SpatialLayers), result[0].ID)
+	assert.Equal(t, []int64{11 * maxVP9SpatialLayers, 10 * maxVP9SpatialLayers}, refsOf(result[0]))
+}
+
+func TestVP9RefFinder_InterLayerPrediction(t *testing.T) {
+	// Upper spatial layer with D=1 references the lower spatial layer of the same picture
+	finder := NewVP9RefFinder()
+
+	base := &EncodedFrame{FrameType: FrameTypeKey}
+	finder.ManageFrame(base, vp9FlexHeader(10, 0, false))
+
+	upper := &EncodedFrame{FrameType: FrameTypeKey}
+	result := finder.ManageFrame(upper, vp9FlexHeader(10, 1, true))
+	require.Len(t, result, 1)
+	assert.Equal(t, int64(10*maxVP9SpatialLayers+1), result[0].ID)
+	assert.Equal(t, []int64{10 * maxVP9SpatialLayers}, refsOf(result[0]))
+}
+
+func TestVP9RefFinder_PictureIDWrap(t *testing.T) {
+	finder := NewVP9RefFinder()
+
+	key := &EncodedFrame{FrameType: FrameTypeKey}
+	result := finder.ManageFrame(key, vp9FlexHeader(pictureIDModulus-1, 0, false))
+	require.Len(t, result, 1)
+	keyID := result[0].ID
+
+	delta := &EncodedFrame{FrameType: FrameTypeDelta}
+	result = finder.ManageFrame(delta, vp9FlexHeader(0, 0, false, 1))
+	require.Len(t, result, 1)
+	assert.Equal(t, keyID+maxVP9SpatialLayers, result[0].ID, "ID should keep increasing across wrap")
+	assert.Equal(t, []int64{keyID}, refsOf(result[0]))
+}
+
+func TestVP9RefFinder_OneBytePictureIDWrap(t *testing.T) {
+	oneByte := func(header *RTPVideoHeader) *RTPVideoHeader {
+		header.VP9.MaxPictureID = maxOneBytePictureID
+
+		return header
+	}
+	finder := NewVP9RefFinder()
+
+	result := finder.ManageFrame(&EncodedFrame{FrameType: FrameTypeKey}, oneByte(vp9FlexHeader(126, 0, false)))
+	require.Len(t, result, 1)
+	keyID := result[0].ID
+
+	result = finder.ManageFrame(&EncodedFrame{FrameType: FrameTypeDelta}, oneByte(vp9FlexHeader(127, 0, false, 1)))
+	require.Len(t, result, 1)
+	assert.Equal(t, keyID+maxVP9SpatialLayers, result[0].ID)
+
+	// 0 follows 127, and its references reach back across the wrap
+	result = finder.ManageFrame(&EncodedFrame{FrameType: FrameTypeDelta}, oneByte(vp9FlexHeader(0, 0, false, 1, 2)))
+	require.Len(t, result, 1)
+	assert.Equal(t, keyID+2*maxVP9SpatialLayers, result[0].ID, "ID should keep increasing across the 7-bit wrap")
+	assert.Equal(t, []int64{keyID + maxVP9SpatialLayers, keyID}, refsOf(result[0]))
+
+	result = finder.ManageFrame(&EncodedFrame{FrameType: FrameTypeDelta}, oneByte(vp9FlexHeader(1, 0, false, 1)))
+	require.Len(t, result, 1)
+	assert.Equal(t, keyID+3*maxVP9SpatialLayers, result[0].ID)
+	assert.Equal(t, []int64{keyID + 2*maxVP9SpatialLayers}, refsOf(result[0]))
+}
+
+func TestVP9RefFinder_OneBytePictureIDWrapGOF(t *testing.T) {
+	// A delta frame after the 7-bit wrap references the base layer frame before it
+	gof := &VP9GOFInfo{
+		NumFramesInGOF:   2,
+		TemporalIdx:      []uint8{0, 1},
+		TemporalUpSwitch: []bool{false, true},
+		PDiff:            [][]uint8{{2}, {1}},
+	}
+	oneByte := func(header *RTPVideoHeader) *RTPVideoHeader {
+		header.VP9.MaxPictureID = maxOneBytePictureID
+
+		return header
+	}
+	finder := NewVP9RefFinder()
+
+	result := finder.ManageFrame(&EncodedFrame{FrameType: FrameTypeKey}, oneByte(vp9NonFlexHeader(126, 0, 0, gof)))
+	require.Len(t, result, 1)
+	keyID := result[0].ID
+
+	result = finder.ManageFrame(&EncodedFrame{FrameType: FrameTypeDelta}, oneByte(vp9NonFlexHeader(127, 1, 0, nil)))
+	require.Len(t, result, 1)
+	assert.Equal(t, []int64{keyID}, refsOf(result[0]))
+
+	result = finder.ManageFrame(&EncodedFrame{FrameType: FrameTypeDelta}, oneByte(vp9NonFlexHeader(0, 0, 1, nil)))
+	require.Len(t, result, 1)
+	assert.Equal(t, keyID+2*maxVP9SpatialLayers, result[0].ID)
+	assert.Equal(t, []int64{keyID}, refsOf(result[0]))
+}
+
+func TestVP9RefFinder_NonFlexibleGOF(t *testing.T) {
+	// Two temporal layers: TL0 frames reference the previous TL0 frame (P_DIFF=2),
+	// TL1 frames reference the preceding TL0 frame (P_DIFF=1)
+	gof := &VP9GOFInfo{
+		NumFramesInGOF:   2,
+		TemporalIdx:      []uint8{0, 1},
+		TemporalUpSwitch: []bool{false, true},
+		PDiff:            [][]uint8{{2}, {1}},
+	}
+	finder := NewVP9RefFinder()
+
+	result := finder.ManageFrame(&EncodedFrame{FrameType: FrameTypeKey}, vp9NonFlexHeader(0, 0, 0, gof))
+	require.Len(t, result, 1)
+	assert.Equal(t, 0, result[0].NumReferences)
+
+	result = finder.ManageFrame(&EncodedFrame{FrameType: FrameTypeDelta}, vp9NonFlexHeader(1, 1, 0, nil))
+	require.Len(t, result, 1)
+	assert.Equal(t, int64(1*maxVP9SpatialLayers), result[0].ID)
+	assert.Equal(t, []int64{0}, refsOf(result[0]))
+
+	result = finder.ManageFrame(&EncodedFrame{FrameType: FrameTypeDelta}, vp9NonFlexHeader(2, 0, 1, nil))
+	require.Len(t, result, 1)
+	assert.Equal(t, int64(2*maxVP9SpatialLayers), result[0].ID)
+	assert.Equal(t, []int64{0}, refsOf(result[0]))
+
+	result = finder.ManageFrame(&EncodedFrame{FrameType: FrameTypeDelta}, vp9NonFlexHeader(3, 1, 1, nil))
+	require.Len(t, result, 1)
+	assert.Equal(t, []int64{2 * maxVP9SpatialLayers}, refsOf(result[0]))
+}
+
+func TestVP9RefFinder_StashUntilGOF(t *testing.T) {
+	// Delta frame arriving before the keyframe carrying the GOF is stashed
+	finder := NewVP9RefFinder()
+
+	delta := &EncodedFrame{FrameType: FrameTypeDelta}
+	result := finder.ManageFrame(delta, vp9NonFlexHeader(1, 0, 1, nil))
+	assert.Empty(t, result, "Delta frame without GOF should be stashed")
+
+	key := &EncodedFrame{FrameType: FrameTypeKey}
+	result = finder.ManageFrame(key, vp9NonFlexHeader(0, 0, 0, newVP9GOFInfoMode1()))
+	require.Len(t, result, 2, "Keyframe and stashed delta should be returned")
+	assert.Same(t, key, result[0])
+	assert.Same(t, delta, result[1])
+	assert.Equal(t, []int64{0}, refsOf(delta))
+}
+
+func TestVP9RefFinder_MissingRequiredFrame(t *testing.T) {
+	// GOF with three temporal layers: TIDs 0,2,1,2
+	// Picture 3 (TID=2) references picture 0, so picture 2 (TID=1) must not be missing
+	gof := &VP9GOFInfo{
+		NumFramesInGOF:   4,
+		TemporalIdx:      []uint8{0, 2, 1, 2},
+		TemporalUpSwitch: []bool{false, false, false, false},
+		PDiff:            [][]uint8{{4}, {1}, {2}, {3}},
+	}
+	finder := NewVP9RefFinder()
+
+	result := finder.ManageFrame(&EncodedFrame{FrameType: FrameTypeKey}, vp9NonFlexHeader(0, 0, 0, gof))
+	require.Len(t, result, 1)
+
+	pic3 := &EncodedFrame{FrameType: FrameTypeDelta}
+	result = finder.ManageFrame(pic3, vp9NonFlexHeader(3, 2, 0, nil))
+	assert.Empty(t, result, "Frame should be stashed while a lower layer frame is missing")
+
+	pic2 := &EncodedFrame{FrameType: FrameTypeDelta}
+	result = finder.ManageFrame(pic2, vp9NonFlexHeader(2, 1, 0, nil))
+	require.Len(t, result, 2)
+	assert.Same(t, pic2, result[0])
+	assert.Same(t, pic3, result[1])
+	assert.Equal(t, []int64{0}, refsOf(pic3))
+}
+
+func TestVP9RefFinder_KeyframeWithoutSSDropped(t *testing.T) {
+	finder := NewVP9RefFinder()
+
+	result := finder.ManageFrame(&EncodedFrame{FrameType: FrameTypeKey}, vp9NonFlexHeader(0, 0, 0, nil))
+	assert.Empty(t, result)
+	assert.Empty(t, finder.stashedFrames, "Base layer keyframe without SS should be dropped")
+}
+
+func TestVP9RefFinder_ClearTo(t *testing.T) {
+	finder := NewVP9RefFinder()
+
+	finder.ManageFrame(&EncodedFrame{FrameType: FrameTypeDelta, FirstSeqNumUnwrapped: 100}, vp9NonFlexHeader(1, 0, 1, nil))
+	finder.ManageFrame(&EncodedFrame{FrameType: FrameTypeDelta, FirstSeqNumUnwrapped: 200}, vp9NonFlexHeader(2, 0, 2, nil))
+	require.Len(t, finder.stashedFrames, 2)
+
+	finder.ClearTo(150)
+	require.Len(t, finder.stashedFrames, 1)
+	assert.Equal(t, int64(200), finder.stashedFrames[0].frame.FirstSeqNumUnwrapped)
+}
+
+func TestSelectRefFinderType_VP9(t *testing.T) {
+	assert.Equal(t, RefFinderVP9, SelectRefFinderType(vp9FlexHeader(1, 0, false)))
+	assert.Equal(t, RefFinderVP9, SelectRefFinderType(vp9NonFlexHeader(1, 0, 0, nil)))
+
+	noTID := vp9NonFlexHeader(1, NoTemporalIdx, NoTL0PicIdx, nil)
+	assert.Equal(t, RefFinderFrameIDOnly, SelectRefFinderType(noTID))
+
+	noPID := vp9FlexHeader(NoPictureID, 0, false)
+	assert.Equal(t, RefFinderSeqNumOnly, SelectRefFinderType(noPID))
+}
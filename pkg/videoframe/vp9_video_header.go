@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package videoframe
+
+import (
+	"github.com/pion/rtp/codecs"
+)
+
+// maxVP9RefPics is the maximum number of reference pictures per VP9 frame.
+// Reference: libwebrtc modules/video_coding/codecs/vp9/include/vp9_globals.h kMaxVp9RefPics
+const maxVP9RefPics = 3
+
+// maxVP9FramesInGOF is the maximum number of frames in a VP9 group of frames.
+// Reference: libwebrtc vp9_globals.h kMaxVp9FramesInGof
+const maxVP9FramesInGOF = 0xFF
+
+// maxVP9SpatialLayers is the maximum number of VP9 spatial layers.
+// Reference: libwebrtc vp9_globals.h kMaxSpatialLayers
+const maxVP9SpatialLayers = 5
+
+// Picture ID widths signaled by the M bit of the VP9 payload descriptor.
+// Reference: libwebrtc modules/video_coding/codecs/interface/common_constants.h
+const (
+	maxOneBytePictureID = 0x7F
+	maxTwoBytePictureID = 0x7FFF
+)
+
+// VP9GOFInfo describes the picture group (PG) signaled in the VP9 scalability structure.
+// This structure is similar to libwebrtc's GofInfoVP9.
+type VP9GOFInfo struct {
+	// NumFramesInGOF is the number of pictures in the picture group (N_G).
+	NumFramesInGOF int
+
+	// TemporalIdx is the temporal layer index of each picture in the group.
+	TemporalIdx []uint8
+
+	// TemporalUpSwitch is the switching up point flag (U) of each picture in the group.
+	TemporalUpSwitch []bool
+
+	// PDiff holds the reference picture ID differences (P_DIFF) of each picture in the group.
+	PDiff [][]uint8
+}
+
+// RTPVideoHeaderVP9 contains VP9-specific metadata extracted from the VP9 payload descriptor.
+// This structure is similar to libwebrtc's RTPVideoHeaderVP9.
+type RTPVideoHeaderVP9 struct {
+	// InterPicPredicted is the P bit: the frame depends on previous pictures.
+	InterPicPredicted bool
+
+	// FlexibleMode is the F bit: references are signaled explicitly with P_DIFF.
+	FlexibleMode bool
+
+	// BeginningOfFrame is the B bit: first packet of a layer frame.
+	BeginningOfFrame bool
+
+	// EndOfFrame is the E bit: last packet of a layer frame.
+	EndOfFrame bool
+
+	// SSDataAvailable is the V bit: the scalability structure is present.
+	SSDataAvailable bool
+
+	// NonRefForInterLayerPred is the Z bit: the frame is not used for
+	// inter-layer prediction by upper spatial layers.
+	NonRefForInterLayerPred bool
+
+	// TemporalUpSwitch is the U bit: switching up point.
+	TemporalUpSwitch bool
+
+	// InterLayerPredicted is the D bit: the frame depends on the next lower spatial layer.
+	InterLayerPredicted bool
+
+	// PDiff holds the reference picture ID differences in flexible mode.
+	PDiff []uint8
+
+	// MaxPictureID is 0x7F for 7-bit and 0x7FFF for 15-bit picture IDs, as signaled by
+	// the M bit. Zero is treated as 15-bit.
+	MaxPictureID uint16
+
+	// NumSpatialLayers is the number of spatial layers signaled in the scalability structure.
+	NumSpatialLayers int
+
+	// GOF is the picture group signaled in the scalability structure.
+	// nil when the scalability structure is absent.
+	GOF *VP9GOFInfo
+}
+
+// NewRTPVideoHeaderFromVP9 creates an RTPVideoHeader from a VP9 packet.
+// Reference: draft-ietf-payload-vp9 - RTP Payload Format for VP9 Video
+// Reference: libwebrtc video_rtp_depacketizer_vp9.cc ParseRtpPayload()
+//
+// Each spatial layer is treated as a separate frame, delimited by the B and E bits.
+// The RTP marker bit only marks the end of the whole picture (superframe).
+func NewRTPVideoHeaderFromVP9(pkt *codecs.VP9Packet) *RTPVideoHeader {
+	header := &RTPVideoHeader{
+		Codec:       VideoCodecVP9,
+		PictureID:   NoPictureID,
+		TemporalIdx: NoTemporalIdx,
+		TL0PicIdx:   NoTL0PicIdx,
+		SpatialIdx:  NoSpatialIdx,
+	}
+
+	vp9 := &RTPVideoHeaderVP9{
+		InterPicPredicted:       pkt.P,
+		FlexibleMode:            pkt.F,
+		BeginningOfFrame:        pkt.B,
+		EndOfFrame:              pkt.E,
+		SSDataAvailable:         pkt.V,
+		NonRefForInterLayerPred: pkt.Z,
+	}
+	header.VP9 = vp9
+
+	// libwebrtc: video_header->is_first_packet_in_frame = b_bit;
+	//            video_header->is_last_packet_in_frame = e_bit;
+	header.IsFirstPacketInFrame = pkt.B
+	header.IsLastPacketInFrame = pkt.E
+
+	// libwebrtc: video_header->frame_type = p_bit ? kVideoFrameDelta : kVideoFrameKey;
+	if pkt.P {
+		header.FrameType = FrameTypeDelta
+	} else {
+		header.FrameType = FrameTypeKey
+	}
+
+	if pkt.I {
+		header.PictureID = int32(pkt.PictureID)
+	}
+
+	// Layer indices (L bit)
+	if pkt.L {
+		header.TemporalIdx = int8(pkt.TID)
+		header.SpatialIdx = int8(pkt.SID)
+		vp9.TemporalUpSwitch = pkt.U
+		vp9.InterLayerPredicted = pkt.D
+
+		// TL0PICIDX is only present in non-flexible mode
+		if !pkt.F {
+			header.TL0PicIdx = int16(pkt.TL0PICIDX)
+		}
+	}
+
+	// Reference indices (flexible mode, inter-picture predicted)
+	if pkt.F && pkt.P {
+		vp9.PDiff = append([]uint8(nil), pkt.PDiff...)
+	}
+
+	// Scalability structure (V bit)
+	if pkt.V {
+		vp9.NumSpatialLayers = int(pkt.NS) + 1
+
+		sid := 0
+		if header.SpatialIdx != NoSpatialIdx {
+			sid = int(header.SpatialIdx)
+		}
+		if pkt.Y && sid < len(pkt.Width) && sid < len(pkt.Height) {
+			header.Width = uint32(pkt.Width[sid])
+			header.Height = uint32(pkt.Height[sid])
+		}
+
+		gof := &VP9GOFInfo{
+			NumFramesInGOF:   int(pkt.NG),
+			TemporalIdx:      append([]uint8(nil), pkt.PGTID...),
+			TemporalUpSwitch: append([]bool(nil), pkt.PGU...),
+			PDiff:            make([][]uint8, len(pkt.PGPDiff)),
+		}
+		for i, pdiff := range pkt.PGPDiff {
+			gof.PDiff[i] = append([]uint8(nil), pdiff...)
+		}
+		vp9.GOF = gof
+	}
+
+	return header
+}
+
+// vp9MaxPictureID returns the largest picture ID of the width signaled by the M bit of a
+// VP9 payload descriptor with the I bit set. The codecs.VP9Packet does not keep the M bit.
+// Reference: draft-ietf-payload-vp9 Section 4.2
+func vp9MaxPictureID(payload []byte) uint16 {
+	if len(payload) > 1 && payload[1]&0x80 == 0 {
+		return maxOneBytePictureID
+	}
+
+	return maxTwoBytePictureID
+}
+
+// newVP9GOFInfoMode1 returns the GOF for a stream with a single temporal layer.
+// Reference: libwebrtc vp9_globals.h SetGofInfoVP9(kTemporalStructureMode1)
+func newVP9GOFInfoMode1() *VP9GOFInfo {
+	return &VP9GOFInfo{
+		NumFramesInGOF:   1,
+		TemporalIdx:      []uint8{0},
+		TemporalUpSwitch: []bool{false},
+		PDiff:            [][]uint8{{1}},
+	}
+}
@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package videoframe
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/pion/rtp/codecs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRTPVideoHeader_VP9_FrameBoundaries(t *testing.T) {
+	tests := []struct {
+		name      string
+		b, e      bool
+		wantFirst bool
+		wantLast  bool
+	}{
+		{name: "B=1 E=1 single packet frame", b: true, e: true, wantFirst: true, wantLast: true},
+		{name: "B=1 E=0 first packet", b: true, e: false, wantFirst: true, wantLast: false},
+		{name: "B=0 E=1 last packet", b: false, e: true, wantFirst: false, wantLast: true},
+		{name: "B=0 E=0 middle packet", b: false, e: false, wantFirst: false, wantLast: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := NewRTPVideoHeaderFromVP9(&codecs.VP9Packet{B: tt.b, E: tt.e})
+
+			require.NotNil(t, header)
+			require.NotNil(t, header.VP9)
+			assert.Equal(t, tt.wantFirst, header.IsFirstPacketInFrame)
+			assert.Equal(t, tt.wantLast, header.IsLastPacketInFrame)
+		})
+	}
+}
+
+func TestRTPVideoHeader_VP9_FrameType(t *testing.T) {
+	// libwebrtc: frame_type = p_bit ? kVideoFrameDelta : kVideoFrameKey
+	key := NewRTPVideoHeaderFromVP9(&codecs.VP9Packet{B: true, P: false})
+	assert.Equal(t, FrameTypeKey, key.FrameType)
+
+	delta := NewRTPVideoHeaderFromVP9(&codecs.VP9Packet{B: true, P: true})
+	assert.Equal(t, FrameTypeDelta, delta.FrameType)
+	assert.True(t, delta.VP9.InterPicPredicted)
+}
+
+func TestRTPVideoHeader_VP9_NoOptionalFields(t *testing.T) {
+	header := NewRTPVideoHeaderFromVP9(&codecs.VP9Packet{B: true})
+
+	assert.Equal(t, NoPictureID, header.PictureID)
+	assert.Equal(t, NoTemporalIdx, header.TemporalIdx)
+	assert.Equal(t, NoTL0PicIdx, header.TL0PicIdx)
+	assert.Equal(t, NoSpatialIdx, header.SpatialIdx)
+	assert.Nil(t, header.VP9.GOF)
+}
+
+func TestRTPVideoHeader_VP9_NonFlexibleMode(t *testing.T) {
+	pkt := &codecs.VP9Packet{
+		I:         true,
+		L:         true,
+		B:         true,
+		PictureID: 300,
+		TID:       2,
+		U:         true,
+		SID:       1,
+		D:         true,
+		TL0PICIDX: 17,
+	}
+
+	header := NewRTPVideoHeaderFromVP9(pkt)
+
+	assert.Equal(t, int32(300), header.PictureID)
+	assert.Equal(t, int8(2), header.TemporalIdx)
+	assert.Equal(t, int8(1), header.SpatialIdx)
+	assert.Equal(t, int16(17), header.TL0PicIdx)
+	assert.True(t, header.VP9.TemporalUpSwitch)
+	assert.True(t, header.VP9.InterLayerPredicted)
+	assert.False(t, header.VP9.FlexibleMode)
+}
+
+func TestRTPVideoHeader_VP9_FlexibleMode(t *testing.T) {
+	pkt := &codecs.VP9Packet{
+		I:         true,
+		P:         true,
+		L:         true,
+		F:         true,
+		B:         true,
+		PictureID: 42,
+		SID:       0,
+		PDiff:     []uint8{1, 3},
+		TL0PICIDX: 99, // Not present in flexible mode, must be ignored
+	}
+
+	header := NewRTPVideoHeaderFromVP9(pkt)
+
+	assert.True(t, header.VP9.FlexibleMode)
+	assert.Equal(t, []uint8{1, 3}, header.VP9.PDiff)
+	assert.Equal(t, NoTL0PicIdx, header.TL0PicIdx)
+
+	// PDiff must be copied so the VP9Packet can be reused
+	pkt.PDiff[0] = 7
+	assert.Equal(t, uint8(1), header.VP9.PDiff[0])
+}
+
+func TestRTPVideoHeader_VP9_ScalabilityStructure(t *testing.T) {
+	// RTP payload: I=1 L=1 B=1 E=1 V=1, PictureID=5, TID=0 SID=1, TL0PICIDX=0,
+	// SS: N_S=1 Y=1 G=1, 320x180 and 640x360, N_G=2
+	payload := []byte{
+		0xAE, 0x05, 0x02, 0x00,
+		0x38,
+		0x01, 0x40, 0x00, 0xB4,
+		0x02, 0x80, 0x01, 0x68,
+		0x02,
+		0x04, 0x02, // T=0 U=0 R=1, P_DIFF=2
+		0x34, 0x01, // T=1 U=1 R=1, P_DIFF=1
+		0xAA,
+	}
+	vp9 := &codecs.VP9Packet{}
+	_, err := vp9.Unmarshal(payload)
+	require.NoError(t, err)
+
+	header := NewRTPVideoHeaderFromVP9(vp9)
+
+	assert.True(t, header.VP9.SSDataAvailable)
+	assert.Equal(t, 2, header.VP9.NumSpatialLayers)
+	assert.Equal(t, uint32(640), header.Width, "width of the packet's spatial layer")
+	assert.Equal(t, uint32(360), header.Height, "height of the packet's spatial layer")
+
+	require.NotNil(t, header.VP9.GOF)
+	assert.Equal(t, 2, header.VP9.GOF.NumFramesInGOF)
+	assert.Equal(t, []uint8{0, 1}, header.VP9.GOF.TemporalIdx)
+	assert.Equal(t, []bool{false, true}, header.VP9.GOF.TemporalUpSwitch)
+	assert.Equal(t, [][]uint8{{2}, {1}}, header.VP9.GOF.PDiff)
+}
+
+func TestRTPVideoHeader_VP9_PictureIDWidth(t *testing.T) {
+	// I=1, B=1, E=1 followed by a 7-bit or a 15-bit picture ID
+	for _, tt := range []struct {
+		payload []byte
+		id      int32
+		max     uint16
+	}{
+		{[]byte{0x8C, 0x7F, 0xAA}, 0x7F, maxOneBytePictureID},
+		{[]byte{0x8C, 0x80 | 0x12, 0x34, 0xAA}, 0x1234, maxTwoBytePictureID},
+	} {
+		header, _, ok := depacketizeVP9(&rtp.Packet{Payload: tt.payload})
+		require.True(t, ok)
+		assert.Equal(t, tt.id, header.PictureID)
+		assert.Equal(t, tt.max, header.VP9.MaxPictureID)
+	}
+}